@@ -3,7 +3,13 @@ package main
 import (
 	"context"
 	"fmt"
+	"log"
 	"play-wails/infarstructure/db"
+	"play-wails/internal/controller"
+	"play-wails/internal/service"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 /*
@@ -12,16 +18,31 @@ import (
  * 起動はturso.goが責務を持つ
  */
 type App struct {
-	ctx context.Context
-	db  *db.TursoDB
+	ctx      context.Context
+	appCtx   *controller.AppContext
+	db       *db.TursoDB
+	recovery *service.RecoveryService
+	trash    *service.TrashService
+	pomodoro *service.PomodoroService
 }
 
+// 計測中セッションのハートビートの保存間隔
+const heartbeatInterval = 30 * time.Second
+
+// ポモドーロのフェーズの終了時刻を確認する間隔
+const pomodoroTickInterval = time.Second
+
 /*
  * アプリのインスタンスを作成
+ * appCtxは起動時にコントローラへコンテキストを共有するために使用する
  */
-func NewApp(db *db.TursoDB) *App {
+func NewApp(db *db.TursoDB, appCtx *controller.AppContext, recovery *service.RecoveryService, trash *service.TrashService, pomodoro *service.PomodoroService) *App {
 	return &App{
-		db: db,
+		db:       db,
+		appCtx:   appCtx,
+		recovery: recovery,
+		trash:    trash,
+		pomodoro: pomodoro,
 	}
 }
 
@@ -29,8 +50,52 @@ func NewApp(db *db.TursoDB) *App {
  * アプリの起動
  */
 func (a *App) startup(ctx context.Context) {
-	// コンテキストを保存
+	// コンテキストを保存し、コントローラにも共有
 	a.ctx = ctx
+	a.appCtx.Set(ctx)
+
+	// 前回の異常終了で停止されなかったセッションを復旧
+	rctx, cancel := a.appCtx.WithTimeout()
+	defer cancel()
+	orphans, err := a.recovery.Recover(rctx)
+	if err != nil {
+		log.Printf("【ERROR】停止漏れセッションの復旧に失敗しました: %v", err)
+	}
+	if len(orphans) > 0 {
+		// 確認待ちのセッションがあることをフロントに通知
+		runtime.EventsEmit(ctx, "recovery:orphans", orphans)
+	}
+
+	// 保持期間を過ぎたゴミ箱の計測結果を完全に削除
+	pctx, pcancel := a.appCtx.WithTimeout()
+	defer pcancel()
+	purged, err := a.trash.PurgeExpired(pctx)
+	if err != nil {
+		log.Printf("【ERROR】ゴミ箱の計測結果の削除に失敗しました: %v", err)
+	}
+	if purged > 0 {
+		log.Printf("【INFO】保持期間を過ぎたゴミ箱の計測結果を%d件削除しました", purged)
+	}
+
+	// 計測中セッションのハートビートを開始
+	a.recovery.StartHeartbeat(ctx, heartbeatInterval)
+
+	// ポモドーロのタイマーを開始
+	a.pomodoro.StartTimer(ctx, pomodoroTickInterval)
+}
+
+/*
+ * アプリの終了
+ * ポモドーロのタイマーとハートビートを停止し、終了時刻を保存する
+ */
+func (a *App) shutdown(ctx context.Context) {
+	a.pomodoro.Shutdown()
+
+	sctx, cancel := context.WithTimeout(ctx, heartbeatInterval)
+	defer cancel()
+	if err := a.recovery.Shutdown(sctx); err != nil {
+		log.Printf("【ERROR】終了時刻の保存に失敗しました: %v", err)
+	}
 }
 
 func (a *App) Greet(name string) string {
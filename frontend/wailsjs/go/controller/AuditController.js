@@ -0,0 +1,11 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function History(arg1) {
+  return window['go']['controller']['AuditController']['History'](arg1);
+}
+
+export function SessionHistory(arg1) {
+  return window['go']['controller']['AuditController']['SessionHistory'](arg1);
+}
@@ -0,0 +1,11 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {model} from '../models';
+
+export function Billable(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string,arg6:number,arg7:string,arg8:boolean):Promise<model.BillableSummary>;
+
+export function DeleteRate(arg1:string):Promise<void>;
+
+export function Rates(arg1:string,arg2:string):Promise<Array<model.Rate>>;
+
+export function SetRate(arg1:model.Rate):Promise<model.Rate>;
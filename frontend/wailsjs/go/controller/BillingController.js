@@ -0,0 +1,19 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function Billable(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8) {
+  return window['go']['controller']['BillingController']['Billable'](arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8);
+}
+
+export function DeleteRate(arg1) {
+  return window['go']['controller']['BillingController']['DeleteRate'](arg1);
+}
+
+export function Rates(arg1, arg2) {
+  return window['go']['controller']['BillingController']['Rates'](arg1, arg2);
+}
+
+export function SetRate(arg1) {
+  return window['go']['controller']['BillingController']['SetRate'](arg1);
+}
@@ -0,0 +1,17 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {model} from '../models';
+
+export function Archive(arg1:string):Promise<void>;
+
+export function Create(arg1:string):Promise<model.Client>;
+
+export function Delete(arg1:string):Promise<void>;
+
+export function Get(arg1:string):Promise<model.Client>;
+
+export function List(arg1:boolean):Promise<Array<model.Client>>;
+
+export function Unarchive(arg1:string):Promise<void>;
+
+export function Update(arg1:model.Client):Promise<model.Client>;
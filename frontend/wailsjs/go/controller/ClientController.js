@@ -0,0 +1,31 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function Archive(arg1) {
+  return window['go']['controller']['ClientController']['Archive'](arg1);
+}
+
+export function Create(arg1) {
+  return window['go']['controller']['ClientController']['Create'](arg1);
+}
+
+export function Delete(arg1) {
+  return window['go']['controller']['ClientController']['Delete'](arg1);
+}
+
+export function Get(arg1) {
+  return window['go']['controller']['ClientController']['Get'](arg1);
+}
+
+export function List(arg1) {
+  return window['go']['controller']['ClientController']['List'](arg1);
+}
+
+export function Unarchive(arg1) {
+  return window['go']['controller']['ClientController']['Unarchive'](arg1);
+}
+
+export function Update(arg1) {
+  return window['go']['controller']['ClientController']['Update'](arg1);
+}
@@ -0,0 +1,7 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {model} from '../models';
+
+export function Export(arg1:model.ExportOptions):Promise<string>;
+
+export function ExportCalendar(arg1:model.CalendarExportOptions):Promise<string>;
@@ -0,0 +1,11 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function Export(arg1) {
+  return window['go']['controller']['ExportController']['Export'](arg1);
+}
+
+export function ExportCalendar(arg1) {
+  return window['go']['controller']['ExportController']['ExportCalendar'](arg1);
+}
@@ -0,0 +1,9 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {model} from '../models';
+
+export function Import(arg1:string,arg2:model.ImportOptions):Promise<model.ImportResult>;
+
+export function Preview(arg1:string,arg2:model.ImportOptions):Promise<model.ImportResult>;
+
+export function SelectFile():Promise<string>;
@@ -0,0 +1,15 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function Import(arg1, arg2) {
+  return window['go']['controller']['ImportController']['Import'](arg1, arg2);
+}
+
+export function Preview(arg1, arg2) {
+  return window['go']['controller']['ImportController']['Preview'](arg1, arg2);
+}
+
+export function SelectFile() {
+  return window['go']['controller']['ImportController']['SelectFile']();
+}
@@ -0,0 +1,17 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {model} from '../models';
+
+export function Get(arg1:string):Promise<model.Invoice>;
+
+export function Issue(arg1:model.InvoiceInput):Promise<model.Invoice>;
+
+export function List(arg1:string):Promise<Array<model.Invoice>>;
+
+export function Preview(arg1:string):Promise<string>;
+
+export function SaveHTML(arg1:string):Promise<string>;
+
+export function SavePDF(arg1:string):Promise<string>;
+
+export function Void(arg1:string,arg2:string):Promise<model.Invoice>;
@@ -0,0 +1,31 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function Get(arg1) {
+  return window['go']['controller']['InvoiceController']['Get'](arg1);
+}
+
+export function Issue(arg1) {
+  return window['go']['controller']['InvoiceController']['Issue'](arg1);
+}
+
+export function List(arg1) {
+  return window['go']['controller']['InvoiceController']['List'](arg1);
+}
+
+export function Preview(arg1) {
+  return window['go']['controller']['InvoiceController']['Preview'](arg1);
+}
+
+export function SaveHTML(arg1) {
+  return window['go']['controller']['InvoiceController']['SaveHTML'](arg1);
+}
+
+export function SavePDF(arg1) {
+  return window['go']['controller']['InvoiceController']['SavePDF'](arg1);
+}
+
+export function Void(arg1, arg2) {
+  return window['go']['controller']['InvoiceController']['Void'](arg1, arg2);
+}
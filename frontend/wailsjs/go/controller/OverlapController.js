@@ -0,0 +1,7 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function Find(arg1, arg2) {
+  return window['go']['controller']['OverlapController']['Find'](arg1, arg2);
+}
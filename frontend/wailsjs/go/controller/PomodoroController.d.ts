@@ -0,0 +1,15 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {model} from '../models';
+
+export function SetSettings(arg1:model.PomodoroSettings):Promise<model.PomodoroSettings>;
+
+export function Settings():Promise<model.PomodoroSettings>;
+
+export function Skip():Promise<model.PomodoroState>;
+
+export function Start(arg1:string,arg2:string):Promise<model.PomodoroState>;
+
+export function State():Promise<model.PomodoroState>;
+
+export function Stop():Promise<model.PomodoroState>;
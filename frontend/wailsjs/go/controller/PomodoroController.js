@@ -0,0 +1,27 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function SetSettings(arg1) {
+  return window['go']['controller']['PomodoroController']['SetSettings'](arg1);
+}
+
+export function Settings() {
+  return window['go']['controller']['PomodoroController']['Settings']();
+}
+
+export function Skip() {
+  return window['go']['controller']['PomodoroController']['Skip']();
+}
+
+export function Start(arg1, arg2) {
+  return window['go']['controller']['PomodoroController']['Start'](arg1, arg2);
+}
+
+export function State() {
+  return window['go']['controller']['PomodoroController']['State']();
+}
+
+export function Stop() {
+  return window['go']['controller']['PomodoroController']['Stop']();
+}
@@ -0,0 +1,31 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function Archive(arg1) {
+  return window['go']['controller']['ProjectController']['Archive'](arg1);
+}
+
+export function Create(arg1, arg2) {
+  return window['go']['controller']['ProjectController']['Create'](arg1, arg2);
+}
+
+export function Delete(arg1) {
+  return window['go']['controller']['ProjectController']['Delete'](arg1);
+}
+
+export function Get(arg1) {
+  return window['go']['controller']['ProjectController']['Get'](arg1);
+}
+
+export function List(arg1) {
+  return window['go']['controller']['ProjectController']['List'](arg1);
+}
+
+export function Unarchive(arg1) {
+  return window['go']['controller']['ProjectController']['Unarchive'](arg1);
+}
+
+export function Update(arg1) {
+  return window['go']['controller']['ProjectController']['Update'](arg1);
+}
@@ -0,0 +1,11 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function Report(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['controller']['ReportController']['Report'](arg1, arg2, arg3, arg4, arg5, arg6);
+}
+
+export function Rollup(arg1, arg2) {
+  return window['go']['controller']['ReportController']['Rollup'](arg1, arg2);
+}
@@ -0,0 +1,11 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {db} from '../models';
+
+export function Conflicts():Promise<Array<db.SyncConflict>>;
+
+export function ResolveConflict(arg1:string,arg2:string,arg3:boolean):Promise<void>;
+
+export function Status():Promise<db.SyncStatus>;
+
+export function SyncNow():Promise<void>;
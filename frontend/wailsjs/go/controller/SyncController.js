@@ -0,0 +1,19 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function Conflicts() {
+  return window['go']['controller']['SyncController']['Conflicts']();
+}
+
+export function ResolveConflict(arg1, arg2, arg3) {
+  return window['go']['controller']['SyncController']['ResolveConflict'](arg1, arg2, arg3);
+}
+
+export function Status() {
+  return window['go']['controller']['SyncController']['Status']();
+}
+
+export function SyncNow() {
+  return window['go']['controller']['SyncController']['SyncNow']();
+}
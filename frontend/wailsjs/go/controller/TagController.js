@@ -0,0 +1,47 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function Create(arg1) {
+  return window['go']['controller']['TagController']['Create'](arg1);
+}
+
+export function Delete(arg1) {
+  return window['go']['controller']['TagController']['Delete'](arg1);
+}
+
+export function List() {
+  return window['go']['controller']['TagController']['List']();
+}
+
+export function Merge(arg1, arg2) {
+  return window['go']['controller']['TagController']['Merge'](arg1, arg2);
+}
+
+export function Rename(arg1, arg2) {
+  return window['go']['controller']['TagController']['Rename'](arg1, arg2);
+}
+
+export function TagTask(arg1, arg2) {
+  return window['go']['controller']['TagController']['TagTask'](arg1, arg2);
+}
+
+export function TagTimeRecord(arg1, arg2) {
+  return window['go']['controller']['TagController']['TagTimeRecord'](arg1, arg2);
+}
+
+export function TaskTags(arg1) {
+  return window['go']['controller']['TagController']['TaskTags'](arg1);
+}
+
+export function TimeRecordTags(arg1) {
+  return window['go']['controller']['TagController']['TimeRecordTags'](arg1);
+}
+
+export function UntagTask(arg1, arg2) {
+  return window['go']['controller']['TagController']['UntagTask'](arg1, arg2);
+}
+
+export function UntagTimeRecord(arg1, arg2) {
+  return window['go']['controller']['TagController']['UntagTimeRecord'](arg1, arg2);
+}
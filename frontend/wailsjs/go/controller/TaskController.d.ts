@@ -0,0 +1,21 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {model} from '../models';
+
+export function Archive(arg1:string):Promise<void>;
+
+export function Create(arg1:string,arg2:string):Promise<model.Task>;
+
+export function Delete(arg1:string):Promise<void>;
+
+export function Get(arg1:string):Promise<model.Task>;
+
+export function List(arg1:boolean):Promise<Array<model.Task>>;
+
+export function SetBillable(arg1:string,arg2:boolean):Promise<void>;
+
+export function SetProject(arg1:string,arg2:string):Promise<model.Task>;
+
+export function Unarchive(arg1:string):Promise<void>;
+
+export function Update(arg1:model.Task):Promise<model.Task>;
@@ -0,0 +1,39 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function Archive(arg1) {
+  return window['go']['controller']['TaskController']['Archive'](arg1);
+}
+
+export function Create(arg1, arg2) {
+  return window['go']['controller']['TaskController']['Create'](arg1, arg2);
+}
+
+export function Delete(arg1) {
+  return window['go']['controller']['TaskController']['Delete'](arg1);
+}
+
+export function Get(arg1) {
+  return window['go']['controller']['TaskController']['Get'](arg1);
+}
+
+export function List(arg1) {
+  return window['go']['controller']['TaskController']['List'](arg1);
+}
+
+export function SetBillable(arg1, arg2) {
+  return window['go']['controller']['TaskController']['SetBillable'](arg1, arg2);
+}
+
+export function SetProject(arg1, arg2) {
+  return window['go']['controller']['TaskController']['SetProject'](arg1, arg2);
+}
+
+export function Unarchive(arg1) {
+  return window['go']['controller']['TaskController']['Unarchive'](arg1);
+}
+
+export function Update(arg1) {
+  return window['go']['controller']['TaskController']['Update'](arg1);
+}
@@ -0,0 +1,17 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {model} from '../models';
+
+export function Create(arg1:model.TimeRecordInput):Promise<model.TimeRecord>;
+
+export function Delete(arg1:string,arg2:string):Promise<void>;
+
+export function Get(arg1:string):Promise<model.TimeRecord>;
+
+export function List():Promise<Array<model.TimeRecord>>;
+
+export function Query(arg1:model.TimeRecordQuery):Promise<model.TimeRecordPage>;
+
+export function SetBillable(arg1:string,arg2:boolean,arg3:string):Promise<model.TimeRecord>;
+
+export function Update(arg1:model.TimeRecordInput):Promise<model.TimeRecord>;
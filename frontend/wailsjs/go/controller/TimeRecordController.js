@@ -0,0 +1,31 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function Create(arg1) {
+  return window['go']['controller']['TimeRecordController']['Create'](arg1);
+}
+
+export function Delete(arg1, arg2) {
+  return window['go']['controller']['TimeRecordController']['Delete'](arg1, arg2);
+}
+
+export function Get(arg1) {
+  return window['go']['controller']['TimeRecordController']['Get'](arg1);
+}
+
+export function List() {
+  return window['go']['controller']['TimeRecordController']['List']();
+}
+
+export function Query(arg1) {
+  return window['go']['controller']['TimeRecordController']['Query'](arg1);
+}
+
+export function SetBillable(arg1, arg2, arg3) {
+  return window['go']['controller']['TimeRecordController']['SetBillable'](arg1, arg2, arg3);
+}
+
+export function Update(arg1) {
+  return window['go']['controller']['TimeRecordController']['Update'](arg1);
+}
@@ -0,0 +1,9 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {model} from '../models';
+
+export function List():Promise<Array<model.TrashEntry>>;
+
+export function Purge(arg1:string,arg2:string):Promise<void>;
+
+export function Restore(arg1:string,arg2:string):Promise<model.TimeRecord>;
@@ -0,0 +1,15 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function List() {
+  return window['go']['controller']['TrashController']['List']();
+}
+
+export function Purge(arg1, arg2) {
+  return window['go']['controller']['TrashController']['Purge'](arg1, arg2);
+}
+
+export function Restore(arg1, arg2) {
+  return window['go']['controller']['TrashController']['Restore'](arg1, arg2);
+}
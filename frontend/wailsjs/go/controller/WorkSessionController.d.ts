@@ -0,0 +1,31 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {model} from '../models';
+
+export function Active():Promise<model.WorkSession>;
+
+export function Cancel(arg1:string):Promise<void>;
+
+export function Complete(arg1:string):Promise<model.TimeRecord>;
+
+export function Current(arg1:string):Promise<model.WorkSession>;
+
+export function DeleteSession(arg1:string,arg2:string):Promise<void>;
+
+export function EditSession(arg1:string,arg2:string,arg3:string,arg4:string):Promise<model.WorkSession>;
+
+export function MergeSessions(arg1:string,arg2:string,arg3:string):Promise<model.WorkSession>;
+
+export function Resume(arg1:string,arg2:string):Promise<model.WorkSession>;
+
+export function Run(arg1:string):Promise<model.Run>;
+
+export function Running():Promise<Array<model.WorkSession>>;
+
+export function Sessions(arg1:string):Promise<Array<model.WorkSession>>;
+
+export function SplitSession(arg1:string,arg2:string,arg3:string,arg4:string):Promise<Array<model.WorkSession>>;
+
+export function Start(arg1:string):Promise<model.WorkSession>;
+
+export function Stop(arg1:string):Promise<void>;
@@ -0,0 +1,59 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function Active() {
+  return window['go']['controller']['WorkSessionController']['Active']();
+}
+
+export function Cancel(arg1) {
+  return window['go']['controller']['WorkSessionController']['Cancel'](arg1);
+}
+
+export function Complete(arg1) {
+  return window['go']['controller']['WorkSessionController']['Complete'](arg1);
+}
+
+export function Current(arg1) {
+  return window['go']['controller']['WorkSessionController']['Current'](arg1);
+}
+
+export function DeleteSession(arg1, arg2) {
+  return window['go']['controller']['WorkSessionController']['DeleteSession'](arg1, arg2);
+}
+
+export function EditSession(arg1, arg2, arg3, arg4) {
+  return window['go']['controller']['WorkSessionController']['EditSession'](arg1, arg2, arg3, arg4);
+}
+
+export function MergeSessions(arg1, arg2, arg3) {
+  return window['go']['controller']['WorkSessionController']['MergeSessions'](arg1, arg2, arg3);
+}
+
+export function Resume(arg1, arg2) {
+  return window['go']['controller']['WorkSessionController']['Resume'](arg1, arg2);
+}
+
+export function Run(arg1) {
+  return window['go']['controller']['WorkSessionController']['Run'](arg1);
+}
+
+export function Running() {
+  return window['go']['controller']['WorkSessionController']['Running']();
+}
+
+export function Sessions(arg1) {
+  return window['go']['controller']['WorkSessionController']['Sessions'](arg1);
+}
+
+export function SplitSession(arg1, arg2, arg3, arg4) {
+  return window['go']['controller']['WorkSessionController']['SplitSession'](arg1, arg2, arg3, arg4);
+}
+
+export function Start(arg1) {
+  return window['go']['controller']['WorkSessionController']['Start'](arg1);
+}
+
+export function Stop(arg1) {
+  return window['go']['controller']['WorkSessionController']['Stop'](arg1);
+}
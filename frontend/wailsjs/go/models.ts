@@ -0,0 +1,1423 @@
+export namespace db {
+	
+	export class SyncConflict {
+	    table_name: string;
+	    row_id: string;
+	    reason: string;
+	    // Go type: time
+	    created_at: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new SyncConflict(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.table_name = source["table_name"];
+	        this.row_id = source["row_id"];
+	        this.reason = source["reason"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SyncStatus {
+	    enabled: boolean;
+	    online: boolean;
+	    pending: number;
+	    conflicts: number;
+	    // Go type: time
+	    last_sync_at?: any;
+	    last_error: string;
+	    // Go type: time
+	    next_retry_at?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new SyncStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.online = source["online"];
+	        this.pending = source["pending"];
+	        this.conflicts = source["conflicts"];
+	        this.last_sync_at = this.convertValues(source["last_sync_at"], null);
+	        this.last_error = source["last_error"];
+	        this.next_retry_at = this.convertValues(source["next_retry_at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace model {
+	
+	export class AuditEntry {
+	    id: number[];
+	    table: string;
+	    row_id: number[];
+	    run_id: number[];
+	    action: string;
+	    before: Record<string, any>;
+	    after: Record<string, any>;
+	    reason: string;
+	    actor: string;
+	    // Go type: time
+	    created_at: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new AuditEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.table = source["table"];
+	        this.row_id = source["row_id"];
+	        this.run_id = source["run_id"];
+	        this.action = source["action"];
+	        this.before = source["before"];
+	        this.after = source["after"];
+	        this.reason = source["reason"];
+	        this.actor = source["actor"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BillableItem {
+	    date: string;
+	    task_id: number[];
+	    task_title: string;
+	    project_id?: number[];
+	    client_id?: number[];
+	    time_record_ids: number[][];
+	    duration: number;
+	    billed_duration: number;
+	    rate_id?: number[];
+	    hourly_rate: number;
+	    currency: string;
+	    amount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BillableItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.task_id = source["task_id"];
+	        this.task_title = source["task_title"];
+	        this.project_id = source["project_id"];
+	        this.client_id = source["client_id"];
+	        this.time_record_ids = source["time_record_ids"];
+	        this.duration = source["duration"];
+	        this.billed_duration = source["billed_duration"];
+	        this.rate_id = source["rate_id"];
+	        this.hourly_rate = source["hourly_rate"];
+	        this.currency = source["currency"];
+	        this.amount = source["amount"];
+	    }
+	}
+	export class BillableTotal {
+	    currency: string;
+	    billed_duration: number;
+	    amount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BillableTotal(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.currency = source["currency"];
+	        this.billed_duration = source["billed_duration"];
+	        this.amount = source["amount"];
+	    }
+	}
+	export class RoundingRule {
+	    increment: number;
+	    scope: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoundingRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.increment = source["increment"];
+	        this.scope = source["scope"];
+	    }
+	}
+	export class BillableSummary {
+	    // Go type: time
+	    from: any;
+	    // Go type: time
+	    to: any;
+	    time_zone: string;
+	    rounding: RoundingRule;
+	    items: BillableItem[];
+	    totals: BillableTotal[];
+	    non_billable: number;
+	    unrated: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BillableSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = this.convertValues(source["from"], null);
+	        this.to = this.convertValues(source["to"], null);
+	        this.time_zone = source["time_zone"];
+	        this.rounding = this.convertValues(source["rounding"], RoundingRule);
+	        this.items = this.convertValues(source["items"], BillableItem);
+	        this.totals = this.convertValues(source["totals"], BillableTotal);
+	        this.non_billable = source["non_billable"];
+	        this.unrated = source["unrated"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class CalendarExportOptions {
+	    // Go type: time
+	    from: any;
+	    // Go type: time
+	    to: any;
+	    unit: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CalendarExportOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = this.convertValues(source["from"], null);
+	        this.to = this.convertValues(source["to"], null);
+	        this.unit = source["unit"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Client {
+	    id: number[];
+	    name: string;
+	    archived: boolean;
+	    // Go type: time
+	    created_at: any;
+	    // Go type: time
+	    updated_at: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Client(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.archived = source["archived"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.updated_at = this.convertValues(source["updated_at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ExportOptions {
+	    // Go type: time
+	    from: any;
+	    // Go type: time
+	    to: any;
+	    format: string;
+	    columns: string[];
+	    duration_format: string;
+	    time_zone: string;
+	    include_sessions: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExportOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = this.convertValues(source["from"], null);
+	        this.to = this.convertValues(source["to"], null);
+	        this.format = source["format"];
+	        this.columns = source["columns"];
+	        this.duration_format = source["duration_format"];
+	        this.time_zone = source["time_zone"];
+	        this.include_sessions = source["include_sessions"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ImportEntry {
+	    line: number;
+	    task_title: string;
+	    new_task: boolean;
+	    // Go type: time
+	    start_time?: any;
+	    // Go type: time
+	    end_time?: any;
+	    duration: number;
+	    status: string;
+	    reason: string;
+	    time_record_id?: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.line = source["line"];
+	        this.task_title = source["task_title"];
+	        this.new_task = source["new_task"];
+	        this.start_time = this.convertValues(source["start_time"], null);
+	        this.end_time = this.convertValues(source["end_time"], null);
+	        this.duration = source["duration"];
+	        this.status = source["status"];
+	        this.reason = source["reason"];
+	        this.time_record_id = source["time_record_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ImportOptions {
+	    format: string;
+	    time_zone: string;
+	    task_mapping: string;
+	    date_order: string;
+	    allow_overlaps: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.format = source["format"];
+	        this.time_zone = source["time_zone"];
+	        this.task_mapping = source["task_mapping"];
+	        this.date_order = source["date_order"];
+	        this.allow_overlaps = source["allow_overlaps"];
+	    }
+	}
+	export class ImportResult {
+	    dry_run: boolean;
+	    entries: ImportEntry[];
+	    created: number;
+	    skipped: number;
+	    tasks_created: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.dry_run = source["dry_run"];
+	        this.entries = this.convertValues(source["entries"], ImportEntry);
+	        this.created = source["created"];
+	        this.skipped = source["skipped"];
+	        this.tasks_created = source["tasks_created"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class InvoiceLine {
+	    id: number[];
+	    invoice_id: number[];
+	    line_no: number;
+	    task_id: number[];
+	    description: string;
+	    billed_duration: number;
+	    hourly_rate: number;
+	    amount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new InvoiceLine(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.invoice_id = source["invoice_id"];
+	        this.line_no = source["line_no"];
+	        this.task_id = source["task_id"];
+	        this.description = source["description"];
+	        this.billed_duration = source["billed_duration"];
+	        this.hourly_rate = source["hourly_rate"];
+	        this.amount = source["amount"];
+	    }
+	}
+	export class Invoice {
+	    id: number[];
+	    number: string;
+	    client_id: number[];
+	    client_name: string;
+	    // Go type: time
+	    period_from: any;
+	    // Go type: time
+	    period_to: any;
+	    time_zone: string;
+	    currency: string;
+	    rounding: RoundingRule;
+	    total: number;
+	    status: string;
+	    // Go type: time
+	    issued_at: any;
+	    // Go type: time
+	    voided_at?: any;
+	    lines: InvoiceLine[];
+	    // Go type: time
+	    created_at: any;
+	    // Go type: time
+	    updated_at: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Invoice(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.number = source["number"];
+	        this.client_id = source["client_id"];
+	        this.client_name = source["client_name"];
+	        this.period_from = this.convertValues(source["period_from"], null);
+	        this.period_to = this.convertValues(source["period_to"], null);
+	        this.time_zone = source["time_zone"];
+	        this.currency = source["currency"];
+	        this.rounding = this.convertValues(source["rounding"], RoundingRule);
+	        this.total = source["total"];
+	        this.status = source["status"];
+	        this.issued_at = this.convertValues(source["issued_at"], null);
+	        this.voided_at = this.convertValues(source["voided_at"], null);
+	        this.lines = this.convertValues(source["lines"], InvoiceLine);
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.updated_at = this.convertValues(source["updated_at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class InvoiceInput {
+	    client_id: number[];
+	    // Go type: time
+	    from: any;
+	    // Go type: time
+	    to: any;
+	    time_zone: string;
+	    currency: string;
+	    rounding: RoundingRule;
+	
+	    static createFrom(source: any = {}) {
+	        return new InvoiceInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.client_id = source["client_id"];
+	        this.from = this.convertValues(source["from"], null);
+	        this.to = this.convertValues(source["to"], null);
+	        this.time_zone = source["time_zone"];
+	        this.currency = source["currency"];
+	        this.rounding = this.convertValues(source["rounding"], RoundingRule);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class PomodoroSettings {
+	    work: number;
+	    short_break: number;
+	    long_break: number;
+	    cycles_per_long_break: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PomodoroSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.work = source["work"];
+	        this.short_break = source["short_break"];
+	        this.long_break = source["long_break"];
+	        this.cycles_per_long_break = source["cycles_per_long_break"];
+	    }
+	}
+	export class PomodoroState {
+	    phase: string;
+	    task_id?: number[];
+	    run_id?: number[];
+	    session_id?: number[];
+	    completed_cycles: number;
+	    // Go type: time
+	    phase_started_at?: any;
+	    // Go type: time
+	    phase_ends_at?: any;
+	    settings: PomodoroSettings;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PomodoroState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.phase = source["phase"];
+	        this.task_id = source["task_id"];
+	        this.run_id = source["run_id"];
+	        this.session_id = source["session_id"];
+	        this.completed_cycles = source["completed_cycles"];
+	        this.phase_started_at = this.convertValues(source["phase_started_at"], null);
+	        this.phase_ends_at = this.convertValues(source["phase_ends_at"], null);
+	        this.settings = this.convertValues(source["settings"], PomodoroSettings);
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Project {
+	    id: number[];
+	    client_id?: number[];
+	    name: string;
+	    archived: boolean;
+	    // Go type: time
+	    created_at: any;
+	    // Go type: time
+	    updated_at: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Project(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.client_id = source["client_id"];
+	        this.name = source["name"];
+	        this.archived = source["archived"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.updated_at = this.convertValues(source["updated_at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Rate {
+	    id: number[];
+	    level: string;
+	    target_id: number[];
+	    hourly_rate: number;
+	    currency: string;
+	    // Go type: time
+	    effective_from: any;
+	    // Go type: time
+	    created_at: any;
+	    // Go type: time
+	    updated_at: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Rate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.level = source["level"];
+	        this.target_id = source["target_id"];
+	        this.hourly_rate = source["hourly_rate"];
+	        this.currency = source["currency"];
+	        this.effective_from = this.convertValues(source["effective_from"], null);
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.updated_at = this.convertValues(source["updated_at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ReportTaskTotal {
+	    task_id: number[];
+	    task_title: string;
+	    duration: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReportTaskTotal(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.task_id = source["task_id"];
+	        this.task_title = source["task_title"];
+	        this.duration = source["duration"];
+	    }
+	}
+	export class ReportPeriodTotal {
+	    key: string;
+	    // Go type: time
+	    start: any;
+	    // Go type: time
+	    end: any;
+	    duration: number;
+	    tasks: ReportTaskTotal[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ReportPeriodTotal(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.start = this.convertValues(source["start"], null);
+	        this.end = this.convertValues(source["end"], null);
+	        this.duration = source["duration"];
+	        this.tasks = this.convertValues(source["tasks"], ReportTaskTotal);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Report {
+	    // Go type: time
+	    from: any;
+	    // Go type: time
+	    to: any;
+	    time_zone: string;
+	    period: string;
+	    total: number;
+	    periods: ReportPeriodTotal[];
+	    tasks: ReportTaskTotal[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Report(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = this.convertValues(source["from"], null);
+	        this.to = this.convertValues(source["to"], null);
+	        this.time_zone = source["time_zone"];
+	        this.period = source["period"];
+	        this.total = source["total"];
+	        this.periods = this.convertValues(source["periods"], ReportPeriodTotal);
+	        this.tasks = this.convertValues(source["tasks"], ReportTaskTotal);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class RollupProjectTotal {
+	    project_id?: number[];
+	    project_name: string;
+	    archived: boolean;
+	    duration: number;
+	    tasks: ReportTaskTotal[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RollupProjectTotal(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.project_id = source["project_id"];
+	        this.project_name = source["project_name"];
+	        this.archived = source["archived"];
+	        this.duration = source["duration"];
+	        this.tasks = this.convertValues(source["tasks"], ReportTaskTotal);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RollupClientTotal {
+	    client_id?: number[];
+	    client_name: string;
+	    archived: boolean;
+	    duration: number;
+	    projects: RollupProjectTotal[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RollupClientTotal(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.client_id = source["client_id"];
+	        this.client_name = source["client_name"];
+	        this.archived = source["archived"];
+	        this.duration = source["duration"];
+	        this.projects = this.convertValues(source["projects"], RollupProjectTotal);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Rollup {
+	    // Go type: time
+	    from: any;
+	    // Go type: time
+	    to: any;
+	    total: number;
+	    clients: RollupClientTotal[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Rollup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = this.convertValues(source["from"], null);
+	        this.to = this.convertValues(source["to"], null);
+	        this.total = source["total"];
+	        this.clients = this.convertValues(source["clients"], RollupClientTotal);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
+	export class Run {
+	    id: number[];
+	    task_id: number[];
+	    status: string;
+	    // Go type: time
+	    created_at: any;
+	    // Go type: time
+	    updated_at: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Run(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.task_id = source["task_id"];
+	        this.status = source["status"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.updated_at = this.convertValues(source["updated_at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SessionOverlap {
+	    first_session_id: number[];
+	    first_run_id: number[];
+	    first_task_id: number[];
+	    first_task_title: string;
+	    second_session_id: number[];
+	    second_run_id: number[];
+	    second_task_id: number[];
+	    second_task_title: string;
+	    same_run: boolean;
+	    // Go type: time
+	    start: any;
+	    // Go type: time
+	    end: any;
+	    duration: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionOverlap(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.first_session_id = source["first_session_id"];
+	        this.first_run_id = source["first_run_id"];
+	        this.first_task_id = source["first_task_id"];
+	        this.first_task_title = source["first_task_title"];
+	        this.second_session_id = source["second_session_id"];
+	        this.second_run_id = source["second_run_id"];
+	        this.second_task_id = source["second_task_id"];
+	        this.second_task_title = source["second_task_title"];
+	        this.same_run = source["same_run"];
+	        this.start = this.convertValues(source["start"], null);
+	        this.end = this.convertValues(source["end"], null);
+	        this.duration = source["duration"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Tag {
+	    id: number[];
+	    name: string;
+	    // Go type: time
+	    created_at: any;
+	    // Go type: time
+	    updated_at: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Tag(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.updated_at = this.convertValues(source["updated_at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Task {
+	    id: number[];
+	    project_id?: number[];
+	    title: string;
+	    description: string;
+	    status: string;
+	    archived: boolean;
+	    project_archived: boolean;
+	    non_billable: boolean;
+	    // Go type: time
+	    created_at: any;
+	    // Go type: time
+	    updated_at: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Task(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.project_id = source["project_id"];
+	        this.title = source["title"];
+	        this.description = source["description"];
+	        this.status = source["status"];
+	        this.archived = source["archived"];
+	        this.project_archived = source["project_archived"];
+	        this.non_billable = source["non_billable"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.updated_at = this.convertValues(source["updated_at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TimeRecord {
+	    id: number[];
+	    run_id: number[];
+	    task_id: number[];
+	    delete_flag: boolean;
+	    // Go type: time
+	    start_time: any;
+	    // Go type: time
+	    end_time: any;
+	    duration: number;
+	    // Go type: time
+	    deleted_at?: any;
+	    non_billable: boolean;
+	    invoice_id?: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TimeRecord(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.run_id = source["run_id"];
+	        this.task_id = source["task_id"];
+	        this.delete_flag = source["delete_flag"];
+	        this.start_time = this.convertValues(source["start_time"], null);
+	        this.end_time = this.convertValues(source["end_time"], null);
+	        this.duration = source["duration"];
+	        this.deleted_at = this.convertValues(source["deleted_at"], null);
+	        this.non_billable = source["non_billable"];
+	        this.invoice_id = source["invoice_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TimeRecordInput {
+	    id: number[];
+	    task_id: number[];
+	    // Go type: time
+	    start_time: any;
+	    // Go type: time
+	    end_time: any;
+	    duration?: number;
+	    reason: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TimeRecordInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.task_id = source["task_id"];
+	        this.start_time = this.convertValues(source["start_time"], null);
+	        this.end_time = this.convertValues(source["end_time"], null);
+	        this.duration = source["duration"];
+	        this.reason = source["reason"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TimeRecordPage {
+	    records: TimeRecord[];
+	    next_cursor: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TimeRecordPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.records = this.convertValues(source["records"], TimeRecord);
+	        this.next_cursor = source["next_cursor"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TimeRecordQuery {
+	    // Go type: time
+	    from?: any;
+	    // Go type: time
+	    to?: any;
+	    task_ids: number[][];
+	    tag_ids: number[][];
+	    min_duration?: number;
+	    max_duration?: number;
+	    include_deleted: boolean;
+	    sort_field: string;
+	    descending: boolean;
+	    cursor: string;
+	    limit: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TimeRecordQuery(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = this.convertValues(source["from"], null);
+	        this.to = this.convertValues(source["to"], null);
+	        this.task_ids = source["task_ids"];
+	        this.tag_ids = source["tag_ids"];
+	        this.min_duration = source["min_duration"];
+	        this.max_duration = source["max_duration"];
+	        this.include_deleted = source["include_deleted"];
+	        this.sort_field = source["sort_field"];
+	        this.descending = source["descending"];
+	        this.cursor = source["cursor"];
+	        this.limit = source["limit"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TrashEntry {
+	    record?: TimeRecord;
+	    task_title: string;
+	    // Go type: time
+	    purge_at?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new TrashEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.record = this.convertValues(source["record"], TimeRecord);
+	        this.task_title = source["task_title"];
+	        this.purge_at = this.convertValues(source["purge_at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class WorkSession {
+	    id: number[];
+	    run_id: number[];
+	    task_id: number[];
+	    // Go type: time
+	    start_time: any;
+	    // Go type: time
+	    end_time?: any;
+	    // Go type: time
+	    heartbeat_at?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new WorkSession(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.run_id = source["run_id"];
+	        this.task_id = source["task_id"];
+	        this.start_time = this.convertValues(source["start_time"], null);
+	        this.end_time = this.convertValues(source["end_time"], null);
+	        this.heartbeat_at = this.convertValues(source["heartbeat_at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
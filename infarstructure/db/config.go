@@ -0,0 +1,129 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/joho/godotenv"
+)
+
+/*
+ * ストレージバックエンドの種類
+ */
+type Mode string
+
+const (
+	// リモートのTurso（libsql://）
+	ModeRemote Mode = "remote"
+	// ローカルのSQLiteファイル
+	ModeLocal Mode = "local"
+	// インメモリのSQLite（テスト用）
+	ModeMemory Mode = "memory"
+	// ローカルのSQLiteファイルに書き込み、バックグラウンドでTursoへ同期する
+	ModeOffline Mode = "offline"
+)
+
+// ローカルモードでパス未指定の場合のファイル名
+const defaultLocalPath = "play-wails.db"
+
+/*
+ * DB接続設定
+ */
+type Config struct {
+	Mode  Mode
+	URL   string
+	Token string
+	Path  string
+}
+
+/*
+ * 環境変数（.envを含む）からDB接続設定を読み込む
+ * DB_MODE が未指定の場合はリモート（Turso）とする
+ *
+ * @return 接続設定, エラー
+ */
+func LoadConfig() (Config, error) {
+	// .envファイルは任意（ローカル・インメモリでは不要）
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Config{}, err
+	}
+
+	cfg := Config{
+		Mode:  Mode(strings.ToLower(os.Getenv("DB_MODE"))),
+		URL:   os.Getenv("TURSO_DATABASE_URL"),
+		Token: os.Getenv("TURSO_AUTH_TOKEN"),
+		Path:  os.Getenv("DB_PATH"),
+	}
+	if cfg.Mode == "" {
+		cfg.Mode = ModeRemote
+	}
+
+	return cfg, cfg.Validate()
+}
+
+/*
+ * 接続設定の妥当性を検証する
+ *
+ * @return エラー
+ */
+func (c Config) Validate() error {
+	switch c.Mode {
+	case ModeRemote, ModeOffline:
+		if c.URL == "" || c.Token == "" {
+			return errors.New("【ERROR】TURSO_DATABASE_URL と TURSO_AUTH_TOKEN を設定してください。")
+		}
+	case ModeLocal, ModeMemory:
+	default:
+		return fmt.Errorf("【ERROR】DB_MODE が不正です: %s（remote / local / memory / offline）", c.Mode)
+	}
+	return nil
+}
+
+/*
+ * オフラインモードの同期先（Turso）の接続設定を取得する
+ *
+ * @return 同期先の接続設定
+ */
+func (c Config) RemoteConfig() Config {
+	return Config{Mode: ModeRemote, URL: c.URL, Token: c.Token}
+}
+
+/*
+ * 接続設定から使用するドライバ名とDSNを決定する
+ *
+ * @return ドライバ名, DSN
+ */
+func (c Config) driverAndDSN() (string, string) {
+	switch c.Mode {
+	case ModeLocal, ModeOffline:
+		path := c.Path
+		if path == "" {
+			path = defaultLocalPath
+		}
+		return "sqlite", CreateLocalURL(path)
+	case ModeMemory:
+		return "sqlite", "file::memory:?_pragma=foreign_keys(1)&_time_format=sqlite"
+	default:
+		return "libsql", CreateTursoURL(c.URL, c.Token)
+	}
+}
+
+/*
+ * ローカルSQLiteファイルの接続URLを作成
+ *
+ * @param path DBファイルのパス
+ * @return 接続URL
+ */
+func CreateLocalURL(path string) string {
+
+	// Strings.Builderを使用して、URLを組み立てる
+	var sb strings.Builder
+
+	sb.WriteString("file:")
+	sb.WriteString(path)
+	sb.WriteString("?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)&_time_format=sqlite")
+
+	return sb.String()
+}
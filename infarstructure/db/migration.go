@@ -0,0 +1,187 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var migrationFS embed.FS
+
+/*
+ * DBのスキーマがバイナリの知らないバージョンまで進んでいる場合のエラー
+ */
+var ErrSchemaAhead = errors.New("【ERROR】DBのスキーマがアプリより新しいバージョンです。アプリを更新してください。")
+
+/*
+ * マイグレーション1件分
+ * ファイル名は「<4桁の番号>_<名前>.sql」とする
+ */
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+/*
+ * 埋め込まれたマイグレーションをバージョン順に読み込む
+ *
+ * @return マイグレーション一覧, エラー
+ */
+func LoadMigrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFS, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]Migration, 0, len(entries))
+	seen := map[int]string{}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".sql") {
+			continue
+		}
+
+		// ファイル名からバージョンと名前を取得
+		base := strings.TrimSuffix(e.Name(), ".sql")
+		num, name, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("【ERROR】マイグレーションのファイル名が不正です: %s", e.Name())
+		}
+		version, err := strconv.Atoi(num)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("【ERROR】マイグレーションのバージョンが不正です: %s", e.Name())
+		}
+		if dup, exists := seen[version]; exists {
+			return nil, fmt.Errorf("【ERROR】マイグレーションのバージョンが重複しています: %s, %s", dup, e.Name())
+		}
+		seen[version] = e.Name()
+
+		body, err := migrationFS.ReadFile(path.Join("migrations", e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, Migration{Version: version, Name: name, SQL: string(body)})
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+	return list, nil
+}
+
+/*
+ * マイグレーション管理テーブルを存在しない場合に作成する
+ */
+func (t *TursoDB) ensureMigrationTable(ctx context.Context) error {
+	_, err := t.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    INTEGER PRIMARY KEY,
+			name       TEXT NOT NULL,
+			applied_at DATETIME NOT NULL
+		);
+	`)
+	return err
+}
+
+/*
+ * 適用済みの最新バージョンを取得する（未適用の場合は0）
+ */
+func (t *TursoDB) currentVersion(ctx context.Context) (int, error) {
+	var version sql.NullInt64
+	err := t.db.QueryRowContext(ctx, `SELECT MAX(version) FROM schema_migrations`).Scan(&version)
+	if err != nil {
+		return 0, err
+	}
+	return int(version.Int64), nil
+}
+
+/*
+ * 未適用のマイグレーション一覧を取得する（ドライラン用）
+ * DBがバイナリより新しい場合はErrSchemaAheadを返す
+ *
+ * @return 未適用のマイグレーション一覧, エラー
+ */
+func (t *TursoDB) PendingMigrations(ctx context.Context) ([]Migration, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	if err := t.ensureMigrationTable(ctx); err != nil {
+		return nil, err
+	}
+
+	current, err := t.currentVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// DBのバージョンがバイナリの最新より新しい場合は起動させない
+	latest := 0
+	if len(migrations) > 0 {
+		latest = migrations[len(migrations)-1].Version
+	}
+	if current > latest {
+		return nil, fmt.Errorf("%w (DB: %d, アプリ: %d)", ErrSchemaAhead, current, latest)
+	}
+
+	pending := make([]Migration, 0)
+	for _, m := range migrations {
+		if m.Version > current {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+/*
+ * 未適用のマイグレーションをバージョン順に適用する（前進のみ）
+ * 1件ごとにトランザクションで適用し、schema_migrationsに記録する
+ *
+ * @return 適用したマイグレーション一覧, エラー
+ */
+func (t *TursoDB) Migrate(ctx context.Context) ([]Migration, error) {
+	pending, err := t.PendingMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make([]Migration, 0, len(pending))
+	for _, m := range pending {
+		if err := t.apply(ctx, m); err != nil {
+			return applied, fmt.Errorf("【ERROR】マイグレーション %04d_%s の適用に失敗しました: %w", m.Version, m.Name, err)
+		}
+		applied = append(applied, m)
+	}
+	return applied, nil
+}
+
+/*
+ * マイグレーションを1件適用する
+ */
+func (t *TursoDB) apply(ctx context.Context, m Migration) error {
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, m.SQL); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`,
+		m.Version, m.Name, time.Now(),
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMigrate_TimeRecordsRunIDDuplicates(t *testing.T) {
+	ctx := context.Background()
+	d, err := Open(Config{Mode: ModeMemory})
+	if err != nil {
+		t.Fatalf("DBを開けません: %v", err)
+	}
+	defer d.Close()
+
+	// 重複を削除するマイグレーションの直前まで適用する
+	migrations, err := LoadMigrations()
+	if err != nil {
+		t.Fatalf("LoadMigrations() error = %v", err)
+	}
+	if err := d.ensureMigrationTable(ctx); err != nil {
+		t.Fatalf("ensureMigrationTable() error = %v", err)
+	}
+	for _, m := range migrations {
+		if m.Version >= 4 {
+			break
+		}
+		if err := d.apply(ctx, m); err != nil {
+			t.Fatalf("apply(%d) error = %v", m.Version, err)
+		}
+	}
+
+	// 同じRunIDの計測結果（2件目は編集済みのつもりで作業時間を変える）
+	start := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	rows := []struct {
+		id       string
+		runID    string
+		duration time.Duration
+	}{
+		{"record-1", "run-1", time.Hour},
+		{"record-2", "run-1", 45 * time.Minute},
+		{"record-3", "run-2", time.Hour},
+	}
+	for _, r := range rows {
+		if _, err := d.db.ExecContext(ctx,
+			`INSERT INTO time_records (id, run_id, task_id, start_time, end_time, duration_ns) VALUES (?, ?, 'task-1', ?, ?, ?)`,
+			r.id, r.runID, start, start.Add(time.Hour), r.duration.Nanoseconds(),
+		); err != nil {
+			t.Fatalf("計測結果を作成できません: %v", err)
+		}
+	}
+
+	if _, err := d.Migrate(ctx); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+
+	var remaining int
+	if err := d.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM time_records`).Scan(&remaining); err != nil {
+		t.Fatalf("件数を取得できません: %v", err)
+	}
+	if remaining != 2 {
+		t.Errorf("time_records = %d, want 2", remaining)
+	}
+
+	// 削除した行は内容ごと退避されている
+	var id string
+	var durationNs int64
+	if err := d.db.QueryRowContext(ctx,
+		`SELECT id, duration_ns FROM time_records_run_id_duplicates WHERE run_id = 'run-1'`,
+	).Scan(&id, &durationNs); err != nil {
+		t.Fatalf("退避した行を取得できません: %v", err)
+	}
+	if id != "record-2" || time.Duration(durationNs) != 45*time.Minute {
+		t.Errorf("退避した行 = {%s %v}, want {record-2 45m0s}", id, time.Duration(durationNs))
+	}
+}
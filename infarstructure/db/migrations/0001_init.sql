@@ -0,0 +1,29 @@
+-- 初期スキーマ
+-- 既存環境で作成済みのテーブルを壊さないよう IF NOT EXISTS で作成する
+CREATE TABLE IF NOT EXISTS tasks (
+	id          TEXT PRIMARY KEY,
+	title       TEXT NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	status      TEXT NOT NULL DEFAULT 'todo',
+	archived    INTEGER NOT NULL DEFAULT 0,
+	created_at  DATETIME NOT NULL,
+	updated_at  DATETIME NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS work_sessions (
+	id         TEXT PRIMARY KEY,
+	run_id     TEXT NOT NULL,
+	task_id    TEXT NOT NULL,
+	start_time TEXT NOT NULL,
+	end_time   TEXT
+);
+
+CREATE TABLE IF NOT EXISTS time_records (
+	id          TEXT PRIMARY KEY,
+	run_id      TEXT NOT NULL,
+	task_id     TEXT NOT NULL,
+	delete_flag INTEGER NOT NULL DEFAULT 0,
+	start_time  TEXT NOT NULL,
+	end_time    TEXT NOT NULL,
+	duration_ns INTEGER NOT NULL
+);
@@ -0,0 +1,29 @@
+-- 開始・終了時刻の列型をTEXTからDATETIMEに変更する
+-- ドライバは宣言型がDATETIMEの列のみtime.Timeとして読み込むため、TEXTのままではScanに失敗する
+-- SQLiteは列型を変更できないため、テーブルを作り直してデータを移す
+CREATE TABLE work_sessions_new (
+	id         TEXT PRIMARY KEY,
+	run_id     TEXT NOT NULL,
+	task_id    TEXT NOT NULL,
+	start_time DATETIME NOT NULL,
+	end_time   DATETIME
+);
+INSERT INTO work_sessions_new (id, run_id, task_id, start_time, end_time)
+	SELECT id, run_id, task_id, start_time, end_time FROM work_sessions;
+DROP TABLE work_sessions;
+ALTER TABLE work_sessions_new RENAME TO work_sessions;
+CREATE INDEX idx_work_sessions_run_id ON work_sessions (run_id);
+
+CREATE TABLE time_records_new (
+	id          TEXT PRIMARY KEY,
+	run_id      TEXT NOT NULL,
+	task_id     TEXT NOT NULL,
+	delete_flag INTEGER NOT NULL DEFAULT 0,
+	start_time  DATETIME NOT NULL,
+	end_time    DATETIME NOT NULL,
+	duration_ns INTEGER NOT NULL
+);
+INSERT INTO time_records_new (id, run_id, task_id, delete_flag, start_time, end_time, duration_ns)
+	SELECT id, run_id, task_id, delete_flag, start_time, end_time, duration_ns FROM time_records;
+DROP TABLE time_records;
+ALTER TABLE time_records_new RENAME TO time_records;
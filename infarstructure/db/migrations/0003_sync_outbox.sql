@@ -0,0 +1,21 @@
+-- オフライン時の書き込みキュー
+-- ローカルDBのトリガーで変更を記録し、同期処理がTursoへ反映する
+CREATE TABLE IF NOT EXISTS sync_outbox (
+	id              INTEGER PRIMARY KEY AUTOINCREMENT,
+	table_name      TEXT NOT NULL,
+	row_id          TEXT NOT NULL,
+	status          TEXT NOT NULL DEFAULT 'pending',
+	attempts        INTEGER NOT NULL DEFAULT 0,
+	last_error      TEXT NOT NULL DEFAULT '',
+	created_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_sync_outbox_key ON sync_outbox (table_name, row_id);
+
+-- 最後にTursoへ反映した行の内容（競合検出用）
+CREATE TABLE IF NOT EXISTS sync_state (
+	table_name  TEXT NOT NULL,
+	row_id      TEXT NOT NULL,
+	synced_hash TEXT NOT NULL,
+	synced_at   DATETIME NOT NULL,
+	PRIMARY KEY (table_name, row_id)
+);
@@ -0,0 +1,24 @@
+-- 同一RunIDの計測結果は1件のみとする
+-- 完了処理の二重実行で作成された重複は、最初に作成されたもの以外を削除する
+-- 削除する行は編集されている可能性があるため、time_records_run_id_duplicates に退避してから削除する
+CREATE TABLE IF NOT EXISTS time_records_run_id_duplicates (
+	id          TEXT PRIMARY KEY,
+	run_id      TEXT NOT NULL,
+	task_id     TEXT NOT NULL,
+	delete_flag INTEGER NOT NULL DEFAULT 0,
+	start_time  DATETIME NOT NULL,
+	end_time    DATETIME NOT NULL,
+	duration_ns INTEGER NOT NULL,
+	removed_at  DATETIME NOT NULL
+);
+INSERT INTO time_records_run_id_duplicates (id, run_id, task_id, delete_flag, start_time, end_time, duration_ns, removed_at)
+	SELECT id, run_id, task_id, delete_flag, start_time, end_time, duration_ns, strftime('%Y-%m-%d %H:%M:%f', 'now')
+	FROM time_records
+	WHERE rowid NOT IN (
+		SELECT MIN(rowid) FROM time_records GROUP BY run_id
+	);
+DELETE FROM time_records
+WHERE rowid NOT IN (
+	SELECT MIN(rowid) FROM time_records GROUP BY run_id
+);
+CREATE UNIQUE INDEX IF NOT EXISTS ux_time_records_run_id ON time_records (run_id);
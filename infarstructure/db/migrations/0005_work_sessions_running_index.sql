@@ -0,0 +1,2 @@
+-- 実行中（未停止）の作業セッション検索用
+CREATE INDEX IF NOT EXISTS idx_work_sessions_running ON work_sessions (start_time) WHERE end_time IS NULL;
@@ -0,0 +1,26 @@
+-- 計測実行（RunID）の状態を保持するテーブル
+CREATE TABLE IF NOT EXISTS runs (
+	id         TEXT PRIMARY KEY,
+	task_id    TEXT NOT NULL,
+	status     TEXT NOT NULL,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_runs_task_id ON runs (task_id);
+
+-- 既存の作業セッションから計測実行を作成する
+-- 計測結果があれば完了、未停止のセッションがあれば計測中、それ以外は一時停止
+INSERT INTO runs (id, task_id, status, created_at, updated_at)
+SELECT
+	ws.run_id
+	, MIN(ws.task_id)
+	, CASE
+		WHEN EXISTS (SELECT 1 FROM time_records tr WHERE tr.run_id = ws.run_id) THEN 'completed'
+		WHEN SUM(CASE WHEN ws.end_time IS NULL THEN 1 ELSE 0 END) > 0 THEN 'running'
+		ELSE 'paused'
+	END
+	, MIN(ws.start_time)
+	, MAX(COALESCE(ws.end_time, ws.start_time))
+FROM work_sessions ws
+WHERE NOT EXISTS (SELECT 1 FROM runs r WHERE r.id = ws.run_id)
+GROUP BY ws.run_id;
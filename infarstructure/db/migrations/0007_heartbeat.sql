@@ -0,0 +1,8 @@
+-- 計測中セッションの生存確認時刻（クラッシュ時の復旧に使用）
+ALTER TABLE work_sessions ADD COLUMN heartbeat_at DATETIME;
+
+-- アプリ全体の状態（最終終了時刻など）
+CREATE TABLE IF NOT EXISTS app_state (
+	key        TEXT PRIMARY KEY,
+	value      DATETIME NOT NULL
+);
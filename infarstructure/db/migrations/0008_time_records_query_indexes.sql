@@ -0,0 +1,6 @@
+-- 計測結果一覧の検索・キーセットページング用
+-- 時刻はタイムゾーン付き文字列のため、julianday の式インデックスで並べる
+CREATE INDEX IF NOT EXISTS idx_time_records_start ON time_records (julianday(start_time), id);
+CREATE INDEX IF NOT EXISTS idx_time_records_end ON time_records (julianday(end_time), id);
+CREATE INDEX IF NOT EXISTS idx_time_records_duration ON time_records (duration_ns, id);
+CREATE INDEX IF NOT EXISTS idx_time_records_task_start ON time_records (task_id, julianday(start_time));
@@ -0,0 +1,11 @@
+-- 取り込み済みの外部データ（同じファイルを再度取り込んでも重複させない）
+-- id は取り込み元の行の内容から作成したハッシュ
+CREATE TABLE IF NOT EXISTS import_fingerprints (
+	id             TEXT PRIMARY KEY,
+	source         TEXT NOT NULL,
+	time_record_id TEXT NOT NULL,
+	created_at     DATETIME NOT NULL
+);
+
+-- 期間と重なる作業セッションの検索用
+CREATE INDEX IF NOT EXISTS idx_work_sessions_start ON work_sessions (julianday(start_time));
@@ -0,0 +1,28 @@
+-- 計測結果・作業セッションの変更履歴（追記のみ）
+-- before_data / after_data は変更前後の内容（JSON、作成時の変更前・削除時の変更後はNULL）
+CREATE TABLE IF NOT EXISTS audit_log (
+	id          TEXT PRIMARY KEY,
+	table_name  TEXT NOT NULL,
+	row_id      TEXT NOT NULL,
+	run_id      TEXT NOT NULL,
+	action      TEXT NOT NULL,
+	before_data TEXT,
+	after_data  TEXT,
+	reason      TEXT NOT NULL DEFAULT '',
+	actor       TEXT NOT NULL DEFAULT '',
+	created_at  DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_row ON audit_log (table_name, row_id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_run ON audit_log (run_id);
+
+-- 履歴は書き換え・削除させない
+CREATE TRIGGER IF NOT EXISTS audit_log_no_update BEFORE UPDATE ON audit_log
+BEGIN
+	SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS audit_log_no_delete BEFORE DELETE ON audit_log
+BEGIN
+	SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
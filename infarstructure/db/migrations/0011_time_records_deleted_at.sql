@@ -0,0 +1,8 @@
+-- 計測結果をゴミ箱に移動した日時（保持期間を過ぎたものは完全に削除する）
+ALTER TABLE time_records ADD COLUMN deleted_at DATETIME;
+
+-- 既に論理削除済みのものは、保持期間をこの時点から数える
+UPDATE time_records SET deleted_at = CURRENT_TIMESTAMP WHERE delete_flag = 1 AND deleted_at IS NULL;
+
+-- ゴミ箱の一覧・保持期間切れの検索用
+CREATE INDEX IF NOT EXISTS idx_time_records_deleted_at ON time_records (julianday(deleted_at)) WHERE delete_flag = 1;
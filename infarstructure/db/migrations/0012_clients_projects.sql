@@ -0,0 +1,23 @@
+-- クライアント（請求先）
+CREATE TABLE IF NOT EXISTS clients (
+	id         TEXT PRIMARY KEY,
+	name       TEXT NOT NULL,
+	archived   INTEGER NOT NULL DEFAULT 0,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL
+);
+
+-- プロジェクト（client_idがNULLの場合はクライアントなし）
+CREATE TABLE IF NOT EXISTS projects (
+	id         TEXT PRIMARY KEY,
+	client_id  TEXT,
+	name       TEXT NOT NULL,
+	archived   INTEGER NOT NULL DEFAULT 0,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_projects_client_id ON projects (client_id);
+
+-- タスクの所属プロジェクト（NULLの場合はプロジェクトなし）
+ALTER TABLE tasks ADD COLUMN project_id TEXT;
+CREATE INDEX IF NOT EXISTS idx_tasks_project_id ON tasks (project_id);
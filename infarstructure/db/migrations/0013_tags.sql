@@ -0,0 +1,41 @@
+-- タグ（名前の重複は大文字・小文字を区別せずサービスで防ぐ）
+CREATE TABLE IF NOT EXISTS tags (
+	id         TEXT PRIMARY KEY,
+	name       TEXT NOT NULL,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_tags_name ON tags (name COLLATE NOCASE);
+
+-- タスクのタグ
+-- id は「task_id:tag_id」（複数の端末で同じタグを付けても同期で重複させない）
+CREATE TABLE IF NOT EXISTS task_tags (
+	id         TEXT PRIMARY KEY,
+	task_id    TEXT NOT NULL,
+	tag_id     TEXT NOT NULL,
+	created_at DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_task_tags_task_id ON task_tags (task_id);
+CREATE INDEX IF NOT EXISTS idx_task_tags_tag_id ON task_tags (tag_id);
+
+-- 計測結果のタグ
+-- id は「time_record_id:tag_id」
+CREATE TABLE IF NOT EXISTS time_record_tags (
+	id             TEXT PRIMARY KEY,
+	time_record_id TEXT NOT NULL,
+	tag_id         TEXT NOT NULL,
+	created_at     DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_time_record_tags_time_record_id ON time_record_tags (time_record_id);
+CREATE INDEX IF NOT EXISTS idx_time_record_tags_tag_id ON time_record_tags (tag_id);
+
+-- タスク・計測結果を物理削除したらタグ付けも削除する
+CREATE TRIGGER IF NOT EXISTS task_tags_cleanup AFTER DELETE ON tasks
+BEGIN
+	DELETE FROM task_tags WHERE task_id = OLD.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS time_record_tags_cleanup AFTER DELETE ON time_records
+BEGIN
+	DELETE FROM time_record_tags WHERE time_record_id = OLD.id;
+END;
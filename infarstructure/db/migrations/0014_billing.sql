@@ -0,0 +1,17 @@
+-- 請求対象外のタスク・計測結果（既定は請求対象）
+ALTER TABLE tasks ADD COLUMN non_billable INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE time_records ADD COLUMN non_billable INTEGER NOT NULL DEFAULT 0;
+
+-- 時間単価（クライアント・プロジェクト・タスクのいずれかに設定、effective_from以降に適用）
+-- hourly_rate は通貨の最小単位（円・セントなど）の整数
+CREATE TABLE IF NOT EXISTS rates (
+	id             TEXT PRIMARY KEY,
+	level          TEXT NOT NULL,
+	target_id      TEXT NOT NULL,
+	hourly_rate    INTEGER NOT NULL,
+	currency       TEXT NOT NULL,
+	effective_from DATETIME NOT NULL,
+	created_at     DATETIME NOT NULL,
+	updated_at     DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_rates_target ON rates (level, target_id, julianday(effective_from));
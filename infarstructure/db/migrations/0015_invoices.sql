@@ -0,0 +1,40 @@
+-- 請求書（numberは発行年ごとの連番、取り消しても番号は再利用しない）
+-- client_name は発行時のクライアント名、total は通貨の最小単位の整数
+CREATE TABLE IF NOT EXISTS invoices (
+	id                    TEXT PRIMARY KEY,
+	number                TEXT NOT NULL,
+	client_id             TEXT NOT NULL,
+	client_name           TEXT NOT NULL,
+	period_from           DATETIME NOT NULL,
+	period_to             DATETIME NOT NULL,
+	time_zone             TEXT NOT NULL,
+	currency              TEXT NOT NULL,
+	rounding_increment_ns INTEGER NOT NULL DEFAULT 0,
+	rounding_scope        TEXT NOT NULL DEFAULT 'record',
+	total                 INTEGER NOT NULL,
+	status                TEXT NOT NULL,
+	issued_at             DATETIME NOT NULL,
+	voided_at             DATETIME,
+	created_at            DATETIME NOT NULL,
+	updated_at            DATETIME NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_number ON invoices (number);
+CREATE INDEX IF NOT EXISTS idx_invoices_client_id ON invoices (client_id);
+
+-- 請求書の明細（タスク・単価ごと）
+CREATE TABLE IF NOT EXISTS invoice_lines (
+	id                 TEXT PRIMARY KEY,
+	invoice_id         TEXT NOT NULL,
+	line_no            INTEGER NOT NULL,
+	task_id            TEXT NOT NULL,
+	description        TEXT NOT NULL,
+	billed_duration_ns INTEGER NOT NULL,
+	hourly_rate        INTEGER NOT NULL,
+	amount             INTEGER NOT NULL,
+	created_at         DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_invoice_lines_invoice_id ON invoice_lines (invoice_id, line_no);
+
+-- 請求済みの計測結果（NULLの場合は未請求）
+ALTER TABLE time_records ADD COLUMN invoice_id TEXT;
+CREATE INDEX IF NOT EXISTS idx_time_records_invoice_id ON time_records (invoice_id);
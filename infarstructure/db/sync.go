@@ -0,0 +1,849 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 同期対象のテーブル（参照される側を先に並べる）
+var syncTables = []string{"clients", "projects", "tasks", "tags", "task_tags", "rates", "invoices", "invoice_lines", "runs", "work_sessions", "time_records", "time_record_tags", "import_fingerprints", "audit_log"}
+
+const (
+	// 同期の間隔
+	syncInterval = 30 * time.Second
+	// 失敗時の再試行間隔の初期値・上限
+	syncRetryBase = 5 * time.Second
+	syncRetryMax  = 5 * time.Minute
+	// 1回の同期処理（マイグレーション・送信・取り込みの1ページ）のタイムアウト
+	syncTimeout = 30 * time.Second
+	// 初回の取り込みで1回の同期に使う時間の目安（超えた分は次回に続きから取り込む）
+	syncPullBudget = 30 * time.Second
+	// 初回の取り込みで1回に読み込む行数
+	syncPullPageSize = 500
+)
+
+/*
+ * 同期状態（UI表示用）
+ */
+type SyncStatus struct {
+	Enabled     bool       `json:"enabled"`
+	Online      bool       `json:"online"`
+	Pending     int        `json:"pending"`
+	Conflicts   int        `json:"conflicts"`
+	LastSyncAt  *time.Time `json:"last_sync_at"`
+	LastError   string     `json:"last_error"`
+	NextRetryAt *time.Time `json:"next_retry_at"`
+}
+
+/*
+ * 同期時に競合した行
+ * Turso側の行が最後の同期以降に別の端末で変更されている場合に発生する
+ */
+type SyncConflict struct {
+	TableName string    `json:"table_name"`
+	RowID     string    `json:"row_id"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+/*
+ * ローカルDBの変更をTursoへ同期する
+ * 変更はトリガーでsync_outboxに記録され、バックグラウンドで再試行しながら反映する
+ */
+type Syncer struct {
+	local  *TursoDB
+	remote *TursoDB
+
+	// 同期処理の排他
+	runMu       sync.Mutex
+	remoteReady bool
+	pulled      bool
+	// 初回の取り込みの進捗（取り込み中のテーブルと、取り込み済みの最後のID）
+	pullTable int
+	pullAfter string
+
+	// 状態の排他
+	mu          sync.Mutex
+	online      bool
+	failures    int
+	lastSyncAt  *time.Time
+	lastError   string
+	nextRetryAt *time.Time
+
+	trigger chan struct{}
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// 同期対象の1行分（列名と値）
+type syncRow struct {
+	cols []string
+	vals []any
+}
+
+// 同期待ちのキー
+type outboxKey struct {
+	table  string
+	rowID  string
+	lastID int64
+}
+
+/*
+ * 同期処理のインスタンスを作成
+ * オフラインモードのローカルDBを受け取り、同期先のTursoを開く
+ *
+ * @param local ローカルDB
+ * @return インスタンス, エラー
+ */
+func NewSyncer(local *TursoDB) (*Syncer, error) {
+	if local.Mode() != ModeOffline {
+		return nil, errors.New("【ERROR】同期処理はオフラインモードでのみ使用できます。")
+	}
+
+	remote, err := Open(local.cfg.RemoteConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Syncer{
+		local:   local,
+		remote:  remote,
+		trigger: make(chan struct{}, 1),
+	}, nil
+}
+
+/*
+ * 変更記録用のトリガーを作成し、バックグラウンドの同期を開始する
+ *
+ * @param ctx コンテキスト
+ * @return エラー
+ */
+func (s *Syncer) Start(ctx context.Context) error {
+	if err := s.installTriggers(ctx); err != nil {
+		return err
+	}
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.done = make(chan struct{})
+	go s.loop(ctx)
+
+	return nil
+}
+
+/*
+ * バックグラウンドの同期を停止し、同期先との接続を閉じる
+ */
+func (s *Syncer) Stop() error {
+	if s.cancel != nil {
+		s.cancel()
+		<-s.done
+	}
+	return s.remote.Close()
+}
+
+/*
+ * 次回の同期を待たずに同期を要求する
+ */
+func (s *Syncer) Notify() {
+	select {
+	case s.trigger <- struct{}{}:
+	default:
+	}
+}
+
+/*
+ * 同期処理のループ
+ * 失敗した場合は指数バックオフで再試行する
+ */
+func (s *Syncer) loop(ctx context.Context) {
+	defer close(s.done)
+
+	wait := time.Duration(0)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		case <-s.trigger:
+		}
+
+		err := s.SyncNow(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("【WARN】Tursoへの同期に失敗しました: %v", err)
+		}
+		wait = s.nextWait()
+	}
+}
+
+/*
+ * 直近の結果から次回の同期までの待ち時間を決める
+ */
+func (s *Syncer) nextWait() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failures == 0 {
+		s.nextRetryAt = nil
+		return syncInterval
+	}
+
+	wait := syncRetryBase << min(s.failures-1, 10)
+	if wait > syncRetryMax {
+		wait = syncRetryMax
+	}
+	next := time.Now().Add(wait)
+	s.nextRetryAt = &next
+	return wait
+}
+
+/*
+ * 同期を1回実行する
+ * 初回はTursoのマイグレーションとローカルへの取り込みを行う
+ *
+ * @param ctx コンテキスト
+ * @return エラー
+ */
+func (s *Syncer) SyncNow(ctx context.Context) error {
+	s.runMu.Lock()
+	defer s.runMu.Unlock()
+
+	err := s.sync(ctx)
+	s.record(err)
+	return err
+}
+
+func (s *Syncer) sync(ctx context.Context) error {
+	// 同期先のスキーマをローカルと揃える
+	if !s.remoteReady {
+		mctx, cancel := context.WithTimeout(ctx, syncTimeout)
+		_, err := s.remote.Migrate(mctx)
+		cancel()
+		if err != nil {
+			return err
+		}
+		s.remoteReady = true
+	}
+
+	// ローカルの変更は初回の取り込みが終わるのを待たずに反映する
+	// （未同期の変更がある行は取り込みの対象外のため、順序によらず結果は同じ）
+	pctx, cancel := context.WithTimeout(ctx, syncTimeout)
+	err := s.push(pctx)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	// 起動後初回のみTurso側のデータをローカルに取り込む
+	if !s.pulled {
+		return s.pullAll(ctx)
+	}
+	return nil
+}
+
+/*
+ * Turso側のデータをテーブルごと・ID順にページ単位で取り込む
+ * 1回の同期で syncPullBudget を超えた場合は進捗を残して中断し、すぐに続きを取り込む
+ */
+func (s *Syncer) pullAll(ctx context.Context) error {
+	deadline := time.Now().Add(syncPullBudget)
+	for s.pullTable < len(syncTables) {
+		if time.Now().After(deadline) {
+			s.Notify()
+			return nil
+		}
+
+		table := syncTables[s.pullTable]
+		pctx, cancel := context.WithTimeout(ctx, syncTimeout)
+		last, n, err := s.pull(pctx, table, s.pullAfter)
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if n < syncPullPageSize {
+			s.pullTable++
+			s.pullAfter = ""
+			continue
+		}
+		s.pullAfter = last
+	}
+	s.pulled = true
+	return nil
+}
+
+/*
+ * 同期結果を状態に反映する
+ */
+func (s *Syncer) record(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err != nil {
+		s.online = false
+		s.failures++
+		s.lastError = err.Error()
+		return
+	}
+
+	now := time.Now()
+	s.online = true
+	s.failures = 0
+	s.lastError = ""
+	s.lastSyncAt = &now
+}
+
+/*
+ * 同期状態を取得する
+ *
+ * @param ctx コンテキスト
+ * @return 同期状態, エラー
+ */
+func (s *Syncer) Status(ctx context.Context) (SyncStatus, error) {
+	var pending, conflicts int
+	err := s.local.db.QueryRowContext(ctx, `
+		SELECT
+			COUNT(DISTINCT CASE WHEN status = 'pending' THEN table_name || ':' || row_id END)
+			, COUNT(DISTINCT CASE WHEN status = 'conflict' THEN table_name || ':' || row_id END)
+		FROM sync_outbox`,
+	).Scan(&pending, &conflicts)
+	if err != nil {
+		return SyncStatus{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return SyncStatus{
+		Enabled:     true,
+		Online:      s.online,
+		Pending:     pending,
+		Conflicts:   conflicts,
+		LastSyncAt:  s.lastSyncAt,
+		LastError:   s.lastError,
+		NextRetryAt: s.nextRetryAt,
+	}, nil
+}
+
+/*
+ * 競合中の行の一覧を取得する
+ *
+ * @param ctx コンテキスト
+ * @return 競合一覧, エラー
+ */
+func (s *Syncer) Conflicts(ctx context.Context) ([]SyncConflict, error) {
+	rows, err := s.local.db.QueryContext(ctx, `
+		SELECT table_name, row_id, last_error, created_at, MIN(id) AS first_id
+		FROM sync_outbox
+		WHERE status = 'conflict'
+		GROUP BY table_name, row_id
+		ORDER BY first_id`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]SyncConflict, 0)
+	for rows.Next() {
+		var c SyncConflict
+		var firstID int64
+		if err := rows.Scan(&c.TableName, &c.RowID, &c.Reason, &c.CreatedAt, &firstID); err != nil {
+			return nil, err
+		}
+		list = append(list, c)
+	}
+	return list, rows.Err()
+}
+
+/*
+ * 競合を解消する
+ *
+ * @param ctx コンテキスト
+ * @param table テーブル名
+ * @param rowID 行ID
+ * @param keepLocal true のときローカルの内容でTursoを上書き、false のときTursoの内容でローカルを上書き
+ * @return エラー
+ */
+func (s *Syncer) ResolveConflict(ctx context.Context, table string, rowID string, keepLocal bool) error {
+	if !isSyncTable(table) {
+		return fmt.Errorf("【ERROR】同期対象外のテーブルです: %s", table)
+	}
+
+	s.runMu.Lock()
+	defer s.runMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, syncTimeout)
+	defer cancel()
+
+	if keepLocal {
+		local, err := readSyncRow(ctx, s.local.db, table, rowID)
+		if err != nil {
+			return err
+		}
+		if err := s.writeRemote(ctx, table, rowID, local); err != nil {
+			return err
+		}
+		return s.finish(ctx, outboxKey{table: table, rowID: rowID, lastID: -1}, local)
+	}
+
+	remote, err := readSyncRow(ctx, s.remote.db, table, rowID)
+	if err != nil {
+		return err
+	}
+	return s.writeLocal(ctx, table, rowID, remote)
+}
+
+/*
+ * ローカルの変更をTursoへ反映する
+ */
+func (s *Syncer) push(ctx context.Context) error {
+	keys, err := s.pendingKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := s.pushKey(ctx, key); err != nil {
+			// 失敗した行に試行回数とエラーを記録して中断（次回再試行）
+			_, _ = s.local.db.ExecContext(ctx, `
+				UPDATE sync_outbox SET attempts = attempts + 1, last_error = ?
+				WHERE table_name = ? AND row_id = ? AND id <= ?`,
+				err.Error(), key.table, key.rowID, key.lastID,
+			)
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+ * 同期待ちのキーを古い順に取得する（競合中のキーは除く）
+ */
+func (s *Syncer) pendingKeys(ctx context.Context) ([]outboxKey, error) {
+	rows, err := s.local.db.QueryContext(ctx, `
+		SELECT table_name, row_id, MAX(id)
+		FROM sync_outbox
+		GROUP BY table_name, row_id
+		HAVING SUM(CASE WHEN status = 'conflict' THEN 1 ELSE 0 END) = 0
+		ORDER BY MIN(id)`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make([]outboxKey, 0)
+	for rows.Next() {
+		var k outboxKey
+		if err := rows.Scan(&k.table, &k.rowID, &k.lastID); err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+/*
+ * 1行分の変更をTursoへ反映する
+ * 最後の同期以降にTurso側が変更されていた場合は競合として保留する
+ */
+func (s *Syncer) pushKey(ctx context.Context, key outboxKey) error {
+	local, err := readSyncRow(ctx, s.local.db, key.table, key.rowID)
+	if err != nil {
+		return err
+	}
+	remote, err := readSyncRow(ctx, s.remote.db, key.table, key.rowID)
+	if err != nil {
+		return err
+	}
+	synced, err := s.syncedHash(ctx, key.table, key.rowID)
+	if err != nil {
+		return err
+	}
+
+	// 競合検出
+	if reason := detectConflict(local, remote, synced); reason != "" {
+		_, err := s.local.db.ExecContext(ctx, `
+			UPDATE sync_outbox SET status = 'conflict', last_error = ?
+			WHERE table_name = ? AND row_id = ? AND id <= ?`,
+			reason, key.table, key.rowID, key.lastID,
+		)
+		return err
+	}
+
+	if err := s.writeRemote(ctx, key.table, key.rowID, local); err != nil {
+		return err
+	}
+	return s.finish(ctx, key, local)
+}
+
+/*
+ * 競合の有無を判定する
+ *
+ * @param local ローカルの行（削除済みの場合はnil）
+ * @param remote Tursoの行（存在しない場合はnil）
+ * @param synced 最後に同期した内容のハッシュ（未同期の場合は空）
+ * @return 競合理由（競合なしの場合は空）
+ */
+func detectConflict(local *syncRow, remote *syncRow, synced string) string {
+	if remote == nil {
+		if synced != "" && local != nil {
+			return "同期後にTurso側で削除されています"
+		}
+		return ""
+	}
+
+	remoteHash := remote.hash()
+	if synced == "" {
+		// 未同期の行と同じIDの行が既にTursoに存在する
+		if local == nil || remoteHash != local.hash() {
+			return "同じIDの行がTurso側に既に存在します"
+		}
+		return ""
+	}
+	if remoteHash != synced {
+		return "同期後にTurso側で変更されています"
+	}
+	return ""
+}
+
+/*
+ * 同期済みとして記録し、反映した分の変更記録を削除する
+ *
+ * @param key 同期したキー（lastIDが負の場合は全件）
+ * @param row 反映した内容（削除の場合はnil）
+ */
+func (s *Syncer) finish(ctx context.Context, key outboxKey, row *syncRow) error {
+	tx, err := s.local.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := setSyncedHash(ctx, tx, key.table, key.rowID, row); err != nil {
+		return err
+	}
+
+	query := `DELETE FROM sync_outbox WHERE table_name = ? AND row_id = ?`
+	args := []any{key.table, key.rowID}
+	if key.lastID >= 0 {
+		query += ` AND id <= ?`
+		args = append(args, key.lastID)
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+/*
+ * Turso側のデータを1ページ分ローカルに取り込む
+ * ローカルに未同期の変更がある行は取り込まない
+ *
+ * @param ctx コンテキスト
+ * @param table テーブル名
+ * @param after 前回のページの最後のID（先頭から取り込む場合は空）
+ * @return ページの最後のID, 読み込んだ行数, エラー
+ */
+func (s *Syncer) pull(ctx context.Context, table string, after string) (string, int, error) {
+	remoteRows, err := readSyncRowsAfter(ctx, s.remote.db, table, after, syncPullPageSize)
+	if err != nil {
+		return "", 0, err
+	}
+
+	last := after
+	for _, remote := range remoteRows {
+		rowID := remote.id()
+		last = rowID
+
+		// ローカルに未同期の変更がある場合はpushで扱う
+		var pending int
+		err := s.local.db.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM sync_outbox WHERE table_name = ? AND row_id = ?`,
+			table, rowID,
+		).Scan(&pending)
+		if err != nil {
+			return "", 0, err
+		}
+		if pending > 0 {
+			continue
+		}
+
+		local, err := readSyncRow(ctx, s.local.db, table, rowID)
+		if err != nil {
+			return "", 0, err
+		}
+		synced, err := s.syncedHash(ctx, table, rowID)
+		if err != nil {
+			return "", 0, err
+		}
+
+		switch {
+		case local == nil && synced == "":
+			// ローカルに存在しない行を取り込む
+		case local != nil && local.hash() == remote.hash():
+			// 内容が同じ場合は同期済みとして記録するのみ
+			if synced != local.hash() {
+				if err := setSyncedHash(ctx, s.local.db, table, rowID, local); err != nil {
+					return "", 0, err
+				}
+			}
+			continue
+		case local != nil && local.hash() == synced:
+			// ローカル未変更のままTurso側が更新されている
+		default:
+			continue
+		}
+
+		if err := s.writeLocal(ctx, table, rowID, remote); err != nil {
+			return "", 0, err
+		}
+	}
+	return last, len(remoteRows), nil
+}
+
+/*
+ * Tursoに行を書き込む（rowがnilの場合は削除）
+ */
+func (s *Syncer) writeRemote(ctx context.Context, table string, rowID string, row *syncRow) error {
+	if row == nil {
+		_, err := s.remote.db.ExecContext(ctx, `DELETE FROM `+table+` WHERE id = ?`, rowID)
+		return err
+	}
+	_, err := s.remote.db.ExecContext(ctx, upsertQuery(table, row.cols), row.vals...)
+	return err
+}
+
+/*
+ * ローカルに行を書き込む（rowがnilの場合は削除）
+ * トリガーで記録された変更は同じトランザクション内で取り消す
+ */
+func (s *Syncer) writeLocal(ctx context.Context, table string, rowID string, row *syncRow) error {
+	tx, err := s.local.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if row == nil {
+		_, err = tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE id = ?`, rowID)
+	} else {
+		_, err = tx.ExecContext(ctx, upsertQuery(table, row.cols), row.vals...)
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM sync_outbox WHERE table_name = ? AND row_id = ?`,
+		table, rowID,
+	); err != nil {
+		return err
+	}
+	if err := setSyncedHash(ctx, tx, table, rowID, row); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+/*
+ * 最後に同期した内容のハッシュを取得する（未同期の場合は空）
+ */
+func (s *Syncer) syncedHash(ctx context.Context, table string, rowID string) (string, error) {
+	var hash string
+	err := s.local.db.QueryRowContext(ctx,
+		`SELECT synced_hash FROM sync_state WHERE table_name = ? AND row_id = ?`,
+		table, rowID,
+	).Scan(&hash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return hash, err
+}
+
+/*
+ * 同期テーブルの変更をsync_outboxに記録するトリガーを作成する
+ * Turso側に不要なキューが溜まらないよう、マイグレーションではなくローカルにのみ作成する
+ */
+func (s *Syncer) installTriggers(ctx context.Context) error {
+	for _, table := range syncTables {
+		for _, ev := range []struct{ name, ref string }{
+			{"INSERT", "NEW"},
+			{"UPDATE", "NEW"},
+			{"DELETE", "OLD"},
+		} {
+			query := fmt.Sprintf(`
+				CREATE TRIGGER IF NOT EXISTS sync_%s_%s AFTER %s ON %s
+				BEGIN
+					INSERT INTO sync_outbox (table_name, row_id) VALUES ('%s', %s.id);
+				END;`,
+				table, strings.ToLower(ev.name), ev.name, table, table, ev.ref,
+			)
+			if _, err := s.local.db.ExecContext(ctx, query); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// database/sqlの*sql.DBと*sql.Txの共通部分
+type syncExecer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+/*
+ * 同期済みの内容を記録する（rowがnilの場合は記録を削除）
+ */
+func setSyncedHash(ctx context.Context, db syncExecer, table string, rowID string, row *syncRow) error {
+	if row == nil {
+		_, err := db.ExecContext(ctx,
+			`DELETE FROM sync_state WHERE table_name = ? AND row_id = ?`,
+			table, rowID,
+		)
+		return err
+	}
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO sync_state (table_name, row_id, synced_hash, synced_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT (table_name, row_id) DO UPDATE SET synced_hash = excluded.synced_hash, synced_at = excluded.synced_at`,
+		table, rowID, row.hash(), time.Now(),
+	)
+	return err
+}
+
+/*
+ * 1行を列名付きで読み込む（存在しない場合はnil）
+ */
+func readSyncRow(ctx context.Context, db syncExecer, table string, rowID string) (*syncRow, error) {
+	rows, err := querySyncRows(ctx, db, `SELECT * FROM `+table+` WHERE id = ?`, rowID)
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+	return rows[0], nil
+}
+
+/*
+ * テーブルの行を指定したIDの次からID順に列名付きで読み込む
+ */
+func readSyncRowsAfter(ctx context.Context, db syncExecer, table string, after string, limit int) ([]*syncRow, error) {
+	return querySyncRows(ctx, db, `SELECT * FROM `+table+` WHERE id > ? ORDER BY id LIMIT ?`, after, limit)
+}
+
+func querySyncRows(ctx context.Context, db syncExecer, query string, args ...any) ([]*syncRow, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*syncRow, 0)
+	for rows.Next() {
+		vals := make([]any, len(cols))
+		ptrs := make([]any, len(cols))
+		for i := range vals {
+			ptrs[i] = &vals[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		list = append(list, &syncRow{cols: cols, vals: vals})
+	}
+	return list, rows.Err()
+}
+
+/*
+ * 行のIDを取得する
+ */
+func (r *syncRow) id() string {
+	for i, c := range r.cols {
+		if c == "id" {
+			return normalizeSyncValue(r.vals[i])
+		}
+	}
+	return ""
+}
+
+/*
+ * 行の内容のハッシュ
+ * ドライバ間で表現が異なる値（時刻・バイト列）は正規化して比較する
+ */
+func (r *syncRow) hash() string {
+	idx := make([]int, len(r.cols))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(a, b int) bool { return r.cols[idx[a]] < r.cols[idx[b]] })
+
+	h := sha256.New()
+	for _, i := range idx {
+		fmt.Fprintf(h, "%s=%s\n", r.cols[i], normalizeSyncValue(r.vals[i]))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func normalizeSyncValue(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return "\x00"
+	case time.Time:
+		return x.UTC().Format(time.RFC3339Nano)
+	case []byte:
+		return string(x)
+	case int64:
+		return strconv.FormatInt(x, 10)
+	case float64:
+		return strconv.FormatFloat(x, 'g', -1, 64)
+	case bool:
+		if x {
+			return "1"
+		}
+		return "0"
+	default:
+		return fmt.Sprint(x)
+	}
+}
+
+/*
+ * IDをキーにしたUPSERT文を作成する
+ */
+func upsertQuery(table string, cols []string) string {
+	placeholders := make([]string, len(cols))
+	updates := make([]string, 0, len(cols))
+	for i, c := range cols {
+		placeholders[i] = "?"
+		if c != "id" {
+			updates = append(updates, c+" = excluded."+c)
+		}
+	}
+	return fmt.Sprintf(
+		`INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (id) DO UPDATE SET %s`,
+		table, strings.Join(cols, ", "), strings.Join(placeholders, ", "), strings.Join(updates, ", "),
+	)
+}
+
+func isSyncTable(table string) bool {
+	for _, t := range syncTables {
+		if t == table {
+			return true
+		}
+	}
+	return false
+}
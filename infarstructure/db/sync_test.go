@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+/*
+ * テスト用にマイグレーション済みのインメモリDBを開く
+ */
+func openTestDB(t *testing.T) *TursoDB {
+	t.Helper()
+	d, err := Open(Config{Mode: ModeMemory})
+	if err != nil {
+		t.Fatalf("DBを開けません: %v", err)
+	}
+	t.Cleanup(func() { d.Close() })
+	if _, err := d.Migrate(context.Background()); err != nil {
+		t.Fatalf("マイグレーションに失敗しました: %v", err)
+	}
+	return d
+}
+
+func insertTestTask(t *testing.T, d *TursoDB, id string) {
+	t.Helper()
+	now := time.Now()
+	if _, err := d.db.Exec(`INSERT INTO tasks (id, title, created_at, updated_at) VALUES (?, ?, ?, ?)`, id, id, now, now); err != nil {
+		t.Fatalf("タスクを作成できません: %v", err)
+	}
+}
+
+func countRows(t *testing.T, d *TursoDB, query string) int {
+	t.Helper()
+	var n int
+	if err := d.db.QueryRow(query).Scan(&n); err != nil {
+		t.Fatalf("件数を取得できません: %v", err)
+	}
+	return n
+}
+
+func TestSyncer_SyncNow_PullsInPagesAndPushes(t *testing.T) {
+	ctx := context.Background()
+	local, remote := openTestDB(t), openTestDB(t)
+	s := &Syncer{local: local, remote: remote, trigger: make(chan struct{}, 1)}
+	if err := s.installTriggers(ctx); err != nil {
+		t.Fatalf("installTriggers() error = %v", err)
+	}
+
+	// ページの境界をまたぐ件数をTurso側に用意する
+	remoteCount := syncPullPageSize*2 + 1
+	for i := 0; i < remoteCount; i++ {
+		insertTestTask(t, remote, fmt.Sprintf("remote-%05d", i))
+	}
+	// ローカルの未同期の変更
+	insertTestTask(t, local, "local-00001")
+
+	if err := s.SyncNow(ctx); err != nil {
+		t.Fatalf("SyncNow() error = %v", err)
+	}
+
+	if !s.pulled {
+		t.Error("pulled = false, want true")
+	}
+	if got := countRows(t, local, `SELECT COUNT(*) FROM tasks`); got != remoteCount+1 {
+		t.Errorf("ローカルのタスク = %d, want %d", got, remoteCount+1)
+	}
+	if got := countRows(t, remote, `SELECT COUNT(*) FROM tasks`); got != remoteCount+1 {
+		t.Errorf("Turso側のタスク = %d, want %d", got, remoteCount+1)
+	}
+	if got := countRows(t, local, `SELECT COUNT(*) FROM sync_outbox`); got != 0 {
+		t.Errorf("同期待ち = %d, want 0", got)
+	}
+}
+
+func TestSyncer_SyncNow_PushesBeforePullFinishes(t *testing.T) {
+	ctx := context.Background()
+	local, remote := openTestDB(t), openTestDB(t)
+	s := &Syncer{local: local, remote: remote, trigger: make(chan struct{}, 1)}
+	if err := s.installTriggers(ctx); err != nil {
+		t.Fatalf("installTriggers() error = %v", err)
+	}
+	insertTestTask(t, local, "local-00001")
+
+	// 取り込みが完了していなくても、ローカルの変更は送信する
+	// （取り込みに失敗するよう、Turso側のテーブルを壊しておく）
+	if _, err := remote.db.Exec(`DROP TABLE audit_log`); err != nil {
+		t.Fatalf("テーブルを削除できません: %v", err)
+	}
+	s.remoteReady = true
+
+	if err := s.SyncNow(ctx); err == nil {
+		t.Fatal("SyncNow() error = nil, want error")
+	}
+	if s.pulled {
+		t.Error("pulled = true, want false")
+	}
+	if got := countRows(t, remote, `SELECT COUNT(*) FROM tasks WHERE id = 'local-00001'`); got != 1 {
+		t.Errorf("Turso側のタスク = %d, want 1", got)
+	}
+}
@@ -9,44 +9,65 @@ import (
 
 	"github.com/joho/godotenv"
 	_ "github.com/tursodatabase/libsql-client-go/libsql"
+	_ "modernc.org/sqlite"
 )
 
 /*
  * TursoDBの構造体
  */
 type TursoDB struct {
-	db *sql.DB
+	db  *sql.DB
+	cfg Config
 }
 
 /*
  * TursoDBのインスタンスを作成
+ * 環境変数の DB_MODE でリモート・ローカル・インメモリを切り替える
  *
  * @return *sql.DB, error
  */
 func NewTursoDB() (*TursoDB, error) {
 
-	// .envファイルから環境変数を取り込む
-	url, token, err := LoadEnv()
+	// .envファイル・環境変数から接続設定を取り込む
+	cfg, err := LoadConfig()
 	if err != nil {
-		log.Fatal("【ERROR】.envファイルの読み込みに失敗しました")
+		log.Println("【ERROR】DB接続設定の読み込みに失敗しました")
 		return nil, err
 	}
 
-	// 接続URLの作成
-	dsn := CreateTursoURL(url, token)
+	return Open(cfg)
+}
+
+/*
+ * 接続設定を指定してDBを開く
+ *
+ * @param cfg 接続設定
+ * @return *TursoDB, error
+ */
+func Open(cfg Config) (*TursoDB, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
 
 	// DB接続
-	db, err := sql.Open("libsql", dsn)
+	driver, dsn := cfg.driverAndDSN()
+	db, err := sql.Open(driver, dsn)
 	if err != nil {
-		log.Fatal("【ERROR】DB接続に失敗しました")
+		log.Println("【ERROR】DB接続に失敗しました")
 		return nil, err
 	}
 
-	// 最大接続数・最大空き接続数を設定
-	db.SetMaxOpenConns(1)
-	db.SetMaxIdleConns(1)
+	// 最大接続数・最大空き接続数を設定（リモートは制限しない）
+	// インメモリは接続ごとにDBが分かれるため、接続を1本に固定して破棄させない
+	// ローカルのSQLiteファイルは書き込みを直列にするため、同じく1本にする
+	switch cfg.Mode {
+	case ModeMemory, ModeLocal, ModeOffline:
+		db.SetMaxOpenConns(1)
+		db.SetMaxIdleConns(1)
+		db.SetConnMaxLifetime(0)
+	}
 
-	return &TursoDB{db: db}, nil
+	return &TursoDB{db: db, cfg: cfg}, nil
 }
 
 /*
@@ -57,40 +78,17 @@ func (t *TursoDB) DB() *sql.DB {
 }
 
 /*
- * TursoDBのインスタンスをクローズ
+ * 使用中のストレージバックエンドを取得
  */
-func (t *TursoDB) Close() error {
-	return t.db.Close()
+func (t *TursoDB) Mode() Mode {
+	return t.cfg.Mode
 }
 
 /*
- * work_sessions/time_recordsテーブルを存在しない場合に作成する
+ * TursoDBのインスタンスをクローズ
  */
-func (t *TursoDB) Migrate(ctx context.Context) error {
-	_, err := t.db.ExecContext(ctx, `
-		CREATE TABLE IF NOT EXISTS work_sessions (
-			id         TEXT PRIMARY KEY,
-			run_id     TEXT NOT NULL,
-			task_id    TEXT NOT NULL,
-			start_time TEXT NOT NULL,
-			end_time   TEXT
-		);
-	`)
-	if err != nil {
-		return err
-	}
-	_, err = t.db.ExecContext(ctx, `
-		CREATE TABLE IF NOT EXISTS time_records (
-			id          TEXT PRIMARY KEY,
-			run_id      TEXT NOT NULL,
-			task_id     TEXT NOT NULL,
-			delete_flag INTEGER NOT NULL DEFAULT 0,
-			start_time  TEXT NOT NULL,
-			end_time    TEXT NOT NULL,
-			duration_ns INTEGER NOT NULL
-		);
-	`)
-	return err
+func (t *TursoDB) Close() error {
+	return t.db.Close()
 }
 
 /*
@@ -0,0 +1,31 @@
+package db
+
+import (
+	"testing"
+)
+
+func TestOpen_MaxOpenConns(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want int
+	}{
+		{name: "インメモリは1本", cfg: Config{Mode: ModeMemory}, want: 1},
+		{name: "ローカルは1本", cfg: Config{Mode: ModeLocal, Path: t.TempDir() + "/local.db"}, want: 1},
+		{name: "オフラインのローカルは1本", cfg: Config{Mode: ModeOffline, Path: t.TempDir() + "/offline.db", URL: "libsql://example.turso.io", Token: "token"}, want: 1},
+		{name: "リモートは制限しない", cfg: Config{Mode: ModeRemote, URL: "libsql://example.turso.io", Token: "token"}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := Open(tt.cfg)
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			defer d.Close()
+			if got := d.DB().Stats().MaxOpenConnections; got != tt.want {
+				t.Errorf("MaxOpenConnections = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
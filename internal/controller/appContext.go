@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+/*
+ * AppContext はWailsのランタイムコンテキストを保持する
+ * コントローラはここから呼び出しごとのタイムアウト付きコンテキストを取得する
+ * （コントローラに埋め込むとメソッドがフロントにバインドされるため、フィールドとして保持する）
+ */
+type AppContext struct {
+	mu      sync.RWMutex
+	ctx     context.Context
+	timeout time.Duration
+}
+
+/*
+ * インスタンス生成
+ *
+ * @param timeout 1回の呼び出しのタイムアウト
+ * @return インスタンス
+ */
+func NewAppContext(timeout time.Duration) *AppContext {
+	return &AppContext{ctx: context.Background(), timeout: timeout}
+}
+
+/*
+ * Wailsの起動時に渡されたコンテキストを保存する
+ *
+ * @param ctx コンテキスト
+ */
+func (a *AppContext) Set(ctx context.Context) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.ctx = ctx
+}
+
+/*
+ * Wailsのランタイムコンテキストを取得する（ダイアログ・イベント用）
+ *
+ * @return コンテキスト
+ */
+func (a *AppContext) Context() context.Context {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.ctx
+}
+
+/*
+ * 呼び出しごとのタイムアウト付きコンテキストを取得する
+ * アプリ終了時はランタイムコンテキストのキャンセルが伝播する
+ *
+ * @return コンテキスト, キャンセル関数
+ */
+func (a *AppContext) WithTimeout() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(a.Context(), a.timeout)
+}
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"play-wails/internal/model"
+	"play-wails/internal/service"
+
+	"github.com/google/uuid"
+)
+
+type AuditController struct {
+	appCtx       *AppContext
+	auditService *service.AuditService
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param appCtx Wailsのランタイムコンテキスト
+ * @param auditService 変更履歴サービス
+ * @return インスタンス
+ */
+func NewAuditController(appCtx *AppContext, auditService *service.AuditService) *AuditController {
+	return &AuditController{appCtx: appCtx, auditService: auditService}
+}
+
+/*
+ * 計測結果の変更履歴を取得する（作業セッションの変更を含む）
+ *
+ * @param recordID 計測結果ID（UUID文字列）
+ * @return 変更履歴, エラー
+ */
+func (c *AuditController) History(recordID string) ([]*model.AuditEntry, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 計測結果IDをUUIDに変換
+	id, err := uuid.Parse(recordID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.auditService.History(ctx, id)
+}
+
+/*
+ * 作業セッションの変更履歴を取得する
+ *
+ * @param sessionID 作業セッションID（UUID文字列）
+ * @return 変更履歴, エラー
+ */
+func (c *AuditController) SessionHistory(sessionID string) ([]*model.AuditEntry, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 作業セッションIDをUUIDに変換
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.auditService.SessionHistory(ctx, id)
+}
@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"play-wails/internal/model"
+	"play-wails/internal/service"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BillingController struct {
+	appCtx         *AppContext
+	billingService *service.BillingService
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param appCtx Wailsのランタイムコンテキスト
+ * @param billingService 請求サービス
+ * @return インスタンス
+ */
+func NewBillingController(appCtx *AppContext, billingService *service.BillingService) *BillingController {
+	return &BillingController{appCtx: appCtx, billingService: billingService}
+}
+
+/*
+ * 時間単価を設定する（適用開始日時の異なる単価として追加する）
+ *
+ * @param rate 時間単価（階層・対象・単価・通貨・適用開始日時）
+ * @return 時間単価, エラー
+ */
+func (c *BillingController) SetRate(rate *model.Rate) (*model.Rate, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	return c.billingService.SetRate(ctx, rate)
+}
+
+/*
+ * 対象に設定された時間単価の一覧を取得する
+ *
+ * @param level 階層（client / project / task）
+ * @param targetID 対象のID（UUID文字列）
+ * @return 時間単価一覧, エラー
+ */
+func (c *BillingController) Rates(level string, targetID string) ([]*model.Rate, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 対象のIDをUUIDに変換
+	uid, err := uuid.Parse(targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.billingService.Rates(ctx, model.RateLevel(level), uid)
+}
+
+/*
+ * 時間単価を削除する
+ *
+ * @param id 時間単価ID（UUID文字列）
+ * @return エラー
+ */
+func (c *BillingController) DeleteRate(id string) error {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 時間単価IDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return err
+	}
+
+	return c.billingService.DeleteRate(ctx, uid)
+}
+
+/*
+ * 期間内の請求額を計算する
+ *
+ * @param from 期間の開始（RFC3339文字列、含む）
+ * @param to 期間の終了（RFC3339文字列、含まない）
+ * @param timeZone タイムゾーン名（空の場合はローカル）
+ * @param clientID 絞り込むクライアントID（UUID文字列、空の場合は絞り込まない）
+ * @param projectID 絞り込むプロジェクトID（UUID文字列、空の場合は絞り込まない）
+ * @param roundingMinutes 丸めの間隔（分、0の場合は丸めない）
+ * @param roundingScope 丸めの単位（record / day）
+ * @param uninvoicedOnly 請求済みの計測結果を除外するか
+ * @return 計算結果, エラー
+ */
+func (c *BillingController) Billable(from string, to string, timeZone string, clientID string, projectID string, roundingMinutes int, roundingScope string, uninvoicedOnly bool) (*model.BillableSummary, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 期間を時刻に変換
+	f, err := time.Parse(time.RFC3339, from)
+	if err != nil {
+		return nil, err
+	}
+	t, err := time.Parse(time.RFC3339, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// クライアントID・プロジェクトIDをUUIDに変換
+	cid, err := parseOptionalUUID(clientID)
+	if err != nil {
+		return nil, err
+	}
+	pid, err := parseOptionalUUID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.billingService.Billable(ctx, service.BillingQuery{
+		From:           f,
+		To:             t,
+		TimeZone:       timeZone,
+		ClientID:       cid,
+		ProjectID:      pid,
+		UninvoicedOnly: uninvoicedOnly,
+		Rounding: model.RoundingRule{
+			Increment: time.Duration(roundingMinutes) * time.Minute,
+			Scope:     model.RoundingScope(roundingScope),
+		},
+	})
+}
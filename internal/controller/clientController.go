@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"play-wails/internal/model"
+	"play-wails/internal/service"
+
+	"github.com/google/uuid"
+)
+
+type ClientController struct {
+	appCtx        *AppContext
+	clientService *service.ClientService
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param appCtx Wailsのランタイムコンテキスト
+ * @param clientService クライアントサービス
+ * @return インスタンス
+ */
+func NewClientController(appCtx *AppContext, clientService *service.ClientService) *ClientController {
+	return &ClientController{appCtx: appCtx, clientService: clientService}
+}
+
+/*
+ * クライアントを新規作成する
+ *
+ * @param name 名前
+ * @return クライアント, エラー
+ */
+func (c *ClientController) Create(name string) (*model.Client, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	return c.clientService.Create(ctx, name)
+}
+
+/*
+ * 指定IDのクライアントを取得する
+ *
+ * @param id クライアントID（UUID文字列）
+ * @return クライアント, エラー
+ */
+func (c *ClientController) Get(id string) (*model.Client, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// クライアントIDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.clientService.Get(ctx, uid)
+}
+
+/*
+ * クライアント一覧を取得する
+ *
+ * @param includeArchived true のときアーカイブ済みも含める
+ * @return クライアント一覧, エラー
+ */
+func (c *ClientController) List(includeArchived bool) ([]*model.Client, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	return c.clientService.List(ctx, includeArchived)
+}
+
+/*
+ * クライアントを更新する
+ *
+ * @param client クライアント
+ * @return 更新後のクライアント, エラー
+ */
+func (c *ClientController) Update(client *model.Client) (*model.Client, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	return c.clientService.Update(ctx, client)
+}
+
+/*
+ * クライアントをアーカイブする
+ *
+ * @param id クライアントID（UUID文字列）
+ * @return エラー
+ */
+func (c *ClientController) Archive(id string) error {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// クライアントIDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return err
+	}
+
+	return c.clientService.SetArchived(ctx, uid, true)
+}
+
+/*
+ * クライアントのアーカイブを解除する
+ *
+ * @param id クライアントID（UUID文字列）
+ * @return エラー
+ */
+func (c *ClientController) Unarchive(id string) error {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// クライアントIDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return err
+	}
+
+	return c.clientService.SetArchived(ctx, uid, false)
+}
+
+/*
+ * クライアントを削除する
+ *
+ * @param id クライアントID（UUID文字列）
+ * @return エラー
+ */
+func (c *ClientController) Delete(id string) error {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// クライアントIDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return err
+	}
+
+	return c.clientService.Delete(ctx, uid)
+}
@@ -0,0 +1,21 @@
+package controller
+
+import (
+	"errors"
+	"play-wails/internal/model"
+)
+
+/*
+ * フロントに返すエラーの形式を決める（WailsのErrorFormatter）
+ * 入力チェックのエラーは項目ごとのエラーを含むオブジェクト、それ以外はメッセージの文字列
+ *
+ * @param err エラー
+ * @return フロントに返す値
+ */
+func FormatError(err error) interface{} {
+	var v *model.ValidationError
+	if errors.As(err, &v) {
+		return v
+	}
+	return err.Error()
+}
@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"play-wails/internal/model"
+	"play-wails/internal/service"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+type ExportController struct {
+	appCtx        *AppContext
+	exportService *service.ExportService
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param appCtx Wailsのランタイムコンテキスト
+ * @param exportService エクスポートサービス
+ * @return インスタンス
+ */
+func NewExportController(appCtx *AppContext, exportService *service.ExportService) *ExportController {
+	return &ExportController{appCtx: appCtx, exportService: exportService}
+}
+
+/*
+ * 計測結果をエクスポートし、保存ダイアログで選択したファイルに保存する
+ * ダイアログでキャンセルした場合は空文字を返す
+ *
+ * @param opts エクスポートの条件
+ * @return 保存したファイルのパス, エラー
+ */
+func (c *ExportController) Export(opts model.ExportOptions) (string, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// ダイアログの操作中にタイムアウトしないよう、先にメモリ上に書き出す
+	var buf bytes.Buffer
+	if _, err := c.exportService.Export(ctx, &buf, opts); err != nil {
+		return "", err
+	}
+
+	// 保存先を選択
+	ext := string(model.ExportFormatCSV)
+	name := "CSV (*.csv)"
+	if opts.Format == model.ExportFormatJSONL {
+		ext = string(model.ExportFormatJSONL)
+		name = "JSON Lines (*.jsonl)"
+	}
+	path, err := runtime.SaveFileDialog(c.appCtx.Context(), runtime.SaveDialogOptions{
+		Title:           "計測結果のエクスポート",
+		DefaultFilename: fmt.Sprintf("time_records_%s_%s.%s", opts.From.Format("20060102"), opts.To.Format("20060102"), ext),
+		Filters: []runtime.FileFilter{
+			{DisplayName: name, Pattern: "*." + ext},
+		},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+/*
+ * 作業セッションまたは計測結果をiCalendar（.ics）で書き出し、保存ダイアログで選択したファイルに保存する
+ * ダイアログでキャンセルした場合は空文字を返す
+ *
+ * @param opts エクスポートの条件
+ * @return 保存したファイルのパス, エラー
+ */
+func (c *ExportController) ExportCalendar(opts model.CalendarExportOptions) (string, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// ダイアログの操作中にタイムアウトしないよう、先にメモリ上に書き出す
+	var buf bytes.Buffer
+	if _, err := c.exportService.ExportCalendar(ctx, &buf, opts); err != nil {
+		return "", err
+	}
+
+	// 保存先を選択
+	path, err := runtime.SaveFileDialog(c.appCtx.Context(), runtime.SaveDialogOptions{
+		Title:           "カレンダーのエクスポート",
+		DefaultFilename: fmt.Sprintf("work_sessions_%s_%s.ics", opts.From.Format("20060102"), opts.To.Format("20060102")),
+		Filters: []runtime.FileFilter{
+			{DisplayName: "iCalendar (*.ics)", Pattern: "*.ics"},
+		},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
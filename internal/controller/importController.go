@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"os"
+	"play-wails/internal/model"
+	"play-wails/internal/service"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+type ImportController struct {
+	appCtx        *AppContext
+	importService *service.ImportService
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param appCtx Wailsのランタイムコンテキスト
+ * @param importService 取り込みサービス
+ * @return インスタンス
+ */
+func NewImportController(appCtx *AppContext, importService *service.ImportService) *ImportController {
+	return &ImportController{appCtx: appCtx, importService: importService}
+}
+
+/*
+ * 取り込むファイルをダイアログで選択する
+ * ダイアログでキャンセルした場合は空文字を返す
+ *
+ * @return 選択したファイルのパス, エラー
+ */
+func (c *ImportController) SelectFile() (string, error) {
+	return runtime.OpenFileDialog(c.appCtx.Context(), runtime.OpenDialogOptions{
+		Title: "取り込むファイルの選択",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "CSV (*.csv)", Pattern: "*.csv"},
+		},
+	})
+}
+
+/*
+ * 取り込み内容を確認する（何も保存しない）
+ *
+ * @param path ファイルのパス
+ * @param opts 取り込みの条件
+ * @return 作成・スキップされる予定の内容, エラー
+ */
+func (c *ImportController) Preview(path string, opts model.ImportOptions) (*model.ImportResult, error) {
+	// 長い履歴のファイルは通常の呼び出しのタイムアウトに収まらないため、アプリ終了時のみキャンセルする
+	ctx, cancel := context.WithCancel(c.appCtx.Context())
+	defer cancel()
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return c.importService.Preview(ctx, f, opts)
+}
+
+/*
+ * ファイルを取り込む
+ *
+ * @param path ファイルのパス
+ * @param opts 取り込みの条件
+ * @return 取り込み結果, エラー
+ */
+func (c *ImportController) Import(path string, opts model.ImportOptions) (*model.ImportResult, error) {
+	// 長い履歴のファイルは通常の呼び出しのタイムアウトに収まらないため、アプリ終了時のみキャンセルする
+	ctx, cancel := context.WithCancel(c.appCtx.Context())
+	defer cancel()
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return c.importService.Import(ctx, f, opts)
+}
@@ -0,0 +1,198 @@
+package controller
+
+import (
+	"bytes"
+	"os"
+	"play-wails/internal/model"
+	"play-wails/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+type InvoiceController struct {
+	appCtx         *AppContext
+	invoiceService *service.InvoiceService
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param appCtx Wailsのランタイムコンテキスト
+ * @param invoiceService 請求書サービス
+ * @return インスタンス
+ */
+func NewInvoiceController(appCtx *AppContext, invoiceService *service.InvoiceService) *InvoiceController {
+	return &InvoiceController{appCtx: appCtx, invoiceService: invoiceService}
+}
+
+/*
+ * クライアントの期間内の未請求の計測結果から請求書を発行する
+ * 請求書に含めた計測結果は、請求書を取り消すまで編集・削除できない
+ *
+ * @param in 入力内容（クライアントID・期間・タイムゾーン・通貨・丸め）
+ * @return 請求書, エラー
+ */
+func (c *InvoiceController) Issue(in model.InvoiceInput) (*model.Invoice, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	return c.invoiceService.Issue(ctx, in)
+}
+
+/*
+ * 請求書を取り消し、含まれていた計測結果を編集できるようにする
+ *
+ * @param id 請求書ID（UUID文字列）
+ * @param reason 取消理由（省略可）
+ * @return 請求書, エラー
+ */
+func (c *InvoiceController) Void(id string, reason string) (*model.Invoice, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 請求書IDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.invoiceService.Void(ctx, uid, reason)
+}
+
+/*
+ * 指定IDの請求書を明細付きで取得する
+ *
+ * @param id 請求書ID（UUID文字列）
+ * @return 請求書, エラー
+ */
+func (c *InvoiceController) Get(id string) (*model.Invoice, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 請求書IDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.invoiceService.Get(ctx, uid)
+}
+
+/*
+ * 請求書一覧を取得する（明細は含まない）
+ *
+ * @param clientID 絞り込むクライアントID（UUID文字列、空の場合は絞り込まない）
+ * @return 請求書一覧, エラー
+ */
+func (c *InvoiceController) List(clientID string) ([]*model.Invoice, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// クライアントIDをUUIDに変換
+	cid, err := parseOptionalUUID(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.invoiceService.List(ctx, cid)
+}
+
+/*
+ * 請求書をHTMLで取得する（画面でのプレビュー用）
+ *
+ * @param id 請求書ID（UUID文字列）
+ * @return HTML, エラー
+ */
+func (c *InvoiceController) Preview(id string) (string, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 請求書IDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if _, err := c.invoiceService.RenderHTML(ctx, &buf, uid); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+/*
+ * 請求書をHTMLで書き出し、保存ダイアログで選択したファイルに保存する
+ * ダイアログでキャンセルした場合は空文字を返す
+ *
+ * @param id 請求書ID（UUID文字列）
+ * @return 保存したファイルのパス, エラー
+ */
+func (c *InvoiceController) SaveHTML(id string) (string, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 請求書IDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return "", err
+	}
+
+	// ダイアログの操作中にタイムアウトしないよう、先にメモリ上に書き出す
+	var buf bytes.Buffer
+	invoice, err := c.invoiceService.RenderHTML(ctx, &buf, uid)
+	if err != nil {
+		return "", err
+	}
+
+	return c.save(invoice, buf.Bytes(), "html", "HTML (*.html)")
+}
+
+/*
+ * 請求書をPDFで書き出し、保存ダイアログで選択したファイルに保存する
+ * ダイアログでキャンセルした場合は空文字を返す
+ *
+ * @param id 請求書ID（UUID文字列）
+ * @return 保存したファイルのパス, エラー
+ */
+func (c *InvoiceController) SavePDF(id string) (string, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 請求書IDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return "", err
+	}
+
+	// ダイアログの操作中にタイムアウトしないよう、先にメモリ上に書き出す
+	var buf bytes.Buffer
+	invoice, err := c.invoiceService.RenderPDF(ctx, &buf, uid)
+	if err != nil {
+		return "", err
+	}
+
+	return c.save(invoice, buf.Bytes(), "pdf", "PDF (*.pdf)")
+}
+
+/*
+ * 保存ダイアログで選択したファイルに書き出す
+ */
+func (c *InvoiceController) save(invoice *model.Invoice, data []byte, ext string, name string) (string, error) {
+	// 保存先を選択
+	path, err := runtime.SaveFileDialog(c.appCtx.Context(), runtime.SaveDialogOptions{
+		Title:           "請求書の保存",
+		DefaultFilename: invoice.Number + "." + ext,
+		Filters: []runtime.FileFilter{
+			{DisplayName: name, Pattern: "*." + ext},
+		},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"play-wails/internal/model"
+	"play-wails/internal/service"
+	"time"
+)
+
+type OverlapController struct {
+	appCtx         *AppContext
+	overlapService *service.OverlapService
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param appCtx Wailsのランタイムコンテキスト
+ * @param overlapService 重なり検出サービス
+ * @return インスタンス
+ */
+func NewOverlapController(appCtx *AppContext, overlapService *service.OverlapService) *OverlapController {
+	return &OverlapController{appCtx: appCtx, overlapService: overlapService}
+}
+
+/*
+ * 期間内の作業セッションの重なりを取得する
+ *
+ * @param from 期間の開始（RFC3339文字列、含む）
+ * @param to 期間の終了（RFC3339文字列、含まない）
+ * @return 重なり一覧, エラー
+ */
+func (c *OverlapController) Find(from string, to string) ([]*model.SessionOverlap, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 期間を時刻に変換
+	f, err := time.Parse(time.RFC3339, from)
+	if err != nil {
+		return nil, err
+	}
+	t, err := time.Parse(time.RFC3339, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.overlapService.Find(ctx, f, t)
+}
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"play-wails/internal/model"
+	"play-wails/internal/service"
+
+	"github.com/google/uuid"
+)
+
+type PomodoroController struct {
+	appCtx          *AppContext
+	pomodoroService *service.PomodoroService
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param appCtx Wailsのランタイムコンテキスト
+ * @param pomodoroService ポモドーロサービス
+ * @return インスタンス
+ */
+func NewPomodoroController(appCtx *AppContext, pomodoroService *service.PomodoroService) *PomodoroController {
+	return &PomodoroController{appCtx: appCtx, pomodoroService: pomodoroService}
+}
+
+/*
+ * ポモドーロの設定を取得する
+ *
+ * @return 設定
+ */
+func (c *PomodoroController) Settings() model.PomodoroSettings {
+	return c.pomodoroService.Settings()
+}
+
+/*
+ * ポモドーロの設定を変更する（実行中の場合は次のフェーズから適用）
+ *
+ * @param settings 設定（作業・短い休憩・長い休憩の長さ、長い休憩までの回数）
+ * @return 設定, エラー
+ */
+func (c *PomodoroController) SetSettings(settings model.PomodoroSettings) (model.PomodoroSettings, error) {
+	return c.pomodoroService.SetSettings(settings)
+}
+
+/*
+ * ポモドーロの現在の状態を取得する
+ * フェーズが変わるたびに "pomodoro:phase" イベントでも通知する
+ *
+ * @return 状態
+ */
+func (c *PomodoroController) State() model.PomodoroState {
+	return c.pomodoroService.State()
+}
+
+/*
+ * ポモドーロを開始する
+ *
+ * @param taskID タスクID（UUID文字列）
+ * @param runID 再開する計測実行のグループID（UUID文字列、空の場合は新規に計測を開始）
+ * @return 状態, エラー
+ */
+func (c *PomodoroController) Start(taskID string, runID string) (model.PomodoroState, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// タスクID・計測実行IDをUUIDに変換
+	tid, err := uuid.Parse(taskID)
+	if err != nil {
+		return model.PomodoroState{}, err
+	}
+	rid, err := parseOptionalUUID(runID)
+	if err != nil {
+		return model.PomodoroState{}, err
+	}
+
+	return c.pomodoroService.Start(ctx, tid, rid)
+}
+
+/*
+ * ポモドーロを終了する（作業中の場合は作業セッションを停止）
+ *
+ * @return 状態, エラー
+ */
+func (c *PomodoroController) Stop() (model.PomodoroState, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	return c.pomodoroService.Stop(ctx)
+}
+
+/*
+ * 現在のフェーズを終了し、次のフェーズに進む
+ *
+ * @return 状態, エラー
+ */
+func (c *PomodoroController) Skip() (model.PomodoroState, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	return c.pomodoroService.Skip(ctx)
+}
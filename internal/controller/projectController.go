@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"play-wails/internal/model"
+	"play-wails/internal/service"
+
+	"github.com/google/uuid"
+)
+
+type ProjectController struct {
+	appCtx         *AppContext
+	projectService *service.ProjectService
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param appCtx Wailsのランタイムコンテキスト
+ * @param projectService プロジェクトサービス
+ * @return インスタンス
+ */
+func NewProjectController(appCtx *AppContext, projectService *service.ProjectService) *ProjectController {
+	return &ProjectController{appCtx: appCtx, projectService: projectService}
+}
+
+/*
+ * プロジェクトを新規作成する
+ *
+ * @param name 名前
+ * @param clientID クライアントID（UUID文字列、空の場合はクライアントなし）
+ * @return プロジェクト, エラー
+ */
+func (c *ProjectController) Create(name string, clientID string) (*model.Project, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// クライアントIDをUUIDに変換
+	cid, err := parseOptionalUUID(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.projectService.Create(ctx, name, cid)
+}
+
+/*
+ * 指定IDのプロジェクトを取得する
+ *
+ * @param id プロジェクトID（UUID文字列）
+ * @return プロジェクト, エラー
+ */
+func (c *ProjectController) Get(id string) (*model.Project, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// プロジェクトIDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.projectService.Get(ctx, uid)
+}
+
+/*
+ * プロジェクト一覧を取得する
+ *
+ * @param includeArchived true のときアーカイブ済みも含める
+ * @return プロジェクト一覧, エラー
+ */
+func (c *ProjectController) List(includeArchived bool) ([]*model.Project, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	return c.projectService.List(ctx, includeArchived)
+}
+
+/*
+ * プロジェクトを更新する
+ *
+ * @param project プロジェクト
+ * @return 更新後のプロジェクト, エラー
+ */
+func (c *ProjectController) Update(project *model.Project) (*model.Project, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	return c.projectService.Update(ctx, project)
+}
+
+/*
+ * プロジェクトをアーカイブする
+ * 所属するタスクは計測の開始対象に表示されなくなる
+ *
+ * @param id プロジェクトID（UUID文字列）
+ * @return エラー
+ */
+func (c *ProjectController) Archive(id string) error {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// プロジェクトIDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return err
+	}
+
+	return c.projectService.SetArchived(ctx, uid, true)
+}
+
+/*
+ * プロジェクトのアーカイブを解除する
+ *
+ * @param id プロジェクトID（UUID文字列）
+ * @return エラー
+ */
+func (c *ProjectController) Unarchive(id string) error {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// プロジェクトIDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return err
+	}
+
+	return c.projectService.SetArchived(ctx, uid, false)
+}
+
+/*
+ * プロジェクトを削除する
+ *
+ * @param id プロジェクトID（UUID文字列）
+ * @return エラー
+ */
+func (c *ProjectController) Delete(id string) error {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// プロジェクトIDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return err
+	}
+
+	return c.projectService.Delete(ctx, uid)
+}
+
+/*
+ * UUID文字列をUUIDに変換する（空の場合はnil）
+ *
+ * @param value UUID文字列
+ * @return UUID, エラー
+ */
+func parseOptionalUUID(value string) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
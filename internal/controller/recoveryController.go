@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"play-wails/internal/model"
+	"play-wails/internal/service"
+
+	"github.com/google/uuid"
+)
+
+/*
+ * RecoveryController は異常終了で停止されなかった作業セッションの復旧をフロントに提供する
+ */
+type RecoveryController struct {
+	appCtx          *AppContext
+	recoveryService *service.RecoveryService
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param appCtx Wailsのランタイムコンテキスト
+ * @param recoveryService 復旧サービス
+ * @return インスタンス
+ */
+func NewRecoveryController(appCtx *AppContext, recoveryService *service.RecoveryService) *RecoveryController {
+	return &RecoveryController{appCtx: appCtx, recoveryService: recoveryService}
+}
+
+/*
+ * 確認待ちの停止漏れセッション一覧を取得する
+ *
+ * @return 作業セッション一覧, エラー
+ */
+func (c *RecoveryController) Orphans() ([]*model.WorkSession, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	return c.recoveryService.Orphans(ctx)
+}
+
+/*
+ * 停止漏れセッションを処理する
+ *
+ * @param sessionID 作業セッションID（UUID文字列）
+ * @param action 処理（heartbeat / shutdown / now / continue）
+ * @return エラー
+ */
+func (c *RecoveryController) Resolve(sessionID string, action string) error {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 作業セッションIDをUUIDに変換
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return err
+	}
+
+	return c.recoveryService.Resolve(ctx, id, service.RecoveryAction(action))
+}
@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"play-wails/internal/model"
+	"play-wails/internal/service"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReportController struct {
+	appCtx        *AppContext
+	reportService *service.ReportService
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param appCtx Wailsのランタイムコンテキスト
+ * @param reportService 集計サービス
+ * @return インスタンス
+ */
+func NewReportController(appCtx *AppContext, reportService *service.ReportService) *ReportController {
+	return &ReportController{appCtx: appCtx, reportService: reportService}
+}
+
+/*
+ * 期間内の作業時間を集計する
+ *
+ * @param from 期間の開始（RFC3339文字列、含む）
+ * @param to 期間の終了（RFC3339文字列、含まない）
+ * @param period 集計単位（day / week / month）
+ * @param timeZone タイムゾーン名（空の場合はローカル）
+ * @param taskIDs 絞り込むタスクID（UUID文字列、空の場合は全タスク）
+ * @param tagIDs 絞り込むタグID（UUID文字列、空の場合は絞り込まない）
+ * @return 集計結果, エラー
+ */
+func (c *ReportController) Report(from string, to string, period string, timeZone string, taskIDs []string, tagIDs []string) (*model.Report, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 期間を時刻に変換
+	f, err := time.Parse(time.RFC3339, from)
+	if err != nil {
+		return nil, err
+	}
+	t, err := time.Parse(time.RFC3339, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// タスクIDをUUIDに変換
+	ids, err := parseUUIDs(taskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// タグIDをUUIDに変換
+	tags, err := parseUUIDs(tagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.reportService.Report(ctx, service.ReportQuery{
+		From:     f,
+		To:       t,
+		Period:   model.ReportPeriod(period),
+		TimeZone: timeZone,
+		TaskIDs:  ids,
+		TagIDs:   tags,
+	})
+}
+
+/*
+ * 期間内の作業時間をクライアント → プロジェクト → タスクの階層ごとに合計する
+ *
+ * @param from 期間の開始（RFC3339文字列、含む）
+ * @param to 期間の終了（RFC3339文字列、含まない）
+ * @return 階層ごとの合計, エラー
+ */
+func (c *ReportController) Rollup(from string, to string) (*model.Rollup, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 期間を時刻に変換
+	f, err := time.Parse(time.RFC3339, from)
+	if err != nil {
+		return nil, err
+	}
+	t, err := time.Parse(time.RFC3339, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.reportService.Rollup(ctx, f, t)
+}
+
+/*
+ * UUID文字列の一覧をUUIDに変換する
+ *
+ * @param values UUID文字列一覧
+ * @return UUID一覧, エラー
+ */
+func parseUUIDs(values []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, v := range values {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"errors"
+	"play-wails/infarstructure/db"
+)
+
+/*
+ * SyncController はオフラインモードの同期状態をフロントに提供する
+ * オフラインモード以外では同期処理はnilとなり、無効状態を返す
+ */
+type SyncController struct {
+	appCtx *AppContext
+	syncer *db.Syncer
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param appCtx Wailsのランタイムコンテキスト
+ * @param syncer 同期処理（オフラインモード以外はnil）
+ * @return インスタンス
+ */
+func NewSyncController(appCtx *AppContext, syncer *db.Syncer) *SyncController {
+	return &SyncController{appCtx: appCtx, syncer: syncer}
+}
+
+/*
+ * 同期状態を取得する
+ *
+ * @return 同期状態, エラー
+ */
+func (c *SyncController) Status() (db.SyncStatus, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	if c.syncer == nil {
+		return db.SyncStatus{Enabled: false}, nil
+	}
+	return c.syncer.Status(ctx)
+}
+
+/*
+ * 競合中の行の一覧を取得する
+ *
+ * @return 競合一覧, エラー
+ */
+func (c *SyncController) Conflicts() ([]db.SyncConflict, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	if c.syncer == nil {
+		return []db.SyncConflict{}, nil
+	}
+	return c.syncer.Conflicts(ctx)
+}
+
+/*
+ * 競合を解消する
+ *
+ * @param table テーブル名
+ * @param rowID 行ID
+ * @param keepLocal true のときローカルの内容を優先
+ * @return エラー
+ */
+func (c *SyncController) ResolveConflict(table string, rowID string, keepLocal bool) error {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	if c.syncer == nil {
+		return errors.New("【ERROR】同期が有効ではありません。")
+	}
+	return c.syncer.ResolveConflict(ctx, table, rowID, keepLocal)
+}
+
+/*
+ * 即時に同期を実行する
+ *
+ * @return エラー
+ */
+func (c *SyncController) SyncNow() error {
+	if c.syncer == nil {
+		return errors.New("【ERROR】同期が有効ではありません。")
+	}
+
+	// 同期処理側で専用のタイムアウトを設定する
+	return c.syncer.SyncNow(c.appCtx.Context())
+}
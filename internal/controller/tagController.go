@@ -0,0 +1,238 @@
+package controller
+
+import (
+	"play-wails/internal/model"
+	"play-wails/internal/service"
+
+	"github.com/google/uuid"
+)
+
+type TagController struct {
+	appCtx     *AppContext
+	tagService *service.TagService
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param appCtx Wailsのランタイムコンテキスト
+ * @param tagService タグサービス
+ * @return インスタンス
+ */
+func NewTagController(appCtx *AppContext, tagService *service.TagService) *TagController {
+	return &TagController{appCtx: appCtx, tagService: tagService}
+}
+
+/*
+ * タグを新規作成する
+ *
+ * @param name 名前
+ * @return タグ, エラー
+ */
+func (c *TagController) Create(name string) (*model.Tag, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	return c.tagService.Create(ctx, name)
+}
+
+/*
+ * タグ一覧を取得する（名前順）
+ *
+ * @return タグ一覧, エラー
+ */
+func (c *TagController) List() ([]*model.Tag, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	return c.tagService.List(ctx)
+}
+
+/*
+ * タグの名前を変更する
+ *
+ * @param id タグID（UUID文字列）
+ * @param name 変更後の名前
+ * @return 変更後のタグ, エラー
+ */
+func (c *TagController) Rename(id string, name string) (*model.Tag, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// タグIDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.tagService.Rename(ctx, uid, name)
+}
+
+/*
+ * タグを別のタグに統合する
+ *
+ * @param fromID 統合元のタグID（UUID文字列）
+ * @param toID 統合先のタグID（UUID文字列）
+ * @return 統合先のタグ, エラー
+ */
+func (c *TagController) Merge(fromID string, toID string) (*model.Tag, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// タグIDをUUIDに変換
+	from, err := uuid.Parse(fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := uuid.Parse(toID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.tagService.Merge(ctx, from, to)
+}
+
+/*
+ * タグを削除する
+ *
+ * @param id タグID（UUID文字列）
+ * @return エラー
+ */
+func (c *TagController) Delete(id string) error {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// タグIDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return err
+	}
+
+	return c.tagService.Delete(ctx, uid)
+}
+
+/*
+ * タスクにタグを付ける（同じ名前のタグがない場合は作成する）
+ *
+ * @param taskID タスクID（UUID文字列）
+ * @param name タグの名前
+ * @return タグ, エラー
+ */
+func (c *TagController) TagTask(taskID string, name string) (*model.Tag, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// タスクIDをUUIDに変換
+	uid, err := uuid.Parse(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.tagService.TagTask(ctx, uid, name)
+}
+
+/*
+ * タスクからタグを外す
+ *
+ * @param taskID タスクID（UUID文字列）
+ * @param tagID タグID（UUID文字列）
+ * @return エラー
+ */
+func (c *TagController) UntagTask(taskID string, tagID string) error {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// タスクID・タグIDをUUIDに変換
+	uid, err := uuid.Parse(taskID)
+	if err != nil {
+		return err
+	}
+	tid, err := uuid.Parse(tagID)
+	if err != nil {
+		return err
+	}
+
+	return c.tagService.UntagTask(ctx, uid, tid)
+}
+
+/*
+ * タスクに付いているタグを取得する
+ *
+ * @param taskID タスクID（UUID文字列）
+ * @return タグ一覧, エラー
+ */
+func (c *TagController) TaskTags(taskID string) ([]*model.Tag, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// タスクIDをUUIDに変換
+	uid, err := uuid.Parse(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.tagService.TaskTags(ctx, uid)
+}
+
+/*
+ * 計測結果にタグを付ける（同じ名前のタグがない場合は作成する）
+ *
+ * @param timeRecordID 計測結果ID（UUID文字列）
+ * @param name タグの名前
+ * @return タグ, エラー
+ */
+func (c *TagController) TagTimeRecord(timeRecordID string, name string) (*model.Tag, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 計測結果IDをUUIDに変換
+	uid, err := uuid.Parse(timeRecordID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.tagService.TagTimeRecord(ctx, uid, name)
+}
+
+/*
+ * 計測結果からタグを外す
+ *
+ * @param timeRecordID 計測結果ID（UUID文字列）
+ * @param tagID タグID（UUID文字列）
+ * @return エラー
+ */
+func (c *TagController) UntagTimeRecord(timeRecordID string, tagID string) error {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 計測結果ID・タグIDをUUIDに変換
+	uid, err := uuid.Parse(timeRecordID)
+	if err != nil {
+		return err
+	}
+	tid, err := uuid.Parse(tagID)
+	if err != nil {
+		return err
+	}
+
+	return c.tagService.UntagTimeRecord(ctx, uid, tid)
+}
+
+/*
+ * 計測結果に付いているタグを取得する（タスクのタグは含まない）
+ *
+ * @param timeRecordID 計測結果ID（UUID文字列）
+ * @return タグ一覧, エラー
+ */
+func (c *TagController) TimeRecordTags(timeRecordID string) ([]*model.Tag, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 計測結果IDをUUIDに変換
+	uid, err := uuid.Parse(timeRecordID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.tagService.TimeRecordTags(ctx, uid)
+}
@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"play-wails/internal/model"
+	"play-wails/internal/service"
+
+	"github.com/google/uuid"
+)
+
+type TaskController struct {
+	appCtx      *AppContext
+	taskService *service.TaskService
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param appCtx Wailsのランタイムコンテキスト
+ * @param taskService タスクサービス
+ * @return インスタンス
+ */
+func NewTaskController(appCtx *AppContext, taskService *service.TaskService) *TaskController {
+	return &TaskController{appCtx: appCtx, taskService: taskService}
+}
+
+/*
+ * タスクを新規作成する
+ *
+ * @param title タイトル
+ * @param description 説明
+ * @return タスク, エラー
+ */
+func (c *TaskController) Create(title string, description string) (*model.Task, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	return c.taskService.Create(ctx, title, description)
+}
+
+/*
+ * 指定IDのタスクを取得する
+ *
+ * @param id タスクID（UUID文字列）
+ * @return タスク, エラー
+ */
+func (c *TaskController) Get(id string) (*model.Task, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// タスクIDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.taskService.Get(ctx, uid)
+}
+
+/*
+ * タスク一覧を取得する
+ *
+ * @param includeArchived true のときアーカイブ済みも含める
+ * @return タスク一覧, エラー
+ */
+func (c *TaskController) List(includeArchived bool) ([]*model.Task, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	return c.taskService.List(ctx, includeArchived)
+}
+
+/*
+ * タスクを更新する
+ *
+ * @param task タスク
+ * @return 更新後のタスク, エラー
+ */
+func (c *TaskController) Update(task *model.Task) (*model.Task, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	return c.taskService.Update(ctx, task)
+}
+
+/*
+ * タスクの所属プロジェクトを変更する
+ *
+ * @param id タスクID（UUID文字列）
+ * @param projectID プロジェクトID（UUID文字列、空の場合はプロジェクトなし）
+ * @return 更新後のタスク, エラー
+ */
+func (c *TaskController) SetProject(id string, projectID string) (*model.Task, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// タスクIDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// プロジェクトIDをUUIDに変換
+	pid, err := parseOptionalUUID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.taskService.SetProject(ctx, uid, pid)
+}
+
+/*
+ * タスクをアーカイブする
+ *
+ * @param id タスクID（UUID文字列）
+ * @return エラー
+ */
+func (c *TaskController) Archive(id string) error {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// タスクIDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return err
+	}
+
+	return c.taskService.SetArchived(ctx, uid, true)
+}
+
+/*
+ * タスクのアーカイブを解除する
+ *
+ * @param id タスクID（UUID文字列）
+ * @return エラー
+ */
+func (c *TaskController) Unarchive(id string) error {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// タスクIDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return err
+	}
+
+	return c.taskService.SetArchived(ctx, uid, false)
+}
+
+/*
+ * タスクの請求対象を切り替える
+ *
+ * @param id タスクID（UUID文字列）
+ * @param billable 請求対象にするか
+ * @return エラー
+ */
+func (c *TaskController) SetBillable(id string, billable bool) error {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// タスクIDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return err
+	}
+
+	return c.taskService.SetBillable(ctx, uid, billable)
+}
+
+/*
+ * タスクを削除する
+ *
+ * @param id タスクID（UUID文字列）
+ * @return エラー
+ */
+func (c *TaskController) Delete(id string) error {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// タスクIDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return err
+	}
+
+	return c.taskService.Delete(ctx, uid)
+}
@@ -8,17 +8,19 @@ import (
 )
 
 type TimeRecordController struct {
+	appCtx            *AppContext
 	timeRecordService *service.TimeRecordService
 }
 
 /*
  * 実装クラスのインスタンス生成
  *
+ * @param appCtx Wailsのランタイムコンテキスト
  * @param timeRecordService 時間計測レコードサービス
  * @return インスタンス
  */
-func NewTimeRecordController(timeRecordService *service.TimeRecordService) *TimeRecordController {
-	return &TimeRecordController{timeRecordService: timeRecordService}
+func NewTimeRecordController(appCtx *AppContext, timeRecordService *service.TimeRecordService) *TimeRecordController {
+	return &TimeRecordController{appCtx: appCtx, timeRecordService: timeRecordService}
 }
 
 /*
@@ -28,7 +30,23 @@ func NewTimeRecordController(timeRecordService *service.TimeRecordService) *Time
  * @return 計測結果一覧, エラー
  */
 func (c *TimeRecordController) List() ([]*model.TimeRecord, error) {
-	return c.timeRecordService.List()
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	return c.timeRecordService.List(ctx)
+}
+
+/*
+ * 条件を指定して計測結果を1ページ分取得する
+ *
+ * @param q 検索条件
+ * @return 検索結果, エラー
+ */
+func (c *TimeRecordController) Query(q model.TimeRecordQuery) (*model.TimeRecordPage, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	return c.timeRecordService.Query(ctx, q)
 }
 
 /*
@@ -38,32 +56,75 @@ func (c *TimeRecordController) List() ([]*model.TimeRecord, error) {
  * @return 計測結果, エラー
  */
 func (c *TimeRecordController) Get(id string) (*model.TimeRecord, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
 	// 計測結果IDをUUIDに変換
 	uid, err := uuid.Parse(id)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.timeRecordService.Get(uid)
+	return c.timeRecordService.Get(ctx, uid)
+}
+
+/*
+ * 計測結果を手入力で作成する
+ *
+ * @param in 入力内容（タスクID・開始・終了）
+ * @return 計測結果, エラー
+ */
+func (c *TimeRecordController) Create(in model.TimeRecordInput) (*model.TimeRecord, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	return c.timeRecordService.Create(ctx, in)
 }
 
 /*
  * 計測結果の時間を変更する
  *
- * @param record 計測結果
- * @return エラー
+ * @param in 入力内容（ID・開始・終了・作業時間・変更理由）
+ * @return 計測結果, エラー
+ */
+func (c *TimeRecordController) Update(in model.TimeRecordInput) (*model.TimeRecord, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	return c.timeRecordService.Update(ctx, in)
+}
+
+/*
+ * 計測結果の請求対象を切り替える
+ *
+ * @param id 計測結果ID（UUID文字列）
+ * @param billable 請求対象にするか
+ * @param reason 変更理由（省略可）
+ * @return 計測結果, エラー
  */
-func (c *TimeRecordController) Update(record *model.TimeRecord) error {
-	return c.timeRecordService.Update(record)
+func (c *TimeRecordController) SetBillable(id string, billable bool, reason string) (*model.TimeRecord, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 計測結果IDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.timeRecordService.SetBillable(ctx, uid, billable, reason)
 }
 
 /*
  * 計測結果を論理削除する
  *
  * @param id 計測結果ID（UUID文字列）
+ * @param reason 変更理由（省略可）
  * @return エラー
  */
-func (c *TimeRecordController) Delete(id string) error {
+func (c *TimeRecordController) Delete(id string, reason string) error {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
 
 	// 計測結果IDをUUIDに変換
 	uid, err := uuid.Parse(id)
@@ -71,5 +132,5 @@ func (c *TimeRecordController) Delete(id string) error {
 		return err
 	}
 
-	return c.timeRecordService.Delete(uid)
+	return c.timeRecordService.Delete(ctx, uid, reason)
 }
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"play-wails/internal/model"
+	"play-wails/internal/service"
+
+	"github.com/google/uuid"
+)
+
+type TrashController struct {
+	appCtx       *AppContext
+	trashService *service.TrashService
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param appCtx Wailsのランタイムコンテキスト
+ * @param trashService ゴミ箱サービス
+ * @return インスタンス
+ */
+func NewTrashController(appCtx *AppContext, trashService *service.TrashService) *TrashController {
+	return &TrashController{appCtx: appCtx, trashService: trashService}
+}
+
+/*
+ * ゴミ箱の計測結果一覧を取得する（削除日時の新しい順）
+ *
+ * @return ゴミ箱の計測結果一覧, エラー
+ */
+func (c *TrashController) List() ([]*model.TrashEntry, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	return c.trashService.List(ctx)
+}
+
+/*
+ * ゴミ箱の計測結果を元に戻す
+ *
+ * @param id 計測結果ID（UUID文字列）
+ * @param reason 変更理由（省略可）
+ * @return 計測結果, エラー
+ */
+func (c *TrashController) Restore(id string, reason string) (*model.TimeRecord, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 計測結果IDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.trashService.Restore(ctx, uid, reason)
+}
+
+/*
+ * ゴミ箱の計測結果を完全に削除する
+ *
+ * @param id 計測結果ID（UUID文字列）
+ * @param reason 変更理由（省略可）
+ * @return エラー
+ */
+func (c *TrashController) Purge(id string, reason string) error {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 計測結果IDをUUIDに変換
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return err
+	}
+
+	return c.trashService.Purge(ctx, uid, reason)
+}
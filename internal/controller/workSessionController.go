@@ -3,6 +3,7 @@ package controller
 import (
 	"play-wails/internal/model"
 	"play-wails/internal/service"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -12,17 +13,19 @@ import (
  * フロントからのリクエストを受け、Service を呼び出して結果を返す
  */
 type WorkSessionController struct {
+	appCtx             *AppContext
 	workSessionService *service.WorkSessionService
 }
 
 /*
  * 実装クラスのインスタンス生成
  *
+ * @param appCtx Wailsのランタイムコンテキスト
  * @param workSessionService 作業セッションサービス
  * @return インスタンス
  */
-func NewWorkSessionController(workSessionService *service.WorkSessionService) *WorkSessionController {
-	return &WorkSessionController{workSessionService: workSessionService}
+func NewWorkSessionController(appCtx *AppContext, workSessionService *service.WorkSessionService) *WorkSessionController {
+	return &WorkSessionController{appCtx: appCtx, workSessionService: workSessionService}
 }
 
 /*
@@ -32,6 +35,9 @@ func NewWorkSessionController(workSessionService *service.WorkSessionService) *W
  * @return 作業セッション, エラー
  */
 func (c *WorkSessionController) Start(taskID string) (*model.WorkSession, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
 	// タスクIDをUUIDに変換
 	id, err := uuid.Parse(taskID)
 	if err != nil {
@@ -39,7 +45,7 @@ func (c *WorkSessionController) Start(taskID string) (*model.WorkSession, error)
 	}
 
 	// 作業セッションを開始
-	return c.workSessionService.Start(id)
+	return c.workSessionService.Start(ctx, id)
 }
 
 /*
@@ -49,12 +55,15 @@ func (c *WorkSessionController) Start(taskID string) (*model.WorkSession, error)
  * @return エラー
  */
 func (c *WorkSessionController) Stop(sessionID string) error {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
 	// 作業セッションIDをUUIDに変換
 	id, err := uuid.Parse(sessionID)
 	if err != nil {
 		return err
 	}
-	return c.workSessionService.Stop(id)
+	return c.workSessionService.Stop(ctx, id)
 }
 
 /*
@@ -66,6 +75,9 @@ func (c *WorkSessionController) Stop(sessionID string) error {
  */
 // taskID: タスクID（UUID文字列）, runID: 計測実行のグループID（UUID文字列）
 func (c *WorkSessionController) Resume(taskID string, runID string) (*model.WorkSession, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
 	// タスクIDをUUIDに変換
 	tid, err := uuid.Parse(taskID)
 	if err != nil {
@@ -79,7 +91,7 @@ func (c *WorkSessionController) Resume(taskID string, runID string) (*model.Work
 	}
 
 	// 作業セッションを再開
-	return c.workSessionService.Resume(tid, rid)
+	return c.workSessionService.Resume(ctx, tid, rid)
 }
 
 /*
@@ -89,6 +101,8 @@ func (c *WorkSessionController) Resume(taskID string, runID string) (*model.Work
  * @return 作業セッション, エラー
  */
 func (c *WorkSessionController) Current(sessionID string) (*model.WorkSession, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
 
 	// 作業セッションIDをUUIDに変換
 	id, err := uuid.Parse(sessionID)
@@ -97,7 +111,70 @@ func (c *WorkSessionController) Current(sessionID string) (*model.WorkSession, e
 	}
 
 	// 作業セッションを取得
-	return c.workSessionService.Current(id)
+	return c.workSessionService.Current(ctx, id)
+}
+
+/*
+ * 計測中の作業セッションを取得する（なければnull）
+ * 起動時に前回から計測中のタイマーを復元するために使用する
+ *
+ * @return 作業セッション, エラー
+ */
+func (c *WorkSessionController) Active() (*model.WorkSession, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	return c.workSessionService.Active(ctx)
+}
+
+/*
+ * 計測中の作業セッション一覧を取得する
+ *
+ * @return 作業セッション一覧, エラー
+ */
+func (c *WorkSessionController) Running() ([]*model.WorkSession, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	return c.workSessionService.Running(ctx)
+}
+
+/*
+ * 計測実行（状態を含む）を取得する
+ *
+ * @param runID 計測実行のグループID（UUID文字列）
+ * @return 計測実行, エラー
+ */
+func (c *WorkSessionController) Run(runID string) (*model.Run, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 計測実行のグループIDをUUIDに変換
+	id, err := uuid.Parse(runID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.workSessionService.Run(ctx, id)
+}
+
+/*
+ * 計測を取り消す（計測結果は作成しない）
+ *
+ * @param runID 計測実行のグループID（UUID文字列）
+ * @return エラー
+ */
+func (c *WorkSessionController) Cancel(runID string) error {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 計測実行のグループIDをUUIDに変換
+	id, err := uuid.Parse(runID)
+	if err != nil {
+		return err
+	}
+
+	return c.workSessionService.Cancel(ctx, id)
 }
 
 /*
@@ -107,6 +184,8 @@ func (c *WorkSessionController) Current(sessionID string) (*model.WorkSession, e
  * @return 作成したTimeRecord, エラー
  */
 func (c *WorkSessionController) Complete(runID string) (*model.TimeRecord, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
 
 	// 計測実行のグループIDをUUIDに変換
 	id, err := uuid.Parse(runID)
@@ -115,5 +194,130 @@ func (c *WorkSessionController) Complete(runID string) (*model.TimeRecord, error
 	}
 
 	// 計測を完了し、累計時間でTimeRecordを1件作成
-	return c.workSessionService.Complete(id)
+	return c.workSessionService.Complete(ctx, id)
+}
+
+/*
+ * 計測実行の作業セッション一覧を取得する（開始時刻順）
+ *
+ * @param runID 計測実行のグループID（UUID文字列）
+ * @return 作業セッション一覧, エラー
+ */
+func (c *WorkSessionController) Sessions(runID string) ([]*model.WorkSession, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 計測実行のグループIDをUUIDに変換
+	id, err := uuid.Parse(runID)
+	if err != nil {
+		return nil, err
+	}
+	return c.workSessionService.Sessions(ctx, id)
+}
+
+/*
+ * 停止済みの作業セッションの開始・終了時刻を修正する
+ *
+ * @param sessionID 作業セッションID（UUID文字列）
+ * @param startTime 開始時刻（RFC3339文字列）
+ * @param endTime 終了時刻（RFC3339文字列）
+ * @param reason 変更理由（省略可）
+ * @return 作業セッション, エラー
+ */
+func (c *WorkSessionController) EditSession(sessionID string, startTime string, endTime string, reason string) (*model.WorkSession, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 作業セッションIDをUUIDに変換
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 時刻を変換
+	start, err := time.Parse(time.RFC3339, startTime)
+	if err != nil {
+		return nil, err
+	}
+	end, err := time.Parse(time.RFC3339, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.workSessionService.EditSession(ctx, id, start, end, reason)
+}
+
+/*
+ * 停止済みの作業セッションを2つに分割する
+ *
+ * @param sessionID 作業セッションID（UUID文字列）
+ * @param splitAt 分割する時刻（RFC3339文字列）
+ * @param resumeAt 後半の開始時刻（RFC3339文字列、空の場合は分割する時刻）
+ * @param reason 変更理由（省略可）
+ * @return 分割後の作業セッション（前半・後半）, エラー
+ */
+func (c *WorkSessionController) SplitSession(sessionID string, splitAt string, resumeAt string, reason string) ([]*model.WorkSession, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 作業セッションIDをUUIDに変換
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 時刻を変換
+	at, err := time.Parse(time.RFC3339, splitAt)
+	if err != nil {
+		return nil, err
+	}
+	var resume time.Time
+	if resumeAt != "" {
+		if resume, err = time.Parse(time.RFC3339, resumeAt); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.workSessionService.SplitSession(ctx, id, at, resume, reason)
+}
+
+/*
+ * 同じ計測実行の2つの作業セッションを1つに結合する
+ *
+ * @param firstID 作業セッションID（UUID文字列）
+ * @param secondID 作業セッションID（UUID文字列）
+ * @param reason 変更理由（省略可）
+ * @return 結合後の作業セッション, エラー
+ */
+func (c *WorkSessionController) MergeSessions(firstID string, secondID string, reason string) (*model.WorkSession, error) {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 作業セッションIDをUUIDに変換
+	ids, err := parseUUIDs([]string{firstID, secondID})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.workSessionService.MergeSessions(ctx, ids[0], ids[1], reason)
+}
+
+/*
+ * 停止済みの作業セッションを削除する
+ *
+ * @param sessionID 作業セッションID（UUID文字列）
+ * @param reason 変更理由（省略可）
+ * @return エラー
+ */
+func (c *WorkSessionController) DeleteSession(sessionID string, reason string) error {
+	ctx, cancel := c.appCtx.WithTimeout()
+	defer cancel()
+
+	// 作業セッションIDをUUIDに変換
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return err
+	}
+
+	return c.workSessionService.DeleteSession(ctx, id, reason)
 }
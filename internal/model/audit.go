@@ -0,0 +1,48 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+ * 変更履歴の対象テーブル
+ */
+type AuditTable string
+
+const (
+	AuditTableTimeRecords  AuditTable = "time_records"
+	AuditTableWorkSessions AuditTable = "work_sessions"
+)
+
+/*
+ * 変更の種類（deleteは論理削除・作業セッションの削除、purgeはゴミ箱からの完全な削除）
+ */
+type AuditAction string
+
+const (
+	AuditActionCreate  AuditAction = "create"
+	AuditActionUpdate  AuditAction = "update"
+	AuditActionDelete  AuditAction = "delete"
+	AuditActionRestore AuditAction = "restore"
+	AuditActionPurge   AuditAction = "purge"
+)
+
+/*
+ * 計測結果・作業セッションの変更履歴（追記のみで更新・削除はしない）
+ * Before・Afterは変更前後の内容（作成時のBefore、物理削除時のAfterはnil）
+ * Actorは変更した端末（ユーザー名@ホスト名）
+ */
+type AuditEntry struct {
+	ID        uuid.UUID              `json:"id"`
+	Table     AuditTable             `json:"table"`
+	RowID     uuid.UUID              `json:"row_id"`
+	RunID     uuid.UUID              `json:"run_id"`
+	Action    AuditAction            `json:"action"`
+	Before    map[string]interface{} `json:"before"`
+	After     map[string]interface{} `json:"after"`
+	Reason    string                 `json:"reason"`
+	Actor     string                 `json:"actor"`
+	CreatedAt time.Time              `json:"created_at"`
+}
@@ -0,0 +1,111 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+ * 時間単価を設定する階層
+ * タスク → プロジェクト → クライアントの順に、最も細かい階層の単価を適用する
+ */
+type RateLevel string
+
+const (
+	RateLevelClient  RateLevel = "client"
+	RateLevelProject RateLevel = "project"
+	RateLevelTask    RateLevel = "task"
+)
+
+/*
+ * 階層が定義済みの値か判定
+ */
+func (l RateLevel) IsValid() bool {
+	switch l {
+	case RateLevelClient, RateLevelProject, RateLevelTask:
+		return true
+	}
+	return false
+}
+
+/*
+ * 時間単価
+ * TargetIDはLevelに応じたクライアント・プロジェクト・タスクのID
+ * HourlyRateは1時間あたりの金額（通貨の最小単位の整数、円・セントなど）
+ * EffectiveFrom以降に開始した計測結果に適用する（同じ対象では最も新しいものを優先）
+ */
+type Rate struct {
+	ID            uuid.UUID `json:"id"`
+	Level         RateLevel `json:"level"`
+	TargetID      uuid.UUID `json:"target_id"`
+	HourlyRate    int64     `json:"hourly_rate"`
+	Currency      string    `json:"currency"`
+	EffectiveFrom time.Time `json:"effective_from"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+/*
+ * 丸めの単位
+ */
+type RoundingScope string
+
+const (
+	RoundingScopeRecord RoundingScope = "record"
+	RoundingScopeDay    RoundingScope = "day"
+)
+
+/*
+ * 請求時間の丸め（Incrementの単位に切り上げる、0の場合は丸めない）
+ * Scopeがrecordの場合は計測結果ごと、dayの場合は日・タスク・単価ごとの合計を丸める
+ * 保存されている作業時間は変更せず、集計時にのみ適用する
+ */
+type RoundingRule struct {
+	Increment time.Duration `json:"increment"`
+	Scope     RoundingScope `json:"scope"`
+}
+
+/*
+ * 請求明細（丸めの単位ごと）
+ * Dateは集計のタイムゾーンでの日付（2006-01-02）
+ * RateIDがnilの場合は単価が未設定（金額は0）
+ */
+type BillableItem struct {
+	Date           string        `json:"date"`
+	TaskID         uuid.UUID     `json:"task_id"`
+	TaskTitle      string        `json:"task_title"`
+	ProjectID      *uuid.UUID    `json:"project_id"`
+	ClientID       *uuid.UUID    `json:"client_id"`
+	TimeRecordIDs  []uuid.UUID   `json:"time_record_ids"`
+	Duration       time.Duration `json:"duration"`
+	BilledDuration time.Duration `json:"billed_duration"`
+	RateID         *uuid.UUID    `json:"rate_id"`
+	HourlyRate     int64         `json:"hourly_rate"`
+	Currency       string        `json:"currency"`
+	Amount         int64         `json:"amount"`
+}
+
+/*
+ * 通貨ごとの請求合計
+ */
+type BillableTotal struct {
+	Currency       string        `json:"currency"`
+	BilledDuration time.Duration `json:"billed_duration"`
+	Amount         int64         `json:"amount"`
+}
+
+/*
+ * 期間内の請求額の計算結果
+ * NonBillableは請求対象外の作業時間、Unratedは請求対象で単価が未設定の作業時間（丸め後）
+ */
+type BillableSummary struct {
+	From        time.Time       `json:"from"`
+	To          time.Time       `json:"to"`
+	TimeZone    string          `json:"time_zone"`
+	Rounding    RoundingRule    `json:"rounding"`
+	Items       []BillableItem  `json:"items"`
+	Totals      []BillableTotal `json:"totals"`
+	NonBillable time.Duration   `json:"non_billable"`
+	Unrated     time.Duration   `json:"unrated"`
+}
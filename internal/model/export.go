@@ -0,0 +1,105 @@
+package model
+
+import (
+	"time"
+)
+
+/*
+ * エクスポートの形式
+ */
+type ExportFormat string
+
+const (
+	ExportFormatCSV   ExportFormat = "csv"
+	ExportFormatJSONL ExportFormat = "jsonl"
+)
+
+/*
+ * エクスポートする作業時間の表記
+ */
+type DurationFormat string
+
+const (
+	// hh:mm:ss（秒未満は四捨五入）
+	DurationFormatHMS DurationFormat = "hms"
+	// 時間の小数表記（小数第2位まで）
+	DurationFormatHours DurationFormat = "hours"
+	// ナノ秒（duration_nsの値そのまま）
+	DurationFormatNanoseconds DurationFormat = "ns"
+)
+
+/*
+ * エクスポートする計測結果の項目
+ */
+type ExportColumn string
+
+const (
+	ExportColumnID        ExportColumn = "id"
+	ExportColumnRunID     ExportColumn = "run_id"
+	ExportColumnTaskID    ExportColumn = "task_id"
+	ExportColumnTaskTitle ExportColumn = "task_title"
+	ExportColumnStartTime ExportColumn = "start_time"
+	ExportColumnEndTime   ExportColumn = "end_time"
+	ExportColumnDuration  ExportColumn = "duration"
+)
+
+// 項目の指定がない場合に出力する項目（この順で出力する）
+var DefaultExportColumns = []ExportColumn{
+	ExportColumnID,
+	ExportColumnRunID,
+	ExportColumnTaskID,
+	ExportColumnTaskTitle,
+	ExportColumnStartTime,
+	ExportColumnEndTime,
+	ExportColumnDuration,
+}
+
+/*
+ * 項目名が正しいか判定
+ */
+func (c ExportColumn) IsValid() bool {
+	for _, v := range DefaultExportColumns {
+		if c == v {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+ * エクスポートの条件
+ * From/Toは期間と重なる計測結果を対象とする（Fromは含む、Toは含まない）
+ * IncludeSessionsを指定すると計測実行の作業セッションも出力する
+ * （CSVはセッションごとに1行、JSON Linesは計測結果ごとにsessionsの配列）
+ */
+type ExportOptions struct {
+	From            time.Time      `json:"from"`
+	To              time.Time      `json:"to"`
+	Format          ExportFormat   `json:"format"`
+	Columns         []ExportColumn `json:"columns"`
+	DurationFormat  DurationFormat `json:"duration_format"`
+	TimeZone        string         `json:"time_zone"`
+	IncludeSessions bool           `json:"include_sessions"`
+}
+
+/*
+ * カレンダーに出力する単位
+ */
+type CalendarEventUnit string
+
+const (
+	// 作業セッションごとに1件
+	CalendarEventSession CalendarEventUnit = "session"
+	// 計測結果ごとに1件（開始〜終了。一時停止中も含む）
+	CalendarEventRecord CalendarEventUnit = "record"
+)
+
+/*
+ * iCalendarエクスポートの条件
+ * From/Toは期間と重なるものを対象とする（Fromは含む、Toは含まない）
+ */
+type CalendarExportOptions struct {
+	From time.Time         `json:"from"`
+	To   time.Time         `json:"to"`
+	Unit CalendarEventUnit `json:"unit"`
+}
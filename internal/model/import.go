@@ -0,0 +1,111 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+ * 取り込むファイルの形式
+ */
+type ImportFormat string
+
+const (
+	// Toggl Trackの詳細レポートのCSV
+	ImportFormatToggl ImportFormat = "toggl"
+	// Clockifyの詳細レポートのCSV
+	ImportFormatClockify ImportFormat = "clockify"
+	// task, start, end（任意でdescription）の列を持つCSV
+	ImportFormatCSV ImportFormat = "csv"
+)
+
+/*
+ * 取り込む行をどのタスクに割り当てるか（Toggl・Clockifyのみ）
+ */
+type ImportTaskMapping string
+
+const (
+	// プロジェクト名をタスク名にする（空の場合は説明）
+	ImportTaskMappingProject ImportTaskMapping = "project"
+	// 説明をタスク名にする（空の場合はプロジェクト名）
+	ImportTaskMappingDescription ImportTaskMapping = "description"
+	// 「プロジェクト名 / 説明」をタスク名にする
+	ImportTaskMappingProjectDescription ImportTaskMapping = "project_description"
+)
+
+/*
+ * 年が最後にある日付（01/02/2006など）の月と日の順序（Toggl・Clockifyのみ）
+ * 年が先頭の日付（2006-01-02など）は順序の指定に関係なく年/月/日として解釈する
+ */
+type ImportDateOrder string
+
+const (
+	// ファイル全体の日付から判別する（判別できない場合はエラー）
+	ImportDateOrderAuto ImportDateOrder = ""
+	// 月/日/年
+	ImportDateOrderMDY ImportDateOrder = "mdy"
+	// 日/月/年
+	ImportDateOrderDMY ImportDateOrder = "dmy"
+)
+
+/*
+ * 取り込みの条件
+ * TimeZoneはファイル内のオフセットなしの日時を解釈するタイムゾーン（空の場合はローカル）
+ */
+type ImportOptions struct {
+	Format        ImportFormat      `json:"format"`
+	TimeZone      string            `json:"time_zone"`
+	TaskMapping   ImportTaskMapping `json:"task_mapping"`
+	DateOrder     ImportDateOrder   `json:"date_order"`
+	AllowOverlaps bool              `json:"allow_overlaps"`
+}
+
+/*
+ * 取り込む行の処理結果
+ */
+type ImportEntryStatus string
+
+const (
+	ImportEntryCreate ImportEntryStatus = "create"
+	ImportEntrySkip   ImportEntryStatus = "skip"
+)
+
+/*
+ * 取り込む1行分の内容と処理結果
+ * Lineはファイルの行番号（見出し行が1）
+ */
+type ImportEntry struct {
+	Line         int               `json:"line"`
+	TaskTitle    string            `json:"task_title"`
+	NewTask      bool              `json:"new_task"`
+	StartTime    *time.Time        `json:"start_time"`
+	EndTime      *time.Time        `json:"end_time"`
+	Duration     time.Duration     `json:"duration"`
+	Status       ImportEntryStatus `json:"status"`
+	Reason       string            `json:"reason"`
+	TimeRecordID *uuid.UUID        `json:"time_record_id"`
+}
+
+/*
+ * 取り込みの結果
+ * DryRunの場合は何も保存せず、作成・スキップされる予定の内容を返す
+ */
+type ImportResult struct {
+	DryRun       bool           `json:"dry_run"`
+	Entries      []*ImportEntry `json:"entries"`
+	Created      int            `json:"created"`
+	Skipped      int            `json:"skipped"`
+	TasksCreated int            `json:"tasks_created"`
+}
+
+/*
+ * 取り込み済みの外部データの記録
+ * IDは取り込み元の行の内容から作成したハッシュ
+ */
+type ImportFingerprint struct {
+	ID           string       `json:"id"`
+	Source       ImportFormat `json:"source"`
+	TimeRecordID uuid.UUID    `json:"time_record_id"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
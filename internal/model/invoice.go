@@ -0,0 +1,71 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+ * 請求書の状態
+ */
+type InvoiceStatus string
+
+const (
+	InvoiceStatusIssued InvoiceStatus = "issued"
+	InvoiceStatusVoid   InvoiceStatus = "void"
+)
+
+/*
+ * 請求書
+ * Numberは発行年ごとの連番（INV-2026-0001）
+ * ClientNameは発行時のクライアント名、Totalは通貨の最小単位の整数
+ * 発行中の請求書の計測結果は編集できない（取り消すと編集できるようになる）
+ */
+type Invoice struct {
+	ID         uuid.UUID     `json:"id"`
+	Number     string        `json:"number"`
+	ClientID   uuid.UUID     `json:"client_id"`
+	ClientName string        `json:"client_name"`
+	PeriodFrom time.Time     `json:"period_from"`
+	PeriodTo   time.Time     `json:"period_to"`
+	TimeZone   string        `json:"time_zone"`
+	Currency   string        `json:"currency"`
+	Rounding   RoundingRule  `json:"rounding"`
+	Total      int64         `json:"total"`
+	Status     InvoiceStatus `json:"status"`
+	IssuedAt   time.Time     `json:"issued_at"`
+	VoidedAt   *time.Time    `json:"voided_at"`
+	Lines      []InvoiceLine `json:"lines"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
+/*
+ * 請求書の明細（タスク・単価ごと）
+ * HourlyRate・Amountは通貨の最小単位の整数
+ */
+type InvoiceLine struct {
+	ID             uuid.UUID     `json:"id"`
+	InvoiceID      uuid.UUID     `json:"invoice_id"`
+	LineNo         int           `json:"line_no"`
+	TaskID         uuid.UUID     `json:"task_id"`
+	Description    string        `json:"description"`
+	BilledDuration time.Duration `json:"billed_duration"`
+	HourlyRate     int64         `json:"hourly_rate"`
+	Amount         int64         `json:"amount"`
+}
+
+/*
+ * 請求書の発行条件
+ * 期間内に開始した未請求の計測結果を対象とする（Fromは含む、Toは含まない）
+ * Currencyは複数の通貨の単価がある場合に指定する
+ */
+type InvoiceInput struct {
+	ClientID uuid.UUID    `json:"client_id"`
+	From     time.Time    `json:"from"`
+	To       time.Time    `json:"to"`
+	TimeZone string       `json:"time_zone"`
+	Currency string       `json:"currency"`
+	Rounding RoundingRule `json:"rounding"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+ * 時間が重なっている2つの作業セッション
+ * First は開始時刻が早い方。Start〜End が重なっている範囲（計測中の場合は現在時刻まで）
+ */
+type SessionOverlap struct {
+	FirstSessionID  uuid.UUID     `json:"first_session_id"`
+	FirstRunID      uuid.UUID     `json:"first_run_id"`
+	FirstTaskID     uuid.UUID     `json:"first_task_id"`
+	FirstTaskTitle  string        `json:"first_task_title"`
+	SecondSessionID uuid.UUID     `json:"second_session_id"`
+	SecondRunID     uuid.UUID     `json:"second_run_id"`
+	SecondTaskID    uuid.UUID     `json:"second_task_id"`
+	SecondTaskTitle string        `json:"second_task_title"`
+	SameRun         bool          `json:"same_run"`
+	Start           time.Time     `json:"start"`
+	End             time.Time     `json:"end"`
+	Duration        time.Duration `json:"duration"`
+}
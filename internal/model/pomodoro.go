@@ -0,0 +1,88 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+ * ポモドーロのフェーズ
+ */
+type PomodoroPhase string
+
+const (
+	// ポモドーロを実行していない
+	PomodoroPhaseIdle PomodoroPhase = "idle"
+	// 作業中（作業セッションを計測中）
+	PomodoroPhaseWork PomodoroPhase = "work"
+	// 短い休憩（作業セッションは停止中）
+	PomodoroPhaseShortBreak PomodoroPhase = "short_break"
+	// 長い休憩（作業セッションは停止中）
+	PomodoroPhaseLongBreak PomodoroPhase = "long_break"
+)
+
+/*
+ * 休憩中か判定
+ */
+func (p PomodoroPhase) IsBreak() bool {
+	return p == PomodoroPhaseShortBreak || p == PomodoroPhaseLongBreak
+}
+
+/*
+ * ポモドーロの設定
+ * CyclesPerLongBreak回の作業ごとに、短い休憩の代わりに長い休憩をとる
+ */
+type PomodoroSettings struct {
+	Work               time.Duration `json:"work"`
+	ShortBreak         time.Duration `json:"short_break"`
+	LongBreak          time.Duration `json:"long_break"`
+	CyclesPerLongBreak int           `json:"cycles_per_long_break"`
+}
+
+/*
+ * 標準の設定（作業25分・短い休憩5分・長い休憩15分・4回ごとに長い休憩）
+ */
+func DefaultPomodoroSettings() PomodoroSettings {
+	return PomodoroSettings{
+		Work:               25 * time.Minute,
+		ShortBreak:         5 * time.Minute,
+		LongBreak:          15 * time.Minute,
+		CyclesPerLongBreak: 4,
+	}
+}
+
+/*
+ * フェーズの長さ
+ *
+ * @param phase フェーズ
+ * @return 長さ（idleの場合は0）
+ */
+func (s PomodoroSettings) Length(phase PomodoroPhase) time.Duration {
+	switch phase {
+	case PomodoroPhaseWork:
+		return s.Work
+	case PomodoroPhaseShortBreak:
+		return s.ShortBreak
+	case PomodoroPhaseLongBreak:
+		return s.LongBreak
+	}
+	return 0
+}
+
+/*
+ * ポモドーロの状態（フェーズが変わるたびにフロントへ通知する）
+ * RunIDは作業・休憩を通して同じ計測実行、SessionIDは作業中のみ計測中の作業セッション
+ * CompletedCyclesは終えた作業の回数、Errorは自動の停止・再開に失敗して終了した場合の理由
+ */
+type PomodoroState struct {
+	Phase           PomodoroPhase    `json:"phase"`
+	TaskID          *uuid.UUID       `json:"task_id"`
+	RunID           *uuid.UUID       `json:"run_id"`
+	SessionID       *uuid.UUID       `json:"session_id"`
+	CompletedCycles int              `json:"completed_cycles"`
+	PhaseStartedAt  *time.Time       `json:"phase_started_at"`
+	PhaseEndsAt     *time.Time       `json:"phase_ends_at"`
+	Settings        PomodoroSettings `json:"settings"`
+	Error           string           `json:"error"`
+}
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+ * プロジェクト
+ * Task.ProjectIDが参照する。ClientIDがnilの場合はクライアントなし
+ * アーカイブ済みのプロジェクトのタスクでは計測を開始できない
+ */
+type Project struct {
+	ID        uuid.UUID  `json:"id"`
+	ClientID  *uuid.UUID `json:"client_id"`
+	Name      string     `json:"name"`
+	Archived  bool       `json:"archived"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+ * 集計の単位
+ */
+type ReportPeriod string
+
+const (
+	ReportPeriodDay   ReportPeriod = "day"
+	ReportPeriodWeek  ReportPeriod = "week"
+	ReportPeriodMonth ReportPeriod = "month"
+)
+
+/*
+ * タスクごとの合計
+ */
+type ReportTaskTotal struct {
+	TaskID    uuid.UUID     `json:"task_id"`
+	TaskTitle string        `json:"task_title"`
+	Duration  time.Duration `json:"duration"`
+}
+
+/*
+ * 期間（日・ISO週・月）ごとの合計とタスク別の内訳
+ * Keyは日が「2006-01-02」、週が「2006-W01」、月が「2006-01」
+ */
+type ReportPeriodTotal struct {
+	Key      string            `json:"key"`
+	Start    time.Time         `json:"start"`
+	End      time.Time         `json:"end"`
+	Duration time.Duration     `json:"duration"`
+	Tasks    []ReportTaskTotal `json:"tasks"`
+}
+
+/*
+ * 集計結果
+ */
+type Report struct {
+	From     time.Time           `json:"from"`
+	To       time.Time           `json:"to"`
+	TimeZone string              `json:"time_zone"`
+	Period   ReportPeriod        `json:"period"`
+	Total    time.Duration       `json:"total"`
+	Periods  []ReportPeriodTotal `json:"periods"`
+	Tasks    []ReportTaskTotal   `json:"tasks"`
+}
+
+/*
+ * プロジェクトごとの合計とタスク別の内訳
+ * ProjectIDがnilの場合はプロジェクトに属さないタスクの合計
+ */
+type RollupProjectTotal struct {
+	ProjectID   *uuid.UUID        `json:"project_id"`
+	ProjectName string            `json:"project_name"`
+	Archived    bool              `json:"archived"`
+	Duration    time.Duration     `json:"duration"`
+	Tasks       []ReportTaskTotal `json:"tasks"`
+}
+
+/*
+ * クライアントごとの合計とプロジェクト別の内訳
+ * ClientIDがnilの場合はクライアントに属さないプロジェクト（とプロジェクトなし）の合計
+ */
+type RollupClientTotal struct {
+	ClientID   *uuid.UUID           `json:"client_id"`
+	ClientName string               `json:"client_name"`
+	Archived   bool                 `json:"archived"`
+	Duration   time.Duration        `json:"duration"`
+	Projects   []RollupProjectTotal `json:"projects"`
+}
+
+/*
+ * クライアント → プロジェクト → タスクの階層ごとの合計
+ */
+type Rollup struct {
+	From    time.Time           `json:"from"`
+	To      time.Time           `json:"to"`
+	Total   time.Duration       `json:"total"`
+	Clients []RollupClientTotal `json:"clients"`
+}
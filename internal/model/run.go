@@ -0,0 +1,111 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+ * 計測実行の状態
+ * Running → Paused → Running → ... → Completed / Cancelled
+ */
+type RunStatus string
+
+const (
+	RunStatusRunning   RunStatus = "running"
+	RunStatusPaused    RunStatus = "paused"
+	RunStatusCompleted RunStatus = "completed"
+	RunStatusCancelled RunStatus = "cancelled"
+)
+
+/*
+ * 計測実行（同一RunIDのWorkSessionをまとめる単位）
+ */
+type Run struct {
+	ID        uuid.UUID `json:"id"`
+	TaskID    uuid.UUID `json:"task_id"`
+	Status    RunStatus `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+/*
+ * 計測実行を新規作成する（計測中の状態）
+ *
+ * @param taskID タスクID
+ * @param now 現在時刻
+ * @return 計測実行
+ */
+func NewRun(taskID uuid.UUID, now time.Time) *Run {
+	return &Run{
+		ID:        uuid.New(),
+		TaskID:    taskID,
+		Status:    RunStatusRunning,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+/*
+ * 終了済み（完了・取消）か判定
+ */
+func (r Run) IsFinished() bool {
+	return r.Status == RunStatusCompleted || r.Status == RunStatusCancelled
+}
+
+/*
+ * 一時停止する（計測中 → 一時停止）
+ *
+ * @param now 現在時刻
+ */
+func (r *Run) Pause(now time.Time) error {
+	return r.transition(now, RunStatusPaused, RunStatusRunning)
+}
+
+/*
+ * 再開する（一時停止 → 計測中）
+ *
+ * @param now 現在時刻
+ */
+func (r *Run) Resume(now time.Time) error {
+	return r.transition(now, RunStatusRunning, RunStatusPaused)
+}
+
+/*
+ * 完了する（一時停止 → 完了）
+ * 計測中のセッションは完了前に停止しておく必要がある
+ *
+ * @param now 現在時刻
+ */
+func (r *Run) Complete(now time.Time) error {
+	return r.transition(now, RunStatusCompleted, RunStatusPaused)
+}
+
+/*
+ * 取り消す（計測中・一時停止 → 取消）
+ *
+ * @param now 現在時刻
+ */
+func (r *Run) Cancel(now time.Time) error {
+	return r.transition(now, RunStatusCancelled, RunStatusRunning, RunStatusPaused)
+}
+
+/*
+ * 状態を遷移する
+ *
+ * @param now 現在時刻
+ * @param to 遷移先
+ * @param from 遷移を許可する状態
+ */
+func (r *Run) transition(now time.Time, to RunStatus, from ...RunStatus) error {
+	for _, f := range from {
+		if r.Status == f {
+			r.Status = to
+			r.UpdatedAt = now
+			return nil
+		}
+	}
+	return fmt.Errorf("【ERROR】計測実行の状態が %s のため %s にできません。", r.Status, to)
+}
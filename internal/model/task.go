@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+ * タスクのステータス
+ */
+type TaskStatus string
+
+const (
+	TaskStatusTodo       TaskStatus = "todo"
+	TaskStatusInProgress TaskStatus = "in_progress"
+	TaskStatusDone       TaskStatus = "done"
+)
+
+/*
+ * ステータスが定義済みの値か判定
+ */
+func (s TaskStatus) IsValid() bool {
+	switch s {
+	case TaskStatusTodo, TaskStatusInProgress, TaskStatusDone:
+		return true
+	}
+	return false
+}
+
+/*
+ * タスク
+ * WorkSession/TimeRecordのTaskIDが参照する作業単位
+ * ProjectIDがnilの場合はプロジェクトなし
+ * ProjectArchivedは所属プロジェクト（またはそのクライアント）がアーカイブ済みか（取得時のみ設定）
+ * NonBillableは請求対象外のタスクか（計測結果はすべて請求しない）
+ */
+type Task struct {
+	ID              uuid.UUID  `json:"id"`
+	ProjectID       *uuid.UUID `json:"project_id"`
+	Title           string     `json:"title"`
+	Description     string     `json:"description"`
+	Status          TaskStatus `json:"status"`
+	Archived        bool       `json:"archived"`
+	ProjectArchived bool       `json:"project_archived"`
+	NonBillable     bool       `json:"non_billable"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+/*
+ * 計測を開始できるか判定（タスク・プロジェクトのいずれもアーカイブされていない）
+ */
+func (t Task) IsSelectable() bool {
+	return !t.Archived && !t.ProjectArchived
+}
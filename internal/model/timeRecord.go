@@ -9,13 +9,41 @@ import (
 /*
  * 時間計測
  * 完了時に1件作成。同一のRunIDをWorkSessionグループの累計時間を保持
+ * DeletedAtは論理削除（ゴミ箱に移動）した日時
+ * NonBillableは請求対象外か（タスクが請求対象外の場合も請求しない）
+ * InvoiceIDは請求済みの場合の請求書ID（請求済みの計測結果は編集できない）
  */
 type TimeRecord struct {
-	ID         uuid.UUID     `json:"id"`
-	RunID      uuid.UUID     `json:"run_id"`
-	TaskID     uuid.UUID     `json:"task_id"`
-	DeleteFlag bool          `json:"delete_flag"`
-	StartTime  time.Time     `json:"start_time"`
-	EndTime    time.Time     `json:"end_time"`
-	Duration   time.Duration `json:"duration"`
+	ID          uuid.UUID     `json:"id"`
+	RunID       uuid.UUID     `json:"run_id"`
+	TaskID      uuid.UUID     `json:"task_id"`
+	DeleteFlag  bool          `json:"delete_flag"`
+	StartTime   time.Time     `json:"start_time"`
+	EndTime     time.Time     `json:"end_time"`
+	Duration    time.Duration `json:"duration"`
+	DeletedAt   *time.Time    `json:"deleted_at"`
+	NonBillable bool          `json:"non_billable"`
+	InvoiceID   *uuid.UUID    `json:"invoice_id"`
+}
+
+/*
+ * 請求済みか判定
+ */
+func (r TimeRecord) IsInvoiced() bool {
+	return r.InvoiceID != nil
+}
+
+/*
+ * 計測結果の手入力・編集の内容
+ * 作成時はTaskID、編集時はIDを指定する
+ * Durationを省略した場合は作業セッションの合計（手入力の作成時は開始〜終了）とする
+ * Reasonは変更履歴に記録する変更理由（省略可）
+ */
+type TimeRecordInput struct {
+	ID        uuid.UUID      `json:"id"`
+	TaskID    uuid.UUID      `json:"task_id"`
+	StartTime time.Time      `json:"start_time"`
+	EndTime   time.Time      `json:"end_time"`
+	Duration  *time.Duration `json:"duration"`
+	Reason    string         `json:"reason"`
 }
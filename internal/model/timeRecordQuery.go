@@ -0,0 +1,47 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+ * 計測結果一覧の並び順の項目
+ */
+type TimeRecordSortField string
+
+const (
+	TimeRecordSortStartTime TimeRecordSortField = "start_time"
+	TimeRecordSortEndTime   TimeRecordSortField = "end_time"
+	TimeRecordSortDuration  TimeRecordSortField = "duration"
+)
+
+/*
+ * 計測結果一覧の検索条件
+ * From/Toは期間と重なる計測結果を対象とする（Fromは含む、Toは含まない）
+ * TagIDsはいずれかのタグが計測結果またはそのタスクに付いているものを対象とする
+ * Cursorは前回の検索結果のNextCursorを指定し、続きを取得する（キーセット方式）
+ */
+type TimeRecordQuery struct {
+	From           *time.Time          `json:"from"`
+	To             *time.Time          `json:"to"`
+	TaskIDs        []uuid.UUID         `json:"task_ids"`
+	TagIDs         []uuid.UUID         `json:"tag_ids"`
+	MinDuration    *time.Duration      `json:"min_duration"`
+	MaxDuration    *time.Duration      `json:"max_duration"`
+	IncludeDeleted bool                `json:"include_deleted"`
+	SortField      TimeRecordSortField `json:"sort_field"`
+	Descending     bool                `json:"descending"`
+	Cursor         string              `json:"cursor"`
+	Limit          int                 `json:"limit"`
+}
+
+/*
+ * 計測結果一覧の検索結果（1ページ分）
+ * NextCursorが空の場合は続きなし
+ */
+type TimeRecordPage struct {
+	Records    []*TimeRecord `json:"records"`
+	NextCursor string        `json:"next_cursor"`
+}
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+/*
+ * ゴミ箱の計測結果
+ * PurgeAtは保持期間を過ぎて完全に削除される日時（保持期間を設定していない場合はnil）
+ */
+type TrashEntry struct {
+	Record    *TimeRecord `json:"record"`
+	TaskTitle string      `json:"task_title"`
+	PurgeAt   *time.Time  `json:"purge_at"`
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"strings"
+)
+
+/*
+ * 入力項目ごとのエラー
+ * Fieldはフロントの入力欄と対応するJSONの項目名
+ */
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+/*
+ * 入力チェックのエラー
+ * フロントには項目ごとのエラーを含むオブジェクトとして返す（main.goのErrorFormatter）
+ */
+type ValidationError struct {
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields"`
+}
+
+/*
+ * 入力チェックのエラーを作成する
+ *
+ * @param message 全体のメッセージ
+ * @return エラー
+ */
+func NewValidationError(message string) *ValidationError {
+	return &ValidationError{Message: message, Fields: make([]FieldError, 0)}
+}
+
+/*
+ * 項目のエラーを追加する
+ *
+ * @param field 項目名
+ * @param message メッセージ
+ */
+func (e *ValidationError) Add(field string, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: message})
+}
+
+/*
+ * 項目のエラーがあればエラーとして返す（なければnil）
+ */
+func (e *ValidationError) Err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, f.Field+": "+f.Message)
+	}
+	return e.Message + "（" + strings.Join(msgs, " / ") + "）"
+}
@@ -12,11 +12,12 @@ import (
  * RunIDで同じ計測実行に属するセッションをグループ化する
  */
 type WorkSession struct {
-	ID        uuid.UUID  `json:"id"`
-	RunID     uuid.UUID  `json:"run_id"`
-	TaskID    uuid.UUID  `json:"task_id"`
-	StartTime time.Time  `json:"start_time"`
-	EndTime   *time.Time `json:"end_time"`
+	ID          uuid.UUID  `json:"id"`
+	RunID       uuid.UUID  `json:"run_id"`
+	TaskID      uuid.UUID  `json:"task_id"`
+	StartTime   time.Time  `json:"start_time"`
+	EndTime     *time.Time `json:"end_time"`
+	HeartbeatAt *time.Time `json:"heartbeat_at"`
 }
 
 /*
@@ -43,6 +44,16 @@ func (s *WorkSession) Stop(now time.Time) error {
 	return nil
 }
 
+/*
+ * 最後に生存が確認できた時刻（ハートビートがなければ開始時刻）
+ */
+func (s WorkSession) LastSeen() time.Time {
+	if s.HeartbeatAt != nil && s.HeartbeatAt.After(s.StartTime) {
+		return *s.HeartbeatAt
+	}
+	return s.StartTime
+}
+
 /*
  * セッションの作業時間（停止済みの場合のみ有効）
  *
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// 最後にアプリを正常終了した時刻
+const AppStateLastShutdownAt = "last_shutdown_at"
+
+type AppStateRepository interface {
+	GetTime(ctx context.Context, key string) (*time.Time, error)
+	SetTime(ctx context.Context, key string, value time.Time) error
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type appStateRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param db データベース
+ * @return インスタンス
+ */
+func NewAppStateRepositoryImpl(db *sql.DB) AppStateRepository {
+	return &appStateRepositoryImpl{db: sqlx.NewDb(db, "libsql")}
+}
+
+/*
+ * 時刻の値を取得（未設定の場合はnil）
+ *
+ * @param ctx コンテキスト
+ * @param key キー
+ * @return 時刻, エラー
+ */
+func (r *appStateRepositoryImpl) GetTime(ctx context.Context, key string) (*time.Time, error) {
+	var value time.Time
+	err := sqlx.GetContext(ctx, conn(ctx, r.db), &value,
+		`SELECT value FROM app_state WHERE key = ?`,
+		key,
+	)
+
+	// エラーチェック
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &value, nil
+}
+
+/*
+ * 時刻の値を保存
+ *
+ * @param ctx コンテキスト
+ * @param key キー
+ * @param value 時刻
+ * @return エラー
+ */
+func (r *appStateRepositoryImpl) SetTime(ctx context.Context, key string, value time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO app_state (key, value) VALUES (?, ?) 
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value`,
+		key, value,
+	)
+	return err
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"play-wails/internal/model"
+
+	"github.com/google/uuid"
+)
+
+type AuditLogRepository interface {
+	Create(ctx context.Context, entry *model.AuditEntry) error
+	ListByRow(ctx context.Context, table model.AuditTable, rowID uuid.UUID) ([]*model.AuditEntry, error)
+	ListByRunID(ctx context.Context, runID uuid.UUID) ([]*model.AuditEntry, error)
+}
+
+// コンテキストに変更理由を格納するキー
+type auditReasonKey struct{}
+
+/*
+ * 変更履歴に記録する変更理由をコンテキストに設定する
+ * 先に設定された理由を優先する（画面で入力された理由を、サービスの既定の理由で上書きしない）
+ *
+ * @param ctx コンテキスト
+ * @param reason 変更理由（空の場合は何もしない）
+ * @return 変更理由を設定したコンテキスト
+ */
+func WithAuditReason(ctx context.Context, reason string) context.Context {
+	if reason == "" || auditReason(ctx) != "" {
+		return ctx
+	}
+	return context.WithValue(ctx, auditReasonKey{}, reason)
+}
+
+/*
+ * コンテキストの変更理由を取得する（未設定の場合は空）
+ */
+func auditReason(ctx context.Context) string {
+	reason, _ := ctx.Value(auditReasonKey{}).(string)
+	return reason
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"play-wails/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type auditLogRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+// UUIDはTEXT、変更前後の内容はJSONのTEXTのためstringで受ける
+type auditLogRow struct {
+	ID         string         `db:"id"`
+	TableName  string         `db:"table_name"`
+	RowID      string         `db:"row_id"`
+	RunID      string         `db:"run_id"`
+	Action     string         `db:"action"`
+	BeforeData sql.NullString `db:"before_data"`
+	AfterData  sql.NullString `db:"after_data"`
+	Reason     string         `db:"reason"`
+	Actor      string         `db:"actor"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+/*
+ * レコードをモデルに変換
+ *
+ * @param row レコード
+ * @return モデル, エラー
+ */
+func rowToAuditEntry(row *auditLogRow) (*model.AuditEntry, error) {
+
+	e := &model.AuditEntry{
+		Table:     model.AuditTable(row.TableName),
+		Action:    model.AuditAction(row.Action),
+		Reason:    row.Reason,
+		Actor:     row.Actor,
+		CreatedAt: row.CreatedAt,
+	}
+	e.ID, _ = uuid.Parse(row.ID)
+	e.RowID, _ = uuid.Parse(row.RowID)
+	e.RunID, _ = uuid.Parse(row.RunID)
+
+	if row.BeforeData.Valid {
+		if err := json.Unmarshal([]byte(row.BeforeData.String), &e.Before); err != nil {
+			return nil, err
+		}
+	}
+	if row.AfterData.Valid {
+		if err := json.Unmarshal([]byte(row.AfterData.String), &e.After); err != nil {
+			return nil, err
+		}
+	}
+
+	return e, nil
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param db データベース
+ * @return インスタンス
+ */
+func NewAuditLogRepositoryImpl(db *sql.DB) AuditLogRepository {
+	return &auditLogRepositoryImpl{db: sqlx.NewDb(db, "libsql")}
+}
+
+/*
+ * レコード作成
+ *
+ * @param ctx コンテキスト
+ * @param entry レコード
+ * @return エラー
+ */
+func (r *auditLogRepositoryImpl) Create(ctx context.Context, entry *model.AuditEntry) error {
+	query := `INSERT INTO audit_log (
+		id
+		, table_name
+		, row_id
+		, run_id
+		, action
+		, before_data
+		, after_data
+		, reason
+		, actor
+		, created_at
+	) VALUES (
+		:id
+		, :table_name
+		, :row_id
+		, :run_id
+		, :action
+		, :before_data
+		, :after_data
+		, :reason
+		, :actor
+		, :created_at
+	)`
+
+	before, err := marshalAuditData(entry.Before)
+	if err != nil {
+		return err
+	}
+	after, err := marshalAuditData(entry.After)
+	if err != nil {
+		return err
+	}
+
+	// インサート処理実行
+	_, err = sqlx.NamedExecContext(ctx, conn(ctx, r.db), query, map[string]interface{}{
+		"id":          entry.ID.String(),
+		"table_name":  string(entry.Table),
+		"row_id":      entry.RowID.String(),
+		"run_id":      entry.RunID.String(),
+		"action":      string(entry.Action),
+		"before_data": before,
+		"after_data":  after,
+		"reason":      entry.Reason,
+		"actor":       entry.Actor,
+		"created_at":  entry.CreatedAt,
+	})
+
+	return err
+}
+
+/*
+ * 指定した行の変更履歴を取得（古い順）
+ *
+ * @param ctx コンテキスト
+ * @param table テーブル
+ * @param rowID 行のID
+ * @return 変更履歴, エラー
+ */
+func (r *auditLogRepositoryImpl) ListByRow(ctx context.Context, table model.AuditTable, rowID uuid.UUID) ([]*model.AuditEntry, error) {
+	query := `SELECT * FROM audit_log
+		WHERE table_name = ? AND row_id = ?
+		ORDER BY julianday(created_at), rowid`
+
+	return r.list(ctx, query, string(table), rowID.String())
+}
+
+/*
+ * 計測実行に属する計測結果・作業セッションの変更履歴を取得（古い順）
+ *
+ * @param ctx コンテキスト
+ * @param runID 計測実行のグループID
+ * @return 変更履歴, エラー
+ */
+func (r *auditLogRepositoryImpl) ListByRunID(ctx context.Context, runID uuid.UUID) ([]*model.AuditEntry, error) {
+	query := `SELECT * FROM audit_log
+		WHERE run_id = ?
+		ORDER BY julianday(created_at), rowid`
+
+	return r.list(ctx, query, runID.String())
+}
+
+/*
+ * 変更履歴を検索してモデルに変換
+ */
+func (r *auditLogRepositoryImpl) list(ctx context.Context, query string, args ...interface{}) ([]*model.AuditEntry, error) {
+	var rows []*auditLogRow
+	if err := sqlx.SelectContext(ctx, conn(ctx, r.db), &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	entries := make([]*model.AuditEntry, 0, len(rows))
+	for _, row := range rows {
+		e, err := rowToAuditEntry(row)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+/*
+ * 変更前後の内容をJSONにする（nilの場合はNULL）
+ */
+func marshalAuditData(data map[string]interface{}) (interface{}, error) {
+	if data == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
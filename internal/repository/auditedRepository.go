@@ -0,0 +1,332 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"play-wails/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+ * 計測結果・作業セッションのリポジトリの変更を変更履歴に記録する
+ * 各リポジトリをラップし、変更と履歴の記録を同じトランザクションで行う
+ * 同期による取り込みはリポジトリを経由しないため記録しない（変更した端末の履歴が同期される）
+ */
+type auditRecorder struct {
+	tx    Transactor
+	audit AuditLogRepository
+	actor string
+}
+
+/*
+ * 変更履歴を1件記録する
+ *
+ * @param ctx コンテキスト
+ * @param table テーブル
+ * @param rowID 行のID
+ * @param runID 計測実行のグループID
+ * @param action 変更の種類
+ * @param before 変更前の内容（なければnil）
+ * @param after 変更後の内容（なければnil）
+ * @return エラー
+ */
+func (a *auditRecorder) record(ctx context.Context, table model.AuditTable, rowID uuid.UUID, runID uuid.UUID, action model.AuditAction, before interface{}, after interface{}) error {
+	beforeData, err := toAuditData(before)
+	if err != nil {
+		return err
+	}
+	afterData, err := toAuditData(after)
+	if err != nil {
+		return err
+	}
+
+	return a.audit.Create(ctx, &model.AuditEntry{
+		ID:        uuid.New(),
+		Table:     table,
+		RowID:     rowID,
+		RunID:     runID,
+		Action:    action,
+		Before:    beforeData,
+		After:     afterData,
+		Reason:    auditReason(ctx),
+		Actor:     a.actor,
+		CreatedAt: time.Now(),
+	})
+}
+
+/*
+ * モデルを変更履歴に保存する形式（JSONのキーと値）にする
+ */
+func toAuditData(v interface{}) (map[string]interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+type auditedTimeRecordRepository struct {
+	TimeRecordRepository
+	auditRecorder
+}
+
+/*
+ * 変更履歴を記録する計測結果リポジトリのインスタンス生成
+ *
+ * @param inner 計測結果リポジトリ
+ * @param tx トランザクション
+ * @param audit 変更履歴リポジトリ
+ * @param actor 変更した端末（ユーザー名@ホスト名）
+ * @return インスタンス
+ */
+func NewAuditedTimeRecordRepository(inner TimeRecordRepository, tx Transactor, audit AuditLogRepository, actor string) TimeRecordRepository {
+	return &auditedTimeRecordRepository{
+		TimeRecordRepository: inner,
+		auditRecorder:        auditRecorder{tx: tx, audit: audit, actor: actor},
+	}
+}
+
+/*
+ * レコード作成（作成を記録）
+ *
+ * @param ctx コンテキスト
+ * @param record レコード
+ * @return エラー
+ */
+func (r *auditedTimeRecordRepository) Create(ctx context.Context, record *model.TimeRecord) error {
+	return r.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := r.TimeRecordRepository.Create(ctx, record); err != nil {
+			return err
+		}
+		return r.record(ctx, model.AuditTableTimeRecords, record.ID, record.RunID, model.AuditActionCreate, nil, record)
+	})
+}
+
+/*
+ * レコード更新（内容が変わった場合のみ変更前後を記録）
+ *
+ * @param ctx コンテキスト
+ * @param record レコード
+ * @return エラー
+ */
+func (r *auditedTimeRecordRepository) Update(ctx context.Context, record *model.TimeRecord) error {
+	return r.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := r.FindByID(ctx, record.ID)
+		if err != nil {
+			return err
+		}
+		if err := r.TimeRecordRepository.Update(ctx, record); err != nil {
+			return err
+		}
+		return r.recordChange(ctx, before)
+	})
+}
+
+/*
+ * 論理削除（削除を記録）
+ *
+ * @param ctx コンテキスト
+ * @param id ID
+ * @return エラー
+ */
+func (r *auditedTimeRecordRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := r.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := r.TimeRecordRepository.Delete(ctx, id); err != nil {
+			return err
+		}
+		return r.recordChange(ctx, before)
+	})
+}
+
+/*
+ * 論理削除の取り消し（復元を記録）
+ *
+ * @param ctx コンテキスト
+ * @param id ID
+ * @return エラー
+ */
+func (r *auditedTimeRecordRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return r.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := r.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := r.TimeRecordRepository.Restore(ctx, id); err != nil {
+			return err
+		}
+		return r.recordChange(ctx, before)
+	})
+}
+
+/*
+ * 物理削除（削除前の内容を記録）
+ *
+ * @param ctx コンテキスト
+ * @param id ID
+ * @return エラー
+ */
+func (r *auditedTimeRecordRepository) Purge(ctx context.Context, id uuid.UUID) error {
+	return r.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := r.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := r.TimeRecordRepository.Purge(ctx, id); err != nil {
+			return err
+		}
+		return r.record(ctx, model.AuditTableTimeRecords, before.ID, before.RunID, model.AuditActionPurge, before, nil)
+	})
+}
+
+/*
+ * 保存後の内容を読み直し、変更前と異なれば記録する
+ * 削除フラグが立った場合は削除、外れた場合は復元として記録する
+ */
+func (r *auditedTimeRecordRepository) recordChange(ctx context.Context, before *model.TimeRecord) error {
+	after, err := r.FindByID(ctx, before.ID)
+	if err != nil {
+		return err
+	}
+	if sameTimeRecord(before, after) {
+		return nil
+	}
+
+	action := model.AuditActionUpdate
+	switch {
+	case !before.DeleteFlag && after.DeleteFlag:
+		action = model.AuditActionDelete
+	case before.DeleteFlag && !after.DeleteFlag:
+		action = model.AuditActionRestore
+	}
+	return r.record(ctx, model.AuditTableTimeRecords, after.ID, after.RunID, action, before, after)
+}
+
+type auditedWorkSessionRepository struct {
+	WorkSessionRepository
+	auditRecorder
+}
+
+/*
+ * 変更履歴を記録する作業セッションリポジトリのインスタンス生成
+ *
+ * @param inner 作業セッションリポジトリ
+ * @param tx トランザクション
+ * @param audit 変更履歴リポジトリ
+ * @param actor 変更した端末（ユーザー名@ホスト名）
+ * @return インスタンス
+ */
+func NewAuditedWorkSessionRepository(inner WorkSessionRepository, tx Transactor, audit AuditLogRepository, actor string) WorkSessionRepository {
+	return &auditedWorkSessionRepository{
+		WorkSessionRepository: inner,
+		auditRecorder:         auditRecorder{tx: tx, audit: audit, actor: actor},
+	}
+}
+
+/*
+ * レコード作成（作成を記録）
+ *
+ * @param ctx コンテキスト
+ * @param session レコード
+ * @return エラー
+ */
+func (r *auditedWorkSessionRepository) Create(ctx context.Context, session *model.WorkSession) error {
+	return r.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := r.WorkSessionRepository.Create(ctx, session); err != nil {
+			return err
+		}
+		return r.record(ctx, model.AuditTableWorkSessions, session.ID, session.RunID, model.AuditActionCreate, nil, session)
+	})
+}
+
+/*
+ * レコード更新（内容が変わった場合のみ変更前後を記録）
+ * ハートビートのみの更新は定期的に行われるため記録しない
+ *
+ * @param ctx コンテキスト
+ * @param session レコード
+ * @return エラー
+ */
+func (r *auditedWorkSessionRepository) Update(ctx context.Context, session *model.WorkSession) error {
+	return r.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := r.FindByID(ctx, session.ID)
+		if err != nil {
+			return err
+		}
+		if err := r.WorkSessionRepository.Update(ctx, session); err != nil {
+			return err
+		}
+		after, err := r.FindByID(ctx, session.ID)
+		if err != nil {
+			return err
+		}
+		if sameWorkSessionIgnoringHeartbeat(before, after) {
+			return nil
+		}
+		return r.record(ctx, model.AuditTableWorkSessions, after.ID, after.RunID, model.AuditActionUpdate, before, after)
+	})
+}
+
+/*
+ * レコード削除（削除前の内容を記録）
+ *
+ * @param ctx コンテキスト
+ * @param id ID
+ * @return エラー
+ */
+func (r *auditedWorkSessionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := r.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := r.WorkSessionRepository.Delete(ctx, id); err != nil {
+			return err
+		}
+		return r.record(ctx, model.AuditTableWorkSessions, before.ID, before.RunID, model.AuditActionDelete, before, nil)
+	})
+}
+
+/*
+ * 計測結果の内容が同じか判定
+ */
+func sameTimeRecord(a *model.TimeRecord, b *model.TimeRecord) bool {
+	return a.ID == b.ID && a.RunID == b.RunID && a.TaskID == b.TaskID && a.DeleteFlag == b.DeleteFlag &&
+		a.StartTime.Equal(b.StartTime) && a.EndTime.Equal(b.EndTime) && a.Duration == b.Duration &&
+		a.NonBillable == b.NonBillable && sameUUIDPtr(a.InvoiceID, b.InvoiceID)
+}
+
+/*
+ * nilを含めてUUIDが同じか判定
+ */
+func sameUUIDPtr(a *uuid.UUID, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+/*
+ * 作業セッションのハートビート以外の内容が同じか判定
+ */
+func sameWorkSessionIgnoringHeartbeat(a *model.WorkSession, b *model.WorkSession) bool {
+	if a.ID != b.ID || a.RunID != b.RunID || a.TaskID != b.TaskID || !a.StartTime.Equal(b.StartTime) {
+		return false
+	}
+	if a.EndTime == nil || b.EndTime == nil {
+		return a.EndTime == nil && b.EndTime == nil
+	}
+	return a.EndTime.Equal(*b.EndTime)
+}
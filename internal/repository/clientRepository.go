@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"play-wails/internal/model"
+
+	"github.com/google/uuid"
+)
+
+type ClientRepository interface {
+	Create(ctx context.Context, client *model.Client) error
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Client, error)
+	Update(ctx context.Context, client *model.Client) error
+	List(ctx context.Context, includeArchived bool) ([]*model.Client, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
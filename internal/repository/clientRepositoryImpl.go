@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"play-wails/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type clientRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+// UUIDはTEXT、アーカイブフラグは0/1の整数
+type clientRow struct {
+	ID        string    `db:"id"`
+	Name      string    `db:"name"`
+	Archived  int       `db:"archived"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+/*
+ * レコードをモデルに変換
+ *
+ * @param row レコード
+ * @return モデル
+ */
+func rowToClient(row *clientRow) *model.Client {
+	c := &model.Client{
+		Name:      row.Name,
+		Archived:  row.Archived != 0,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+	c.ID, _ = uuid.Parse(row.ID)
+	return c
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param db データベース
+ * @return インスタンス
+ */
+func NewClientRepositoryImpl(db *sql.DB) ClientRepository {
+	return &clientRepositoryImpl{db: sqlx.NewDb(db, "libsql")}
+}
+
+/*
+ * レコード作成
+ *
+ * @param ctx コンテキスト
+ * @param client レコード
+ * @return エラー
+ */
+func (r *clientRepositoryImpl) Create(ctx context.Context, client *model.Client) error {
+
+	// アーカイブフラグを取得
+	archived := 0
+	if client.Archived {
+		archived = 1
+	}
+
+	// インサートクエリ作成
+	query := `INSERT INTO clients (
+		id
+		, name
+		, archived
+		, created_at
+		, updated_at
+	) VALUES (
+		:id
+		, :name
+		, :archived
+		, :created_at
+		, :updated_at
+	)`
+
+	// インサート処理実行
+	_, err := sqlx.NamedExecContext(ctx, conn(ctx, r.db), query, map[string]interface{}{
+		"id":         client.ID.String(),
+		"name":       client.Name,
+		"archived":   archived,
+		"created_at": client.CreatedAt,
+		"updated_at": client.UpdatedAt,
+	})
+
+	return err
+}
+
+/*
+ * レコードを取得
+ *
+ * @param ctx コンテキスト
+ * @param id レコードID
+ * @return レコード, エラー
+ */
+func (r *clientRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*model.Client, error) {
+	var row clientRow
+	err := sqlx.GetContext(ctx, conn(ctx, r.db), &row,
+		`SELECT
+			id
+			, name
+			, archived
+			, created_at
+			, updated_at
+		FROM clients
+		WHERE id = ?`,
+		id.String(),
+	)
+
+	// エラーチェック
+	if err != nil {
+		return nil, err
+	}
+
+	// レコードをモデルに変換
+	return rowToClient(&row), nil
+}
+
+/*
+ * レコードを更新
+ *
+ * @param ctx コンテキスト
+ * @param client レコード
+ * @return エラー
+ */
+func (r *clientRepositoryImpl) Update(ctx context.Context, client *model.Client) error {
+
+	// アーカイブフラグを取得
+	archived := 0
+	if client.Archived {
+		archived = 1
+	}
+
+	query := `UPDATE clients SET
+		name = :name
+		, archived = :archived
+		, updated_at = :updated_at
+	WHERE id = :id`
+
+	// 更新処理実行
+	_, err := sqlx.NamedExecContext(ctx, conn(ctx, r.db), query, map[string]interface{}{
+		"id":         client.ID.String(),
+		"name":       client.Name,
+		"archived":   archived,
+		"updated_at": client.UpdatedAt,
+	})
+	return err
+}
+
+/*
+ * レコード一覧を取得（名前順）
+ *
+ * @param ctx コンテキスト
+ * @param includeArchived true のときアーカイブ済みも含める
+ * @return レコード一覧, エラー
+ */
+func (r *clientRepositoryImpl) List(ctx context.Context, includeArchived bool) ([]*model.Client, error) {
+	query :=
+		`SELECT
+			id
+			, name
+			, archived
+			, created_at
+			, updated_at
+		FROM clients`
+
+	// アーカイブ済みを除外する場合
+	if !includeArchived {
+		query += ` WHERE archived = 0`
+	}
+	query += ` ORDER BY name`
+
+	// レコード一覧を取得
+	var rows []clientRow
+	err := sqlx.SelectContext(ctx, conn(ctx, r.db), &rows, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// レコード一覧をモデルに変換
+	list := make([]*model.Client, 0, len(rows))
+	for i := range rows {
+		list = append(list, rowToClient(&rows[i]))
+	}
+
+	return list, nil
+}
+
+/*
+ * レコードを物理削除
+ * プロジェクトから参照されているクライアントは削除しない
+ *
+ * @param ctx コンテキスト
+ * @param id レコードID
+ * @return エラー
+ */
+func (r *clientRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM clients
+		WHERE id = ?
+			AND NOT EXISTS (SELECT 1 FROM projects WHERE client_id = ?)`,
+		id.String(), id.String(),
+	)
+	if err != nil {
+		return err
+	}
+
+	// 削除件数が0件の場合は未登録または参照中
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.New("【ERROR】クライアントが存在しないか、プロジェクトから参照されているため削除できません。アーカイブしてください。")
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"play-wails/infarstructure/db"
+	"testing"
+)
+
+/*
+ * テスト用にインメモリDBを開き、マイグレーションを適用する
+ * テスト終了時に閉じる
+ */
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	d, err := db.Open(db.Config{Mode: db.ModeMemory})
+	if err != nil {
+		t.Fatalf("DBを開けません: %v", err)
+	}
+	t.Cleanup(func() { d.Close() })
+	if _, err := d.Migrate(context.Background()); err != nil {
+		t.Fatalf("マイグレーションに失敗しました: %v", err)
+	}
+	return d.DB()
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"play-wails/internal/model"
+
+	"github.com/google/uuid"
+)
+
+type ImportFingerprintRepository interface {
+	Create(ctx context.Context, fp *model.ImportFingerprint) error
+	FindExisting(ctx context.Context, ids []string) (map[string]bool, error)
+	DeleteByTimeRecordID(ctx context.Context, timeRecordID uuid.UUID) error
+}
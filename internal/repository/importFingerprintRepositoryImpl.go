@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"play-wails/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type importFingerprintRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param db データベース
+ * @return インスタンス
+ */
+func NewImportFingerprintRepositoryImpl(db *sql.DB) ImportFingerprintRepository {
+	return &importFingerprintRepositoryImpl{db: sqlx.NewDb(db, "libsql")}
+}
+
+/*
+ * レコード作成
+ *
+ * @param ctx コンテキスト
+ * @param fp レコード
+ * @return エラー
+ */
+func (r *importFingerprintRepositoryImpl) Create(ctx context.Context, fp *model.ImportFingerprint) error {
+	query := `INSERT INTO import_fingerprints (
+		id
+		, source
+		, time_record_id
+		, created_at
+	) VALUES (
+		:id
+		, :source
+		, :time_record_id
+		, :created_at
+	)`
+
+	// インサート処理実行
+	_, err := sqlx.NamedExecContext(ctx, conn(ctx, r.db), query, map[string]interface{}{
+		"id":             fp.ID,
+		"source":         string(fp.Source),
+		"time_record_id": fp.TimeRecordID.String(),
+		"created_at":     fp.CreatedAt,
+	})
+
+	return err
+}
+
+/*
+ * 指定したIDのうち登録済みのものを取得
+ *
+ * @param ctx コンテキスト
+ * @param ids ID一覧
+ * @return 登録済みのID, エラー
+ */
+func (r *importFingerprintRepositoryImpl) FindExisting(ctx context.Context, ids []string) (map[string]bool, error) {
+	found := make(map[string]bool)
+	if len(ids) == 0 {
+		return found, nil
+	}
+
+	// IN句のパラメータ数の上限を超えないよう分割して検索
+	const chunk = 500
+	for i := 0; i < len(ids); i += chunk {
+		end := i + chunk
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		query, args, err := sqlx.In(`SELECT id FROM import_fingerprints WHERE id IN (?)`, ids[i:end])
+		if err != nil {
+			return nil, err
+		}
+		var rows []string
+		if err := sqlx.SelectContext(ctx, conn(ctx, r.db), &rows, query, args...); err != nil {
+			return nil, err
+		}
+		for _, id := range rows {
+			found[id] = true
+		}
+	}
+
+	return found, nil
+}
+
+/*
+ * 計測結果の取り込み記録を削除（削除後は同じ行を再度取り込める）
+ *
+ * @param ctx コンテキスト
+ * @param timeRecordID 計測結果ID
+ * @return エラー
+ */
+func (r *importFingerprintRepositoryImpl) DeleteByTimeRecordID(ctx context.Context, timeRecordID uuid.UUID) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM import_fingerprints WHERE time_record_id = ?`,
+		timeRecordID.String(),
+	)
+	return err
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"play-wails/internal/model"
+
+	"github.com/google/uuid"
+)
+
+type InvoiceRepository interface {
+	Create(ctx context.Context, invoice *model.Invoice) error
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Invoice, error)
+	List(ctx context.Context, clientID *uuid.UUID) ([]*model.Invoice, error)
+	UpdateStatus(ctx context.Context, invoice *model.Invoice) error
+	LastNumber(ctx context.Context, prefix string) (string, error)
+}
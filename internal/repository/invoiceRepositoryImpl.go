@@ -0,0 +1,361 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"play-wails/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type invoiceRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+// UUIDはTEXT、丸めの間隔はナノ秒整数
+type invoiceRow struct {
+	ID                  string     `db:"id"`
+	Number              string     `db:"number"`
+	ClientID            string     `db:"client_id"`
+	ClientName          string     `db:"client_name"`
+	PeriodFrom          time.Time  `db:"period_from"`
+	PeriodTo            time.Time  `db:"period_to"`
+	TimeZone            string     `db:"time_zone"`
+	Currency            string     `db:"currency"`
+	RoundingIncrementNs int64      `db:"rounding_increment_ns"`
+	RoundingScope       string     `db:"rounding_scope"`
+	Total               int64      `db:"total"`
+	Status              string     `db:"status"`
+	IssuedAt            time.Time  `db:"issued_at"`
+	VoidedAt            *time.Time `db:"voided_at"`
+	CreatedAt           time.Time  `db:"created_at"`
+	UpdatedAt           time.Time  `db:"updated_at"`
+}
+
+// UUIDはTEXT、請求時間はナノ秒整数
+type invoiceLineRow struct {
+	ID               string `db:"id"`
+	InvoiceID        string `db:"invoice_id"`
+	LineNo           int    `db:"line_no"`
+	TaskID           string `db:"task_id"`
+	Description      string `db:"description"`
+	BilledDurationNs int64  `db:"billed_duration_ns"`
+	HourlyRate       int64  `db:"hourly_rate"`
+	Amount           int64  `db:"amount"`
+}
+
+// 請求書の取得に使う列
+const invoiceSelect = `SELECT
+			id
+			, number
+			, client_id
+			, client_name
+			, period_from
+			, period_to
+			, time_zone
+			, currency
+			, rounding_increment_ns
+			, rounding_scope
+			, total
+			, status
+			, issued_at
+			, voided_at
+			, created_at
+			, updated_at
+		FROM invoices`
+
+/*
+ * レコードをモデルに変換
+ *
+ * @param row レコード
+ * @return モデル
+ */
+func rowToInvoice(row *invoiceRow) *model.Invoice {
+	inv := &model.Invoice{
+		Number:     row.Number,
+		ClientName: row.ClientName,
+		PeriodFrom: row.PeriodFrom,
+		PeriodTo:   row.PeriodTo,
+		TimeZone:   row.TimeZone,
+		Currency:   row.Currency,
+		Rounding: model.RoundingRule{
+			Increment: time.Duration(row.RoundingIncrementNs),
+			Scope:     model.RoundingScope(row.RoundingScope),
+		},
+		Total:     row.Total,
+		Status:    model.InvoiceStatus(row.Status),
+		IssuedAt:  row.IssuedAt,
+		VoidedAt:  row.VoidedAt,
+		Lines:     make([]model.InvoiceLine, 0),
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+	inv.ID, _ = uuid.Parse(row.ID)
+	inv.ClientID, _ = uuid.Parse(row.ClientID)
+	return inv
+}
+
+/*
+ * 明細のレコードをモデルに変換
+ *
+ * @param row レコード
+ * @return モデル
+ */
+func rowToInvoiceLine(row *invoiceLineRow) model.InvoiceLine {
+	l := model.InvoiceLine{
+		LineNo:         row.LineNo,
+		Description:    row.Description,
+		BilledDuration: time.Duration(row.BilledDurationNs),
+		HourlyRate:     row.HourlyRate,
+		Amount:         row.Amount,
+	}
+	l.ID, _ = uuid.Parse(row.ID)
+	l.InvoiceID, _ = uuid.Parse(row.InvoiceID)
+	l.TaskID, _ = uuid.Parse(row.TaskID)
+	return l
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param db データベース
+ * @return インスタンス
+ */
+func NewInvoiceRepositoryImpl(db *sql.DB) InvoiceRepository {
+	return &invoiceRepositoryImpl{db: sqlx.NewDb(db, "libsql")}
+}
+
+/*
+ * 請求書と明細を作成
+ *
+ * @param ctx コンテキスト
+ * @param invoice レコード
+ * @return エラー
+ */
+func (r *invoiceRepositoryImpl) Create(ctx context.Context, invoice *model.Invoice) error {
+
+	// インサートクエリ作成
+	query := `INSERT INTO invoices (
+		id
+		, number
+		, client_id
+		, client_name
+		, period_from
+		, period_to
+		, time_zone
+		, currency
+		, rounding_increment_ns
+		, rounding_scope
+		, total
+		, status
+		, issued_at
+		, voided_at
+		, created_at
+		, updated_at
+	) VALUES (
+		:id
+		, :number
+		, :client_id
+		, :client_name
+		, :period_from
+		, :period_to
+		, :time_zone
+		, :currency
+		, :rounding_increment_ns
+		, :rounding_scope
+		, :total
+		, :status
+		, :issued_at
+		, :voided_at
+		, :created_at
+		, :updated_at
+	)`
+
+	// インサート処理実行
+	_, err := sqlx.NamedExecContext(ctx, conn(ctx, r.db), query, map[string]interface{}{
+		"id":                    invoice.ID.String(),
+		"number":                invoice.Number,
+		"client_id":             invoice.ClientID.String(),
+		"client_name":           invoice.ClientName,
+		"period_from":           invoice.PeriodFrom,
+		"period_to":             invoice.PeriodTo,
+		"time_zone":             invoice.TimeZone,
+		"currency":              invoice.Currency,
+		"rounding_increment_ns": invoice.Rounding.Increment.Nanoseconds(),
+		"rounding_scope":        string(invoice.Rounding.Scope),
+		"total":                 invoice.Total,
+		"status":                string(invoice.Status),
+		"issued_at":             invoice.IssuedAt,
+		"voided_at":             invoice.VoidedAt,
+		"created_at":            invoice.CreatedAt,
+		"updated_at":            invoice.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	// 明細を作成
+	for _, line := range invoice.Lines {
+		_, err := sqlx.NamedExecContext(ctx, conn(ctx, r.db), `INSERT INTO invoice_lines (
+			id
+			, invoice_id
+			, line_no
+			, task_id
+			, description
+			, billed_duration_ns
+			, hourly_rate
+			, amount
+			, created_at
+		) VALUES (
+			:id
+			, :invoice_id
+			, :line_no
+			, :task_id
+			, :description
+			, :billed_duration_ns
+			, :hourly_rate
+			, :amount
+			, :created_at
+		)`, map[string]interface{}{
+			"id":                 line.ID.String(),
+			"invoice_id":         invoice.ID.String(),
+			"line_no":            line.LineNo,
+			"task_id":            line.TaskID.String(),
+			"description":        line.Description,
+			"billed_duration_ns": line.BilledDuration.Nanoseconds(),
+			"hourly_rate":        line.HourlyRate,
+			"amount":             line.Amount,
+			"created_at":         invoice.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/*
+ * 請求書を明細付きで取得
+ *
+ * @param ctx コンテキスト
+ * @param id レコードID
+ * @return レコード, エラー
+ */
+func (r *invoiceRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*model.Invoice, error) {
+	var row invoiceRow
+	err := sqlx.GetContext(ctx, conn(ctx, r.db), &row,
+		invoiceSelect+` WHERE id = ?`,
+		id.String(),
+	)
+
+	// エラーチェック
+	if err != nil {
+		return nil, err
+	}
+
+	// 明細を取得
+	var lines []invoiceLineRow
+	err = sqlx.SelectContext(ctx, conn(ctx, r.db), &lines,
+		`SELECT
+			id
+			, invoice_id
+			, line_no
+			, task_id
+			, description
+			, billed_duration_ns
+			, hourly_rate
+			, amount
+		FROM invoice_lines
+		WHERE invoice_id = ?
+		ORDER BY line_no`,
+		id.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// レコードをモデルに変換
+	invoice := rowToInvoice(&row)
+	for i := range lines {
+		invoice.Lines = append(invoice.Lines, rowToInvoiceLine(&lines[i]))
+	}
+	return invoice, nil
+}
+
+/*
+ * 請求書の一覧を取得（発行日時の新しい順、明細は含まない）
+ *
+ * @param ctx コンテキスト
+ * @param clientID 絞り込むクライアントID（nilの場合は全件）
+ * @return レコード一覧, エラー
+ */
+func (r *invoiceRepositoryImpl) List(ctx context.Context, clientID *uuid.UUID) ([]*model.Invoice, error) {
+	query := invoiceSelect
+	args := make([]interface{}, 0, 1)
+
+	// クライアントで絞り込む場合
+	if clientID != nil {
+		query += ` WHERE client_id = ?`
+		args = append(args, clientID.String())
+	}
+	query += ` ORDER BY julianday(issued_at) DESC, number DESC`
+
+	var rows []invoiceRow
+	if err := sqlx.SelectContext(ctx, conn(ctx, r.db), &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	// レコード一覧をモデルに変換
+	list := make([]*model.Invoice, 0, len(rows))
+	for i := range rows {
+		list = append(list, rowToInvoice(&rows[i]))
+	}
+	return list, nil
+}
+
+/*
+ * 請求書の状態を更新
+ *
+ * @param ctx コンテキスト
+ * @param invoice レコード
+ * @return エラー
+ */
+func (r *invoiceRepositoryImpl) UpdateStatus(ctx context.Context, invoice *model.Invoice) error {
+	query := `UPDATE invoices SET
+		status = :status
+		, voided_at = :voided_at
+		, updated_at = :updated_at
+	WHERE id = :id`
+
+	// 更新処理実行
+	_, err := sqlx.NamedExecContext(ctx, conn(ctx, r.db), query, map[string]interface{}{
+		"id":         invoice.ID.String(),
+		"status":     string(invoice.Status),
+		"voided_at":  invoice.VoidedAt,
+		"updated_at": invoice.UpdatedAt,
+	})
+	return err
+}
+
+/*
+ * 接頭辞が一致する請求書番号のうち最大のものを取得（ない場合は空文字）
+ *
+ * @param ctx コンテキスト
+ * @param prefix 請求書番号の接頭辞
+ * @return 請求書番号, エラー
+ */
+func (r *invoiceRepositoryImpl) LastNumber(ctx context.Context, prefix string) (string, error) {
+	var number string
+	err := sqlx.GetContext(ctx, conn(ctx, r.db), &number,
+		`SELECT number FROM invoices WHERE substr(number, 1, ?) = ? ORDER BY length(number) DESC, number DESC LIMIT 1`,
+		len(prefix), prefix,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return number, err
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"play-wails/internal/model"
+
+	"github.com/google/uuid"
+)
+
+type ProjectRepository interface {
+	Create(ctx context.Context, project *model.Project) error
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Project, error)
+	Update(ctx context.Context, project *model.Project) error
+	List(ctx context.Context, includeArchived bool) ([]*model.Project, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
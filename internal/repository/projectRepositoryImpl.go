@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"play-wails/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type projectRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+// UUIDはTEXT（クライアントなしはNULL）、アーカイブフラグは0/1の整数
+type projectRow struct {
+	ID        string    `db:"id"`
+	ClientID  *string   `db:"client_id"`
+	Name      string    `db:"name"`
+	Archived  int       `db:"archived"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+/*
+ * レコードをモデルに変換
+ *
+ * @param row レコード
+ * @return モデル
+ */
+func rowToProject(row *projectRow) *model.Project {
+	p := &model.Project{
+		Name:      row.Name,
+		Archived:  row.Archived != 0,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+	p.ID, _ = uuid.Parse(row.ID)
+	if row.ClientID != nil {
+		if id, err := uuid.Parse(*row.ClientID); err == nil {
+			p.ClientID = &id
+		}
+	}
+	return p
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param db データベース
+ * @return インスタンス
+ */
+func NewProjectRepositoryImpl(db *sql.DB) ProjectRepository {
+	return &projectRepositoryImpl{db: sqlx.NewDb(db, "libsql")}
+}
+
+/*
+ * レコード作成
+ *
+ * @param ctx コンテキスト
+ * @param project レコード
+ * @return エラー
+ */
+func (r *projectRepositoryImpl) Create(ctx context.Context, project *model.Project) error {
+
+	// アーカイブフラグを取得
+	archived := 0
+	if project.Archived {
+		archived = 1
+	}
+
+	// インサートクエリ作成
+	query := `INSERT INTO projects (
+		id
+		, client_id
+		, name
+		, archived
+		, created_at
+		, updated_at
+	) VALUES (
+		:id
+		, :client_id
+		, :name
+		, :archived
+		, :created_at
+		, :updated_at
+	)`
+
+	// インサート処理実行
+	_, err := sqlx.NamedExecContext(ctx, conn(ctx, r.db), query, map[string]interface{}{
+		"id":         project.ID.String(),
+		"client_id":  nullableUUID(project.ClientID),
+		"name":       project.Name,
+		"archived":   archived,
+		"created_at": project.CreatedAt,
+		"updated_at": project.UpdatedAt,
+	})
+
+	return err
+}
+
+/*
+ * レコードを取得
+ *
+ * @param ctx コンテキスト
+ * @param id レコードID
+ * @return レコード, エラー
+ */
+func (r *projectRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*model.Project, error) {
+	var row projectRow
+	err := sqlx.GetContext(ctx, conn(ctx, r.db), &row,
+		`SELECT
+			id
+			, client_id
+			, name
+			, archived
+			, created_at
+			, updated_at
+		FROM projects
+		WHERE id = ?`,
+		id.String(),
+	)
+
+	// エラーチェック
+	if err != nil {
+		return nil, err
+	}
+
+	// レコードをモデルに変換
+	return rowToProject(&row), nil
+}
+
+/*
+ * レコードを更新
+ *
+ * @param ctx コンテキスト
+ * @param project レコード
+ * @return エラー
+ */
+func (r *projectRepositoryImpl) Update(ctx context.Context, project *model.Project) error {
+
+	// アーカイブフラグを取得
+	archived := 0
+	if project.Archived {
+		archived = 1
+	}
+
+	query := `UPDATE projects SET
+		client_id = :client_id
+		, name = :name
+		, archived = :archived
+		, updated_at = :updated_at
+	WHERE id = :id`
+
+	// 更新処理実行
+	_, err := sqlx.NamedExecContext(ctx, conn(ctx, r.db), query, map[string]interface{}{
+		"id":         project.ID.String(),
+		"client_id":  nullableUUID(project.ClientID),
+		"name":       project.Name,
+		"archived":   archived,
+		"updated_at": project.UpdatedAt,
+	})
+	return err
+}
+
+/*
+ * レコード一覧を取得（名前順）
+ *
+ * @param ctx コンテキスト
+ * @param includeArchived true のときアーカイブ済みも含める
+ * @return レコード一覧, エラー
+ */
+func (r *projectRepositoryImpl) List(ctx context.Context, includeArchived bool) ([]*model.Project, error) {
+	query :=
+		`SELECT
+			id
+			, client_id
+			, name
+			, archived
+			, created_at
+			, updated_at
+		FROM projects`
+
+	// アーカイブ済みを除外する場合
+	if !includeArchived {
+		query += ` WHERE archived = 0`
+	}
+	query += ` ORDER BY name`
+
+	// レコード一覧を取得
+	var rows []projectRow
+	err := sqlx.SelectContext(ctx, conn(ctx, r.db), &rows, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// レコード一覧をモデルに変換
+	list := make([]*model.Project, 0, len(rows))
+	for i := range rows {
+		list = append(list, rowToProject(&rows[i]))
+	}
+
+	return list, nil
+}
+
+/*
+ * レコードを物理削除
+ * タスクから参照されているプロジェクトは削除しない
+ *
+ * @param ctx コンテキスト
+ * @param id レコードID
+ * @return エラー
+ */
+func (r *projectRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM projects
+		WHERE id = ?
+			AND NOT EXISTS (SELECT 1 FROM tasks WHERE project_id = ?)`,
+		id.String(), id.String(),
+	)
+	if err != nil {
+		return err
+	}
+
+	// 削除件数が0件の場合は未登録または参照中
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.New("【ERROR】プロジェクトが存在しないか、タスクから参照されているため削除できません。アーカイブしてください。")
+	}
+
+	return nil
+}
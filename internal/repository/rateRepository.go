@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"play-wails/internal/model"
+
+	"github.com/google/uuid"
+)
+
+type RateRepository interface {
+	Create(ctx context.Context, rate *model.Rate) error
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Rate, error)
+	List(ctx context.Context) ([]*model.Rate, error)
+	ListByTarget(ctx context.Context, level model.RateLevel, targetID uuid.UUID) ([]*model.Rate, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"play-wails/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type rateRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+// UUIDはTEXT、単価は通貨の最小単位の整数
+type rateRow struct {
+	ID            string    `db:"id"`
+	Level         string    `db:"level"`
+	TargetID      string    `db:"target_id"`
+	HourlyRate    int64     `db:"hourly_rate"`
+	Currency      string    `db:"currency"`
+	EffectiveFrom time.Time `db:"effective_from"`
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
+}
+
+/*
+ * レコードをモデルに変換
+ *
+ * @param row レコード
+ * @return モデル
+ */
+func rowToRate(row *rateRow) *model.Rate {
+	r := &model.Rate{
+		Level:         model.RateLevel(row.Level),
+		HourlyRate:    row.HourlyRate,
+		Currency:      row.Currency,
+		EffectiveFrom: row.EffectiveFrom,
+		CreatedAt:     row.CreatedAt,
+		UpdatedAt:     row.UpdatedAt,
+	}
+	r.ID, _ = uuid.Parse(row.ID)
+	r.TargetID, _ = uuid.Parse(row.TargetID)
+	return r
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param db データベース
+ * @return インスタンス
+ */
+func NewRateRepositoryImpl(db *sql.DB) RateRepository {
+	return &rateRepositoryImpl{db: sqlx.NewDb(db, "libsql")}
+}
+
+/*
+ * レコード作成
+ *
+ * @param ctx コンテキスト
+ * @param rate レコード
+ * @return エラー
+ */
+func (r *rateRepositoryImpl) Create(ctx context.Context, rate *model.Rate) error {
+
+	// インサートクエリ作成
+	query := `INSERT INTO rates (
+		id
+		, level
+		, target_id
+		, hourly_rate
+		, currency
+		, effective_from
+		, created_at
+		, updated_at
+	) VALUES (
+		:id
+		, :level
+		, :target_id
+		, :hourly_rate
+		, :currency
+		, :effective_from
+		, :created_at
+		, :updated_at
+	)`
+
+	// インサート処理実行
+	_, err := sqlx.NamedExecContext(ctx, conn(ctx, r.db), query, map[string]interface{}{
+		"id":             rate.ID.String(),
+		"level":          string(rate.Level),
+		"target_id":      rate.TargetID.String(),
+		"hourly_rate":    rate.HourlyRate,
+		"currency":       rate.Currency,
+		"effective_from": rate.EffectiveFrom,
+		"created_at":     rate.CreatedAt,
+		"updated_at":     rate.UpdatedAt,
+	})
+
+	return err
+}
+
+/*
+ * レコードを取得
+ *
+ * @param ctx コンテキスト
+ * @param id レコードID
+ * @return レコード, エラー
+ */
+func (r *rateRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*model.Rate, error) {
+	var row rateRow
+	err := sqlx.GetContext(ctx, conn(ctx, r.db), &row,
+		`SELECT
+			id
+			, level
+			, target_id
+			, hourly_rate
+			, currency
+			, effective_from
+			, created_at
+			, updated_at
+		FROM rates
+		WHERE id = ?`,
+		id.String(),
+	)
+
+	// エラーチェック
+	if err != nil {
+		return nil, err
+	}
+
+	// レコードをモデルに変換
+	return rowToRate(&row), nil
+}
+
+/*
+ * レコード一覧を取得（適用開始日時の新しい順）
+ *
+ * @param ctx コンテキスト
+ * @return レコード一覧, エラー
+ */
+func (r *rateRepositoryImpl) List(ctx context.Context) ([]*model.Rate, error) {
+	var rows []rateRow
+	err := sqlx.SelectContext(ctx, conn(ctx, r.db), &rows,
+		`SELECT
+			id
+			, level
+			, target_id
+			, hourly_rate
+			, currency
+			, effective_from
+			, created_at
+			, updated_at
+		FROM rates
+		ORDER BY julianday(effective_from) DESC, id`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return rowsToRates(rows), nil
+}
+
+/*
+ * 対象のレコード一覧を取得（適用開始日時の新しい順）
+ *
+ * @param ctx コンテキスト
+ * @param level 階層
+ * @param targetID 対象のID
+ * @return レコード一覧, エラー
+ */
+func (r *rateRepositoryImpl) ListByTarget(ctx context.Context, level model.RateLevel, targetID uuid.UUID) ([]*model.Rate, error) {
+	var rows []rateRow
+	err := sqlx.SelectContext(ctx, conn(ctx, r.db), &rows,
+		`SELECT
+			id
+			, level
+			, target_id
+			, hourly_rate
+			, currency
+			, effective_from
+			, created_at
+			, updated_at
+		FROM rates
+		WHERE level = ?
+			AND target_id = ?
+		ORDER BY julianday(effective_from) DESC, id`,
+		string(level), targetID.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return rowsToRates(rows), nil
+}
+
+/*
+ * レコードを物理削除
+ *
+ * @param ctx コンテキスト
+ * @param id レコードID
+ * @return エラー
+ */
+func (r *rateRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM rates WHERE id = ?`,
+		id.String(),
+	)
+	return err
+}
+
+/*
+ * レコード一覧をモデルに変換
+ */
+func rowsToRates(rows []rateRow) []*model.Rate {
+	list := make([]*model.Rate, 0, len(rows))
+	for i := range rows {
+		list = append(list, rowToRate(&rows[i]))
+	}
+	return list
+}
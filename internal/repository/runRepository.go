@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"play-wails/internal/model"
+
+	"github.com/google/uuid"
+)
+
+type RunRepository interface {
+	Create(ctx context.Context, run *model.Run) error
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Run, error)
+	Update(ctx context.Context, run *model.Run) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"play-wails/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type runRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+// UUIDはTEXTのためstringで受ける
+type runRow struct {
+	ID        string    `db:"id"`
+	TaskID    string    `db:"task_id"`
+	Status    string    `db:"status"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+/*
+ * レコードをモデルに変換
+ *
+ * @param row レコード
+ * @return モデル
+ */
+func rowToRun(row *runRow) *model.Run {
+	r := &model.Run{
+		Status:    model.RunStatus(row.Status),
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+	r.ID, _ = uuid.Parse(row.ID)
+	r.TaskID, _ = uuid.Parse(row.TaskID)
+	return r
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param db データベース
+ * @return インスタンス
+ */
+func NewRunRepositoryImpl(db *sql.DB) RunRepository {
+	return &runRepositoryImpl{db: sqlx.NewDb(db, "libsql")}
+}
+
+/*
+ * レコード作成
+ *
+ * @param ctx コンテキスト
+ * @param run レコード
+ * @return エラー
+ */
+func (r *runRepositoryImpl) Create(ctx context.Context, run *model.Run) error {
+	query := `INSERT INTO runs (
+		id
+		, task_id
+		, status
+		, created_at
+		, updated_at
+	) VALUES (
+		:id
+		, :task_id
+		, :status
+		, :created_at
+		, :updated_at
+	)`
+
+	// インサート処理実行
+	_, err := sqlx.NamedExecContext(ctx, conn(ctx, r.db), query, map[string]interface{}{
+		"id":         run.ID.String(),
+		"task_id":    run.TaskID.String(),
+		"status":     string(run.Status),
+		"created_at": run.CreatedAt,
+		"updated_at": run.UpdatedAt,
+	})
+
+	return err
+}
+
+/*
+ * レコードを取得
+ *
+ * @param ctx コンテキスト
+ * @param id レコードID
+ * @return レコード, エラー
+ */
+func (r *runRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*model.Run, error) {
+	var row runRow
+	err := sqlx.GetContext(ctx, conn(ctx, r.db), &row,
+		`SELECT 
+			id
+			, task_id
+			, status
+			, created_at
+			, updated_at 
+		FROM runs 
+		WHERE id = ?`,
+		id.String(),
+	)
+
+	// エラーチェック
+	if err != nil {
+		return nil, err
+	}
+
+	// レコードをモデルに変換
+	return rowToRun(&row), nil
+}
+
+/*
+ * レコードを更新（状態）
+ *
+ * @param ctx コンテキスト
+ * @param run レコード
+ * @return エラー
+ */
+func (r *runRepositoryImpl) Update(ctx context.Context, run *model.Run) error {
+	query := `UPDATE runs SET 
+		status = :status
+		, updated_at = :updated_at
+	WHERE id = :id`
+
+	// 更新処理実行
+	_, err := sqlx.NamedExecContext(ctx, conn(ctx, r.db), query, map[string]interface{}{
+		"id":         run.ID.String(),
+		"status":     string(run.Status),
+		"updated_at": run.UpdatedAt,
+	})
+	return err
+}
+
+/*
+ * レコードを削除
+ *
+ * @param ctx コンテキスト
+ * @param id レコードID
+ * @return エラー
+ */
+func (r *runRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM runs WHERE id = ?`,
+		id.String(),
+	)
+	return err
+}
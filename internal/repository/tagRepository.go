@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"play-wails/internal/model"
+
+	"github.com/google/uuid"
+)
+
+type TagRepository interface {
+	Create(ctx context.Context, tag *model.Tag) error
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Tag, error)
+	FindByName(ctx context.Context, name string) (*model.Tag, error)
+	Update(ctx context.Context, tag *model.Tag) error
+	List(ctx context.Context) ([]*model.Tag, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	AddToTask(ctx context.Context, taskID uuid.UUID, tagID uuid.UUID) error
+	RemoveFromTask(ctx context.Context, taskID uuid.UUID, tagID uuid.UUID) error
+	ListByTask(ctx context.Context, taskID uuid.UUID) ([]*model.Tag, error)
+	AddToTimeRecord(ctx context.Context, timeRecordID uuid.UUID, tagID uuid.UUID) error
+	RemoveFromTimeRecord(ctx context.Context, timeRecordID uuid.UUID, tagID uuid.UUID) error
+	ListByTimeRecord(ctx context.Context, timeRecordID uuid.UUID) ([]*model.Tag, error)
+	TaskIDsByTags(ctx context.Context, tagIDs []uuid.UUID) ([]uuid.UUID, error)
+	TimeRecordIDsByTags(ctx context.Context, tagIDs []uuid.UUID) ([]uuid.UUID, error)
+	MoveLinks(ctx context.Context, fromID uuid.UUID, toID uuid.UUID) error
+}
@@ -0,0 +1,447 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"play-wails/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type tagRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+// UUIDはTEXT
+type tagRow struct {
+	ID        string    `db:"id"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+/*
+ * レコードをモデルに変換
+ *
+ * @param row レコード
+ * @return モデル
+ */
+func rowToTag(row *tagRow) *model.Tag {
+	t := &model.Tag{
+		Name:      row.Name,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+	t.ID, _ = uuid.Parse(row.ID)
+	return t
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param db データベース
+ * @return インスタンス
+ */
+func NewTagRepositoryImpl(db *sql.DB) TagRepository {
+	return &tagRepositoryImpl{db: sqlx.NewDb(db, "libsql")}
+}
+
+/*
+ * レコード作成
+ *
+ * @param ctx コンテキスト
+ * @param tag レコード
+ * @return エラー
+ */
+func (r *tagRepositoryImpl) Create(ctx context.Context, tag *model.Tag) error {
+
+	// インサートクエリ作成
+	query := `INSERT INTO tags (
+		id
+		, name
+		, created_at
+		, updated_at
+	) VALUES (
+		:id
+		, :name
+		, :created_at
+		, :updated_at
+	)`
+
+	// インサート処理実行
+	_, err := sqlx.NamedExecContext(ctx, conn(ctx, r.db), query, map[string]interface{}{
+		"id":         tag.ID.String(),
+		"name":       tag.Name,
+		"created_at": tag.CreatedAt,
+		"updated_at": tag.UpdatedAt,
+	})
+
+	return err
+}
+
+/*
+ * レコードを取得
+ *
+ * @param ctx コンテキスト
+ * @param id レコードID
+ * @return レコード, エラー
+ */
+func (r *tagRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*model.Tag, error) {
+	var row tagRow
+	err := sqlx.GetContext(ctx, conn(ctx, r.db), &row,
+		`SELECT
+			id
+			, name
+			, created_at
+			, updated_at
+		FROM tags
+		WHERE id = ?`,
+		id.String(),
+	)
+
+	// エラーチェック
+	if err != nil {
+		return nil, err
+	}
+
+	// レコードをモデルに変換
+	return rowToTag(&row), nil
+}
+
+/*
+ * 名前が一致するレコードを取得（大文字・小文字は区別しない）
+ *
+ * @param ctx コンテキスト
+ * @param name 名前
+ * @return レコード, エラー
+ */
+func (r *tagRepositoryImpl) FindByName(ctx context.Context, name string) (*model.Tag, error) {
+	var row tagRow
+	err := sqlx.GetContext(ctx, conn(ctx, r.db), &row,
+		`SELECT
+			id
+			, name
+			, created_at
+			, updated_at
+		FROM tags
+		WHERE name = ? COLLATE NOCASE
+		ORDER BY created_at, id
+		LIMIT 1`,
+		name,
+	)
+
+	// エラーチェック
+	if err != nil {
+		return nil, err
+	}
+
+	// レコードをモデルに変換
+	return rowToTag(&row), nil
+}
+
+/*
+ * レコードを更新
+ *
+ * @param ctx コンテキスト
+ * @param tag レコード
+ * @return エラー
+ */
+func (r *tagRepositoryImpl) Update(ctx context.Context, tag *model.Tag) error {
+	query := `UPDATE tags SET
+		name = :name
+		, updated_at = :updated_at
+	WHERE id = :id`
+
+	// 更新処理実行
+	_, err := sqlx.NamedExecContext(ctx, conn(ctx, r.db), query, map[string]interface{}{
+		"id":         tag.ID.String(),
+		"name":       tag.Name,
+		"updated_at": tag.UpdatedAt,
+	})
+	return err
+}
+
+/*
+ * レコード一覧を取得（名前順）
+ *
+ * @param ctx コンテキスト
+ * @return レコード一覧, エラー
+ */
+func (r *tagRepositoryImpl) List(ctx context.Context) ([]*model.Tag, error) {
+	var rows []tagRow
+	err := sqlx.SelectContext(ctx, conn(ctx, r.db), &rows,
+		`SELECT
+			id
+			, name
+			, created_at
+			, updated_at
+		FROM tags
+		ORDER BY name COLLATE NOCASE`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return rowsToTags(rows), nil
+}
+
+/*
+ * レコードとタスク・計測結果のタグ付けを物理削除
+ *
+ * @param ctx コンテキスト
+ * @param id レコードID
+ * @return エラー
+ */
+func (r *tagRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	for _, query := range []string{
+		`DELETE FROM task_tags WHERE tag_id = ?`,
+		`DELETE FROM time_record_tags WHERE tag_id = ?`,
+		`DELETE FROM tags WHERE id = ?`,
+	} {
+		if _, err := conn(ctx, r.db).ExecContext(ctx, query, id.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+ * タスクにタグを付ける（付いている場合は何もしない）
+ *
+ * @param ctx コンテキスト
+ * @param taskID タスクID
+ * @param tagID タグID
+ * @return エラー
+ */
+func (r *tagRepositoryImpl) AddToTask(ctx context.Context, taskID uuid.UUID, tagID uuid.UUID) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`INSERT OR IGNORE INTO task_tags (
+			id
+			, task_id
+			, tag_id
+			, created_at
+		) VALUES (?, ?, ?, ?)`,
+		tagLinkID(taskID.String(), tagID.String()), taskID.String(), tagID.String(), time.Now(),
+	)
+	return err
+}
+
+/*
+ * タスクからタグを外す
+ *
+ * @param ctx コンテキスト
+ * @param taskID タスクID
+ * @param tagID タグID
+ * @return エラー
+ */
+func (r *tagRepositoryImpl) RemoveFromTask(ctx context.Context, taskID uuid.UUID, tagID uuid.UUID) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM task_tags WHERE id = ?`,
+		tagLinkID(taskID.String(), tagID.String()),
+	)
+	return err
+}
+
+/*
+ * タスクに付いているタグを取得（名前順）
+ *
+ * @param ctx コンテキスト
+ * @param taskID タスクID
+ * @return レコード一覧, エラー
+ */
+func (r *tagRepositoryImpl) ListByTask(ctx context.Context, taskID uuid.UUID) ([]*model.Tag, error) {
+	var rows []tagRow
+	err := sqlx.SelectContext(ctx, conn(ctx, r.db), &rows,
+		`SELECT
+			t.id
+			, t.name
+			, t.created_at
+			, t.updated_at
+		FROM tags t
+		JOIN task_tags l ON l.tag_id = t.id
+		WHERE l.task_id = ?
+		ORDER BY t.name COLLATE NOCASE`,
+		taskID.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return rowsToTags(rows), nil
+}
+
+/*
+ * 計測結果にタグを付ける（付いている場合は何もしない）
+ *
+ * @param ctx コンテキスト
+ * @param timeRecordID 計測結果ID
+ * @param tagID タグID
+ * @return エラー
+ */
+func (r *tagRepositoryImpl) AddToTimeRecord(ctx context.Context, timeRecordID uuid.UUID, tagID uuid.UUID) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`INSERT OR IGNORE INTO time_record_tags (
+			id
+			, time_record_id
+			, tag_id
+			, created_at
+		) VALUES (?, ?, ?, ?)`,
+		tagLinkID(timeRecordID.String(), tagID.String()), timeRecordID.String(), tagID.String(), time.Now(),
+	)
+	return err
+}
+
+/*
+ * 計測結果からタグを外す
+ *
+ * @param ctx コンテキスト
+ * @param timeRecordID 計測結果ID
+ * @param tagID タグID
+ * @return エラー
+ */
+func (r *tagRepositoryImpl) RemoveFromTimeRecord(ctx context.Context, timeRecordID uuid.UUID, tagID uuid.UUID) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM time_record_tags WHERE id = ?`,
+		tagLinkID(timeRecordID.String(), tagID.String()),
+	)
+	return err
+}
+
+/*
+ * 計測結果に付いているタグを取得（名前順、タスクのタグは含まない）
+ *
+ * @param ctx コンテキスト
+ * @param timeRecordID 計測結果ID
+ * @return レコード一覧, エラー
+ */
+func (r *tagRepositoryImpl) ListByTimeRecord(ctx context.Context, timeRecordID uuid.UUID) ([]*model.Tag, error) {
+	var rows []tagRow
+	err := sqlx.SelectContext(ctx, conn(ctx, r.db), &rows,
+		`SELECT
+			t.id
+			, t.name
+			, t.created_at
+			, t.updated_at
+		FROM tags t
+		JOIN time_record_tags l ON l.tag_id = t.id
+		WHERE l.time_record_id = ?
+		ORDER BY t.name COLLATE NOCASE`,
+		timeRecordID.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return rowsToTags(rows), nil
+}
+
+/*
+ * いずれかのタグが付いているタスクのIDを取得
+ *
+ * @param ctx コンテキスト
+ * @param tagIDs タグID一覧
+ * @return タスクID一覧, エラー
+ */
+func (r *tagRepositoryImpl) TaskIDsByTags(ctx context.Context, tagIDs []uuid.UUID) ([]uuid.UUID, error) {
+	return r.linkedIDs(ctx, `SELECT DISTINCT task_id FROM task_tags WHERE tag_id IN (?)`, tagIDs)
+}
+
+/*
+ * いずれかのタグが付いている計測結果のIDを取得（タスクのタグは含まない）
+ *
+ * @param ctx コンテキスト
+ * @param tagIDs タグID一覧
+ * @return 計測結果ID一覧, エラー
+ */
+func (r *tagRepositoryImpl) TimeRecordIDsByTags(ctx context.Context, tagIDs []uuid.UUID) ([]uuid.UUID, error) {
+	return r.linkedIDs(ctx, `SELECT DISTINCT time_record_id FROM time_record_tags WHERE tag_id IN (?)`, tagIDs)
+}
+
+/*
+ * タスク・計測結果のタグ付けを別のタグに付け替える
+ * 付け替え先のタグが既に付いている場合は重複させない
+ *
+ * @param ctx コンテキスト
+ * @param fromID 付け替え元のタグID
+ * @param toID 付け替え先のタグID
+ * @return エラー
+ */
+func (r *tagRepositoryImpl) MoveLinks(ctx context.Context, fromID uuid.UUID, toID uuid.UUID) error {
+	for _, query := range []string{
+		`INSERT OR IGNORE INTO task_tags (id, task_id, tag_id, created_at)
+		SELECT task_id || ':' || ?, task_id, ?, created_at FROM task_tags WHERE tag_id = ?`,
+		`INSERT OR IGNORE INTO time_record_tags (id, time_record_id, tag_id, created_at)
+		SELECT time_record_id || ':' || ?, time_record_id, ?, created_at FROM time_record_tags WHERE tag_id = ?`,
+	} {
+		if _, err := conn(ctx, r.db).ExecContext(ctx, query, toID.String(), toID.String(), fromID.String()); err != nil {
+			return err
+		}
+	}
+	for _, query := range []string{
+		`DELETE FROM task_tags WHERE tag_id = ?`,
+		`DELETE FROM time_record_tags WHERE tag_id = ?`,
+	} {
+		if _, err := conn(ctx, r.db).ExecContext(ctx, query, fromID.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+ * タグIDで絞り込んだ対象のIDを取得する
+ *
+ * @param ctx コンテキスト
+ * @param query IN句を含むクエリ
+ * @param tagIDs タグID一覧
+ * @return 対象のID一覧, エラー
+ */
+func (r *tagRepositoryImpl) linkedIDs(ctx context.Context, query string, tagIDs []uuid.UUID) ([]uuid.UUID, error) {
+	if len(tagIDs) == 0 {
+		return []uuid.UUID{}, nil
+	}
+
+	// IN句のパラメータを作成
+	ids := make([]string, 0, len(tagIDs))
+	for _, id := range tagIDs {
+		ids = append(ids, id.String())
+	}
+	query, args, err := sqlx.In(query, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []string
+	if err := sqlx.SelectContext(ctx, conn(ctx, r.db), &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	list := make([]uuid.UUID, 0, len(rows))
+	for _, v := range rows {
+		if id, err := uuid.Parse(v); err == nil {
+			list = append(list, id)
+		}
+	}
+	return list, nil
+}
+
+/*
+ * レコード一覧をモデルに変換
+ */
+func rowsToTags(rows []tagRow) []*model.Tag {
+	list := make([]*model.Tag, 0, len(rows))
+	for i := range rows {
+		list = append(list, rowToTag(&rows[i]))
+	}
+	return list
+}
+
+/*
+ * タグ付けのID（付ける対象のID:タグID）
+ * 複数の端末で同じタグを付けても同期で重複させない
+ */
+func tagLinkID(targetID string, tagID string) string {
+	return targetID + ":" + tagID
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"play-wails/internal/model"
+
+	"github.com/google/uuid"
+)
+
+type TaskRepository interface {
+	Create(ctx context.Context, task *model.Task) error
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Task, error)
+	Update(ctx context.Context, task *model.Task) error
+	List(ctx context.Context, includeArchived bool) ([]*model.Task, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
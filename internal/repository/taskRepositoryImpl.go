@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"play-wails/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type taskRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+// UUIDはTEXT、アーカイブフラグは0/1の整数
+// project_archivedはプロジェクト・クライアントを結合して求める
+type taskRow struct {
+	ID              string    `db:"id"`
+	ProjectID       *string   `db:"project_id"`
+	Title           string    `db:"title"`
+	Description     string    `db:"description"`
+	Status          string    `db:"status"`
+	Archived        int       `db:"archived"`
+	ProjectArchived int       `db:"project_archived"`
+	NonBillable     int       `db:"non_billable"`
+	CreatedAt       time.Time `db:"created_at"`
+	UpdatedAt       time.Time `db:"updated_at"`
+}
+
+// タスクの取得に使う列（所属プロジェクトかそのクライアントがアーカイブ済みかを含む）
+const taskSelect = `SELECT 
+			t.id
+			, t.project_id
+			, t.title
+			, t.description
+			, t.status
+			, t.archived
+			, CASE WHEN COALESCE(p.archived, 0) = 1 OR COALESCE(c.archived, 0) = 1 THEN 1 ELSE 0 END AS project_archived
+			, t.non_billable
+			, t.created_at
+			, t.updated_at 
+		FROM tasks t 
+		LEFT JOIN projects p ON p.id = t.project_id 
+		LEFT JOIN clients c ON c.id = p.client_id`
+
+/*
+ * レコードをモデルに変換
+ *
+ * @param row レコード
+ * @return モデル
+ */
+func rowToTask(row *taskRow) *model.Task {
+	t := &model.Task{
+		Title:           row.Title,
+		Description:     row.Description,
+		Status:          model.TaskStatus(row.Status),
+		Archived:        row.Archived != 0,
+		ProjectArchived: row.ProjectArchived != 0,
+		NonBillable:     row.NonBillable != 0,
+		CreatedAt:       row.CreatedAt,
+		UpdatedAt:       row.UpdatedAt,
+	}
+	t.ID, _ = uuid.Parse(row.ID)
+	if row.ProjectID != nil {
+		if id, err := uuid.Parse(*row.ProjectID); err == nil {
+			t.ProjectID = &id
+		}
+	}
+	return t
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param db データベース
+ * @return インスタンス
+ */
+func NewTaskRepositoryImpl(db *sql.DB) TaskRepository {
+	return &taskRepositoryImpl{db: sqlx.NewDb(db, "libsql")}
+}
+
+/*
+ * レコード作成
+ *
+ * @param ctx コンテキスト
+ * @param task レコード
+ * @return エラー
+ */
+func (r *taskRepositoryImpl) Create(ctx context.Context, task *model.Task) error {
+
+	// アーカイブフラグを取得
+	archived := 0
+	if task.Archived {
+		archived = 1
+	}
+
+	// 請求対象外フラグを取得
+	nonBillable := 0
+	if task.NonBillable {
+		nonBillable = 1
+	}
+
+	// インサートクエリ作成
+	query := `INSERT INTO tasks (
+		id
+		, project_id
+		, title
+		, description
+		, status
+		, archived
+		, non_billable
+		, created_at
+		, updated_at
+	) VALUES (
+		:id
+		, :project_id
+		, :title
+		, :description
+		, :status
+		, :archived
+		, :non_billable
+		, :created_at
+		, :updated_at
+	)`
+
+	// インサート処理実行
+	_, err := sqlx.NamedExecContext(ctx, conn(ctx, r.db), query, map[string]interface{}{
+		"id":           task.ID.String(),
+		"project_id":   nullableUUID(task.ProjectID),
+		"title":        task.Title,
+		"description":  task.Description,
+		"status":       string(task.Status),
+		"archived":     archived,
+		"non_billable": nonBillable,
+		"created_at":   task.CreatedAt,
+		"updated_at":   task.UpdatedAt,
+	})
+
+	return err
+}
+
+/*
+ * レコードを取得
+ *
+ * @param ctx コンテキスト
+ * @param id レコードID
+ * @return レコード, エラー
+ */
+func (r *taskRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*model.Task, error) {
+	var row taskRow
+	err := sqlx.GetContext(ctx, conn(ctx, r.db), &row,
+		taskSelect+` WHERE t.id = ?`,
+		id.String(),
+	)
+
+	// エラーチェック
+	if err != nil {
+		return nil, err
+	}
+
+	// レコードをモデルに変換
+	return rowToTask(&row), nil
+}
+
+/*
+ * レコードを更新
+ *
+ * @param ctx コンテキスト
+ * @param task レコード
+ * @return エラー
+ */
+func (r *taskRepositoryImpl) Update(ctx context.Context, task *model.Task) error {
+
+	// アーカイブフラグを取得
+	archived := 0
+	if task.Archived {
+		archived = 1
+	}
+
+	// 請求対象外フラグを取得
+	nonBillable := 0
+	if task.NonBillable {
+		nonBillable = 1
+	}
+
+	query := `UPDATE tasks SET 
+		project_id = :project_id
+		, title = :title
+		, description = :description
+		, status = :status
+		, archived = :archived
+		, non_billable = :non_billable
+		, updated_at = :updated_at
+	WHERE id = :id`
+
+	// 更新処理実行
+	_, err := sqlx.NamedExecContext(ctx, conn(ctx, r.db), query, map[string]interface{}{
+		"id":           task.ID.String(),
+		"project_id":   nullableUUID(task.ProjectID),
+		"title":        task.Title,
+		"description":  task.Description,
+		"status":       string(task.Status),
+		"archived":     archived,
+		"non_billable": nonBillable,
+		"updated_at":   task.UpdatedAt,
+	})
+	return err
+}
+
+/*
+ * レコード一覧を取得
+ *
+ * @param ctx コンテキスト
+ * @param includeArchived true のときアーカイブ済み（所属プロジェクト・クライアントのアーカイブを含む）も含める
+ * @return レコード一覧, エラー
+ */
+func (r *taskRepositoryImpl) List(ctx context.Context, includeArchived bool) ([]*model.Task, error) {
+	query := taskSelect
+
+	// アーカイブ済みを除外する場合
+	if !includeArchived {
+		query += ` WHERE t.archived = 0 AND COALESCE(p.archived, 0) = 0 AND COALESCE(c.archived, 0) = 0`
+	}
+	query += ` ORDER BY t.created_at DESC`
+
+	// レコード一覧を取得
+	var rows []taskRow
+	err := sqlx.SelectContext(ctx, conn(ctx, r.db), &rows, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// レコード一覧をモデルに変換
+	list := make([]*model.Task, 0, len(rows))
+	for i := range rows {
+		list = append(list, rowToTask(&rows[i]))
+	}
+
+	return list, nil
+}
+
+/*
+ * レコードを物理削除
+ * 作業セッション・計測結果から参照されているタスクは削除しない
+ *
+ * @param ctx コンテキスト
+ * @param id レコードID
+ * @return エラー
+ */
+func (r *taskRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM tasks 
+		WHERE id = ? 
+			AND NOT EXISTS (SELECT 1 FROM work_sessions WHERE task_id = ?) 
+			AND NOT EXISTS (SELECT 1 FROM time_records WHERE task_id = ?)`,
+		id.String(), id.String(), id.String(),
+	)
+	if err != nil {
+		return err
+	}
+
+	// 削除件数が0件の場合は未登録または参照中
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.New("【ERROR】タスクが存在しないか、作業記録から参照されているため削除できません。アーカイブしてください。")
+	}
+
+	return nil
+}
+
+/*
+ * 省略可能なIDを保存する値にする（nilの場合はNULL）
+ *
+ * @param id ID
+ * @return 保存する値
+ */
+func nullableUUID(id *uuid.UUID) interface{} {
+	if id == nil {
+		return nil
+	}
+	return id.String()
+}
@@ -1,15 +1,25 @@
 package repository
 
 import (
+	"context"
 	"play-wails/internal/model"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type TimeRecordRepository interface {
-	Create(record *model.TimeRecord) error
-	FindByID(id uuid.UUID) (*model.TimeRecord, error)
-	Update(record *model.TimeRecord) error
-	List(excludeDeleted bool) ([]*model.TimeRecord, error)
-	Delete(id uuid.UUID) error
+	Create(ctx context.Context, record *model.TimeRecord) error
+	FindByID(ctx context.Context, id uuid.UUID) (*model.TimeRecord, error)
+	FindByRunID(ctx context.Context, runID uuid.UUID) (*model.TimeRecord, error)
+	Update(ctx context.Context, record *model.TimeRecord) error
+	List(ctx context.Context, excludeDeleted bool) ([]*model.TimeRecord, error)
+	ListByRange(ctx context.Context, from time.Time, to time.Time) ([]*model.TimeRecord, error)
+	Query(ctx context.Context, q model.TimeRecordQuery) (*model.TimeRecordPage, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) error
+	Purge(ctx context.Context, id uuid.UUID) error
+	ListDeleted(ctx context.Context) ([]*model.TimeRecord, error)
+	ListDeletedBefore(ctx context.Context, before time.Time) ([]*model.TimeRecord, error)
+	ListByInvoiceID(ctx context.Context, invoiceID uuid.UUID) ([]*model.TimeRecord, error)
 }
@@ -1,8 +1,14 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"play-wails/internal/model"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,13 +21,16 @@ type timeRecordRepositoryImpl struct {
 
 // UUIDはTEXT、durationはナノ秒整数
 type timeRecordRow struct {
-	ID         string    `db:"id"`
-	RunID      string    `db:"run_id"`
-	TaskID     string    `db:"task_id"`
-	DeleteFlag int       `db:"delete_flag"`
-	StartTime  time.Time `db:"start_time"`
-	EndTime    time.Time `db:"end_time"`
-	DurationNs int64     `db:"duration_ns"`
+	ID          string     `db:"id"`
+	RunID       string     `db:"run_id"`
+	TaskID      string     `db:"task_id"`
+	DeleteFlag  int        `db:"delete_flag"`
+	StartTime   time.Time  `db:"start_time"`
+	EndTime     time.Time  `db:"end_time"`
+	DurationNs  int64      `db:"duration_ns"`
+	DeletedAt   *time.Time `db:"deleted_at"`
+	NonBillable int        `db:"non_billable"`
+	InvoiceID   *string    `db:"invoice_id"`
 }
 
 /*
@@ -32,14 +41,21 @@ type timeRecordRow struct {
  */
 func rowToTimeRecord(row *timeRecordRow) *model.TimeRecord {
 	t := &model.TimeRecord{
-		StartTime:  row.StartTime,
-		EndTime:    row.EndTime,
-		Duration:   time.Duration(row.DurationNs),
-		DeleteFlag: row.DeleteFlag != 0,
+		StartTime:   row.StartTime,
+		EndTime:     row.EndTime,
+		Duration:    time.Duration(row.DurationNs),
+		DeleteFlag:  row.DeleteFlag != 0,
+		DeletedAt:   row.DeletedAt,
+		NonBillable: row.NonBillable != 0,
 	}
 	t.ID, _ = uuid.Parse(row.ID)
 	t.RunID, _ = uuid.Parse(row.RunID)
 	t.TaskID, _ = uuid.Parse(row.TaskID)
+	if row.InvoiceID != nil {
+		if id, err := uuid.Parse(*row.InvoiceID); err == nil {
+			t.InvoiceID = &id
+		}
+	}
 	return t
 }
 
@@ -56,10 +72,11 @@ func NewTimeRecordRepositoryImpl(db *sql.DB) TimeRecordRepository {
 /*
  * レコード作成
  *
+ * @param ctx コンテキスト
  * @param record レコード
  * @return エラー
  */
-func (r *timeRecordRepositoryImpl) Create(record *model.TimeRecord) error {
+func (r *timeRecordRepositoryImpl) Create(ctx context.Context, record *model.TimeRecord) error {
 
 	// 削除フラグを取得
 	deleteFlag := 0
@@ -67,6 +84,12 @@ func (r *timeRecordRepositoryImpl) Create(record *model.TimeRecord) error {
 		deleteFlag = 1
 	}
 
+	// 請求対象外フラグを取得
+	nonBillable := 0
+	if record.NonBillable {
+		nonBillable = 1
+	}
+
 	// インサートクエリ作成
 	query := `INSERT INTO time_records (
 		id
@@ -76,6 +99,7 @@ func (r *timeRecordRepositoryImpl) Create(record *model.TimeRecord) error {
 		, start_time
 		, end_time
 		, duration_ns
+		, non_billable
 	) VALUES (
 		:id
 		, :run_id
@@ -84,17 +108,19 @@ func (r *timeRecordRepositoryImpl) Create(record *model.TimeRecord) error {
 		, :start_time
 		, :end_time
 		, :duration_ns
+		, :non_billable
 	)`
 
 	// インサート処理実行
-	_, err := r.db.NamedExec(query, map[string]interface{}{
-		"id":          record.ID.String(),
-		"run_id":      record.RunID.String(),
-		"task_id":     record.TaskID.String(),
-		"delete_flag": deleteFlag,
-		"start_time":  record.StartTime,
-		"end_time":    record.EndTime,
-		"duration_ns": record.Duration.Nanoseconds(),
+	_, err := sqlx.NamedExecContext(ctx, conn(ctx, r.db), query, map[string]interface{}{
+		"id":           record.ID.String(),
+		"run_id":       record.RunID.String(),
+		"task_id":      record.TaskID.String(),
+		"delete_flag":  deleteFlag,
+		"start_time":   record.StartTime,
+		"end_time":     record.EndTime,
+		"duration_ns":  record.Duration.Nanoseconds(),
+		"non_billable": nonBillable,
 	})
 
 	return err
@@ -103,12 +129,13 @@ func (r *timeRecordRepositoryImpl) Create(record *model.TimeRecord) error {
 /*
  * レコードを取得
  *
+ * @param ctx コンテキスト
  * @param id レコードID
  * @return レコード, エラー
  */
-func (r *timeRecordRepositoryImpl) FindByID(id uuid.UUID) (*model.TimeRecord, error) {
+func (r *timeRecordRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*model.TimeRecord, error) {
 	var row timeRecordRow
-	err := r.db.Get(&row,
+	err := sqlx.GetContext(ctx, conn(ctx, r.db), &row,
 		`SELECT 
 			id
 			, run_id
@@ -116,7 +143,10 @@ func (r *timeRecordRepositoryImpl) FindByID(id uuid.UUID) (*model.TimeRecord, er
 			, delete_flag
 			, start_time
 			, end_time
-			, duration_ns 
+			, duration_ns
+			, deleted_at 
+			, non_billable 
+			, invoice_id 
 		FROM time_records 
 		WHERE id = ?`,
 		id.String(),
@@ -132,26 +162,72 @@ func (r *timeRecordRepositoryImpl) FindByID(id uuid.UUID) (*model.TimeRecord, er
 	return rowToTimeRecord(&row), nil
 }
 
+/*
+ * 計測実行のグループIDでレコードを取得
+ * 該当なしの場合はsql.ErrNoRowsを返す
+ *
+ * @param ctx コンテキスト
+ * @param runID 計測実行のグループID
+ * @return レコード, エラー
+ */
+func (r *timeRecordRepositoryImpl) FindByRunID(ctx context.Context, runID uuid.UUID) (*model.TimeRecord, error) {
+	var row timeRecordRow
+	err := sqlx.GetContext(ctx, conn(ctx, r.db), &row,
+		`SELECT 
+			id
+			, run_id
+			, task_id
+			, delete_flag
+			, start_time
+			, end_time
+			, duration_ns
+			, deleted_at 
+			, non_billable 
+			, invoice_id 
+		FROM time_records 
+		WHERE run_id = ?`,
+		runID.String(),
+	)
+
+	// エラーチェック
+	if err != nil {
+		return nil, err
+	}
+	return rowToTimeRecord(&row), nil
+}
+
 /*
  * レコードを更新（開始・終了時刻・作業時間）
  *
+ * @param ctx コンテキスト
  * @param record レコード
  * @return エラー
  */
-func (r *timeRecordRepositoryImpl) Update(record *model.TimeRecord) error {
+func (r *timeRecordRepositoryImpl) Update(ctx context.Context, record *model.TimeRecord) error {
+
+	// 請求対象外フラグを取得
+	nonBillable := 0
+	if record.NonBillable {
+		nonBillable = 1
+	}
+
 	query :=
 		`UPDATE time_records 
 			SET start_time = :start_time
 			, end_time = :end_time
 			, duration_ns = :duration_ns 
+			, non_billable = :non_billable 
+			, invoice_id = :invoice_id 
 		WHERE id = :id`
 
 	// 更新処理実行
-	_, err := r.db.NamedExec(query, map[string]interface{}{
-		"id":          record.ID.String(),
-		"start_time":  record.StartTime,
-		"end_time":    record.EndTime,
-		"duration_ns": record.Duration.Nanoseconds(),
+	_, err := sqlx.NamedExecContext(ctx, conn(ctx, r.db), query, map[string]interface{}{
+		"id":           record.ID.String(),
+		"start_time":   record.StartTime,
+		"end_time":     record.EndTime,
+		"duration_ns":  record.Duration.Nanoseconds(),
+		"non_billable": nonBillable,
+		"invoice_id":   nullableUUID(record.InvoiceID),
 	})
 
 	return err
@@ -160,10 +236,11 @@ func (r *timeRecordRepositoryImpl) Update(record *model.TimeRecord) error {
 /*
  * レコード一覧を取得
  *
+ * @param ctx コンテキスト
  * @param excludeDeleted true のとき論理削除済みを除外
  * @return レコード一覧, エラー
  */
-func (r *timeRecordRepositoryImpl) List(excludeDeleted bool) ([]*model.TimeRecord, error) {
+func (r *timeRecordRepositoryImpl) List(ctx context.Context, excludeDeleted bool) ([]*model.TimeRecord, error) {
 	query :=
 		`SELECT 
 			id
@@ -172,7 +249,10 @@ func (r *timeRecordRepositoryImpl) List(excludeDeleted bool) ([]*model.TimeRecor
 			, delete_flag
 			, start_time
 			, end_time
-			, duration_ns 
+			, duration_ns
+			, deleted_at 
+			, non_billable 
+			, invoice_id 
 		FROM time_records`
 
 	// 論理削除済みを除外する場合
@@ -183,7 +263,50 @@ func (r *timeRecordRepositoryImpl) List(excludeDeleted bool) ([]*model.TimeRecor
 
 	// レコード一覧を取得
 	var rows []timeRecordRow
-	err := r.db.Select(&rows, query)
+	err := sqlx.SelectContext(ctx, conn(ctx, r.db), &rows, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// レコード一覧をモデルに変換
+	list := make([]*model.TimeRecord, 0, len(rows))
+	for i := range rows {
+		list = append(list, rowToTimeRecord(&rows[i]))
+	}
+
+	return list, nil
+}
+
+/*
+ * 期間と重なるレコード一覧を取得（論理削除済みは除外）
+ * 時刻はタイムゾーン付きの文字列で保存されるため、julianday で比較する
+ *
+ * @param ctx コンテキスト
+ * @param from 期間の開始（含む）
+ * @param to 期間の終了（含まない）
+ * @return レコード一覧, エラー
+ */
+func (r *timeRecordRepositoryImpl) ListByRange(ctx context.Context, from time.Time, to time.Time) ([]*model.TimeRecord, error) {
+	var rows []timeRecordRow
+	err := sqlx.SelectContext(ctx, conn(ctx, r.db), &rows,
+		`SELECT 
+			id
+			, run_id
+			, task_id
+			, delete_flag
+			, start_time
+			, end_time
+			, duration_ns
+			, deleted_at 
+			, non_billable 
+			, invoice_id 
+		FROM time_records 
+		WHERE delete_flag = 0 
+			AND julianday(start_time) < julianday(?) 
+			AND julianday(end_time) > julianday(?) 
+		ORDER BY start_time`,
+		to, from,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -198,15 +321,339 @@ func (r *timeRecordRepositoryImpl) List(excludeDeleted bool) ([]*model.TimeRecor
 }
 
 /*
- * レコードを論理削除
+ * レコードを論理削除（ゴミ箱に移動）
+ * 既に論理削除済みの場合は削除日時を変えない
+ *
+ * @param ctx コンテキスト
+ * @param id レコードID
+ * @return エラー
+ */
+func (r *timeRecordRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE time_records SET delete_flag = 1, deleted_at = ? WHERE id = ? AND delete_flag = 0`,
+		time.Now(), id.String(),
+	)
+	return err
+}
+
+/*
+ * 論理削除したレコードを元に戻す
  *
+ * @param ctx コンテキスト
  * @param id レコードID
  * @return エラー
  */
-func (r *timeRecordRepositoryImpl) Delete(id uuid.UUID) error {
-	_, err := r.db.Exec(
-		`UPDATE time_records SET delete_flag = 1 WHERE id = ?`,
+func (r *timeRecordRepositoryImpl) Restore(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE time_records SET delete_flag = 0, deleted_at = NULL WHERE id = ?`,
 		id.String(),
 	)
 	return err
 }
+
+/*
+ * レコードを物理削除
+ *
+ * @param ctx コンテキスト
+ * @param id レコードID
+ * @return エラー
+ */
+func (r *timeRecordRepositoryImpl) Purge(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM time_records WHERE id = ?`,
+		id.String(),
+	)
+	return err
+}
+
+/*
+ * 論理削除済みのレコード一覧を取得（削除日時の新しい順）
+ *
+ * @param ctx コンテキスト
+ * @return レコード一覧, エラー
+ */
+func (r *timeRecordRepositoryImpl) ListDeleted(ctx context.Context) ([]*model.TimeRecord, error) {
+	return r.listDeleted(ctx,
+		`SELECT 
+			id
+			, run_id
+			, task_id
+			, delete_flag
+			, start_time
+			, end_time
+			, duration_ns
+			, deleted_at 
+			, non_billable 
+			, invoice_id 
+		FROM time_records 
+		WHERE delete_flag = 1 
+		ORDER BY julianday(deleted_at) DESC`,
+	)
+}
+
+/*
+ * 指定日時より前に論理削除したレコード一覧を取得（削除日時の古い順）
+ *
+ * @param ctx コンテキスト
+ * @param before 削除日時の上限（含まない）
+ * @return レコード一覧, エラー
+ */
+func (r *timeRecordRepositoryImpl) ListDeletedBefore(ctx context.Context, before time.Time) ([]*model.TimeRecord, error) {
+	return r.listDeleted(ctx,
+		`SELECT 
+			id
+			, run_id
+			, task_id
+			, delete_flag
+			, start_time
+			, end_time
+			, duration_ns
+			, deleted_at 
+			, non_billable 
+			, invoice_id 
+		FROM time_records 
+		WHERE delete_flag = 1 
+			AND julianday(deleted_at) < julianday(?) 
+		ORDER BY julianday(deleted_at)`,
+		before,
+	)
+}
+
+/*
+ * 論理削除済みのレコードを検索してモデルに変換
+ */
+func (r *timeRecordRepositoryImpl) listDeleted(ctx context.Context, query string, args ...interface{}) ([]*model.TimeRecord, error) {
+	var rows []timeRecordRow
+	if err := sqlx.SelectContext(ctx, conn(ctx, r.db), &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	// レコード一覧をモデルに変換
+	list := make([]*model.TimeRecord, 0, len(rows))
+	for i := range rows {
+		list = append(list, rowToTimeRecord(&rows[i]))
+	}
+
+	return list, nil
+}
+
+// キーセットページングの位置（最後に返したレコードの並び順の値とID）
+type timeRecordCursor struct {
+	Field model.TimeRecordSortField `json:"f"`
+	Desc  bool                      `json:"d"`
+	Key   float64                   `json:"k"`
+	ID    string                    `json:"i"`
+}
+
+// 並び順の値を付けたレコード
+type timeRecordSortRow struct {
+	timeRecordRow
+	SortKey float64 `db:"sort_key"`
+}
+
+/*
+ * 並び順の項目に対応する式を取得
+ * 時刻は julianday で並べる（インデックスも同じ式で作成している）
+ */
+func timeRecordSortExpr(field model.TimeRecordSortField) (string, error) {
+	switch field {
+	case model.TimeRecordSortStartTime, "":
+		return "julianday(start_time)", nil
+	case model.TimeRecordSortEndTime:
+		return "julianday(end_time)", nil
+	case model.TimeRecordSortDuration:
+		return "duration_ns", nil
+	default:
+		return "", fmt.Errorf("【ERROR】並び順の項目が不正です: %s", field)
+	}
+}
+
+/*
+ * カーソル文字列を作成
+ */
+func encodeTimeRecordCursor(c timeRecordCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+/*
+ * カーソル文字列を解析
+ */
+func decodeTimeRecordCursor(s string) (*timeRecordCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("【ERROR】カーソルが不正です。")
+	}
+	var c timeRecordCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, errors.New("【ERROR】カーソルが不正です。")
+	}
+	return &c, nil
+}
+
+/*
+ * 条件に一致するレコードを1ページ分取得
+ * (並び順の値, ID) のキーセットでページングする
+ *
+ * @param ctx コンテキスト
+ * @param q 検索条件（Limitは1以上であること）
+ * @return 検索結果, エラー
+ */
+func (r *timeRecordRepositoryImpl) Query(ctx context.Context, q model.TimeRecordQuery) (*model.TimeRecordPage, error) {
+	sortExpr, err := timeRecordSortExpr(q.SortField)
+	if err != nil {
+		return nil, err
+	}
+	field := q.SortField
+	if field == "" {
+		field = model.TimeRecordSortStartTime
+	}
+
+	where := make([]string, 0)
+	args := make([]interface{}, 0)
+
+	// 論理削除済みを除外する場合
+	if !q.IncludeDeleted {
+		where = append(where, "delete_flag = 0")
+	}
+	// 期間と重なるもの
+	if q.To != nil {
+		where = append(where, "julianday(start_time) < julianday(?)")
+		args = append(args, *q.To)
+	}
+	if q.From != nil {
+		where = append(where, "julianday(end_time) > julianday(?)")
+		args = append(args, *q.From)
+	}
+	// タスク
+	if len(q.TaskIDs) > 0 {
+		marks := make([]string, 0, len(q.TaskIDs))
+		for _, id := range q.TaskIDs {
+			marks = append(marks, "?")
+			args = append(args, id.String())
+		}
+		where = append(where, "task_id IN ("+strings.Join(marks, ", ")+")")
+	}
+	// タグ（計測結果またはタスクに付いているもの）
+	if len(q.TagIDs) > 0 {
+		marks := make([]string, 0, len(q.TagIDs))
+		tagArgs := make([]interface{}, 0, len(q.TagIDs))
+		for _, id := range q.TagIDs {
+			marks = append(marks, "?")
+			tagArgs = append(tagArgs, id.String())
+		}
+		in := strings.Join(marks, ", ")
+		where = append(where, "(id IN (SELECT time_record_id FROM time_record_tags WHERE tag_id IN ("+in+"))"+
+			" OR task_id IN (SELECT task_id FROM task_tags WHERE tag_id IN ("+in+")))")
+		args = append(args, tagArgs...)
+		args = append(args, tagArgs...)
+	}
+	// 作業時間
+	if q.MinDuration != nil {
+		where = append(where, "duration_ns >= ?")
+		args = append(args, q.MinDuration.Nanoseconds())
+	}
+	if q.MaxDuration != nil {
+		where = append(where, "duration_ns <= ?")
+		args = append(args, q.MaxDuration.Nanoseconds())
+	}
+
+	// 前回の続きから
+	cmp, order := ">", "ASC"
+	if q.Descending {
+		cmp, order = "<", "DESC"
+	}
+	if q.Cursor != "" {
+		c, err := decodeTimeRecordCursor(q.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if c.Field != field || c.Desc != q.Descending {
+			return nil, errors.New("【ERROR】カーソルと並び順が一致しません。")
+		}
+		// 行値の比較だけではインデックスの範囲検索にならないため、先頭の項目の条件も付ける
+		where = append(where, fmt.Sprintf("%s %s= ? AND (%s, id) %s (?, ?)", sortExpr, cmp, sortExpr, cmp))
+		args = append(args, c.Key, c.Key, c.ID)
+	}
+
+	query := `SELECT 
+			id
+			, run_id
+			, task_id
+			, delete_flag
+			, start_time
+			, end_time
+			, duration_ns
+			, deleted_at 
+			, non_billable 
+			, invoice_id 
+			, ` + sortExpr + ` AS sort_key 
+		FROM time_records`
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	// 続きの有無を判定するため1件多く取得する
+	query += fmt.Sprintf(` ORDER BY %s %s, id %s LIMIT ?`, sortExpr, order, order)
+	args = append(args, q.Limit+1)
+
+	var rows []timeRecordSortRow
+	if err := sqlx.SelectContext(ctx, conn(ctx, r.db), &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	page := &model.TimeRecordPage{Records: make([]*model.TimeRecord, 0, len(rows))}
+	if len(rows) > q.Limit {
+		rows = rows[:q.Limit]
+		last := rows[len(rows)-1]
+		page.NextCursor = encodeTimeRecordCursor(timeRecordCursor{
+			Field: field,
+			Desc:  q.Descending,
+			Key:   last.SortKey,
+			ID:    last.ID,
+		})
+	}
+	for i := range rows {
+		page.Records = append(page.Records, rowToTimeRecord(&rows[i].timeRecordRow))
+	}
+
+	return page, nil
+}
+
+/*
+ * 請求書の計測結果を開始時刻順に取得
+ *
+ * @param ctx コンテキスト
+ * @param invoiceID 請求書ID
+ * @return レコード一覧, エラー
+ */
+func (r *timeRecordRepositoryImpl) ListByInvoiceID(ctx context.Context, invoiceID uuid.UUID) ([]*model.TimeRecord, error) {
+	var rows []timeRecordRow
+	err := sqlx.SelectContext(ctx, conn(ctx, r.db), &rows,
+		`SELECT 
+			id
+			, run_id
+			, task_id
+			, delete_flag
+			, start_time
+			, end_time
+			, duration_ns
+			, deleted_at 
+			, non_billable 
+			, invoice_id 
+		FROM time_records 
+		WHERE invoice_id = ? 
+		ORDER BY julianday(start_time), id`,
+		invoiceID.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// レコード一覧をモデルに変換
+	list := make([]*model.TimeRecord, 0, len(rows))
+	for i := range rows {
+		list = append(list, rowToTimeRecord(&rows[i]))
+	}
+
+	return list, nil
+}
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"play-wails/internal/model"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+ * テスト用にインメモリDBを開き、タスクを1件登録したリポジトリを作成する
+ */
+func newTestTimeRecordRepository(t *testing.T) (TimeRecordRepository, uuid.UUID) {
+	t.Helper()
+	conn := openTestDB(t)
+
+	now := time.Now()
+	task := &model.Task{ID: uuid.New(), Title: "設計", Status: model.TaskStatusTodo, CreatedAt: now, UpdatedAt: now}
+	if err := NewTaskRepositoryImpl(conn).Create(context.Background(), task); err != nil {
+		t.Fatalf("タスクを作成できません: %v", err)
+	}
+	return NewTimeRecordRepositoryImpl(conn), task.ID
+}
+
+func TestTimeRecordCursor_RoundTrip(t *testing.T) {
+	tests := []timeRecordCursor{
+		{Field: model.TimeRecordSortStartTime, Desc: false, Key: 2461315.0416666665, ID: uuid.NewString()},
+		{Field: model.TimeRecordSortEndTime, Desc: true, Key: 2461315.5, ID: uuid.NewString()},
+		{Field: model.TimeRecordSortDuration, Desc: false, Key: float64(90 * time.Minute), ID: uuid.NewString()},
+	}
+
+	for _, want := range tests {
+		t.Run(string(want.Field), func(t *testing.T) {
+			got, err := decodeTimeRecordCursor(encodeTimeRecordCursor(want))
+			if err != nil {
+				t.Fatalf("decodeTimeRecordCursor() error = %v", err)
+			}
+			if *got != want {
+				t.Errorf("decodeTimeRecordCursor() = %+v, want %+v", *got, want)
+			}
+		})
+	}
+}
+
+func TestDecodeTimeRecordCursor_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "base64ではない", cursor: "!!!"},
+		{name: "JSONではない", cursor: base64.RawURLEncoding.EncodeToString([]byte("not json"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeTimeRecordCursor(tt.cursor); err == nil {
+				t.Error("decodeTimeRecordCursor() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestTimeRecordSortExpr(t *testing.T) {
+	tests := []struct {
+		field   model.TimeRecordSortField
+		want    string
+		wantErr bool
+	}{
+		{field: "", want: "julianday(start_time)"},
+		{field: model.TimeRecordSortStartTime, want: "julianday(start_time)"},
+		{field: model.TimeRecordSortEndTime, want: "julianday(end_time)"},
+		{field: model.TimeRecordSortDuration, want: "duration_ns"},
+		{field: "id; DROP TABLE time_records", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.field), func(t *testing.T) {
+			got, err := timeRecordSortExpr(tt.field)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("timeRecordSortExpr() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("timeRecordSortExpr() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeRecordRepositoryImpl_Query_Paging(t *testing.T) {
+	ctx := context.Background()
+	repo, taskID := newTestTimeRecordRepository(t)
+
+	// 並び順の値が同じレコードを含める（IDで順序が決まる）
+	base := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	spans := []struct {
+		start    time.Duration
+		duration time.Duration
+	}{
+		{0, time.Hour},
+		{0, time.Hour},
+		{0, 30 * time.Minute},
+		{time.Hour, time.Hour},
+		{2 * time.Hour, 30 * time.Minute},
+		{2 * time.Hour, 30 * time.Minute},
+		{3 * time.Hour, 2 * time.Hour},
+	}
+	records := make([]*model.TimeRecord, 0, len(spans))
+	for _, sp := range spans {
+		r := &model.TimeRecord{
+			ID:        uuid.New(),
+			RunID:     uuid.New(),
+			TaskID:    taskID,
+			StartTime: base.Add(sp.start),
+			EndTime:   base.Add(sp.start + sp.duration),
+			Duration:  sp.duration,
+		}
+		if err := repo.Create(ctx, r); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		records = append(records, r)
+	}
+	// 論理削除済みは含めない
+	deleted := &model.TimeRecord{ID: uuid.New(), RunID: uuid.New(), TaskID: taskID, StartTime: base, EndTime: base.Add(time.Hour), Duration: time.Hour}
+	if err := repo.Create(ctx, deleted); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := repo.Delete(ctx, deleted.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	keys := map[model.TimeRecordSortField]func(r *model.TimeRecord) int64{
+		model.TimeRecordSortStartTime: func(r *model.TimeRecord) int64 { return r.StartTime.UnixNano() },
+		model.TimeRecordSortEndTime:   func(r *model.TimeRecord) int64 { return r.EndTime.UnixNano() },
+		model.TimeRecordSortDuration:  func(r *model.TimeRecord) int64 { return int64(r.Duration) },
+	}
+
+	tests := []struct {
+		field model.TimeRecordSortField
+		desc  bool
+		limit int
+	}{
+		{field: model.TimeRecordSortStartTime, desc: false, limit: 2},
+		{field: model.TimeRecordSortStartTime, desc: true, limit: 2},
+		{field: model.TimeRecordSortEndTime, desc: false, limit: 3},
+		{field: model.TimeRecordSortEndTime, desc: true, limit: 1},
+		{field: model.TimeRecordSortDuration, desc: false, limit: 2},
+		{field: model.TimeRecordSortDuration, desc: true, limit: 3},
+		{field: model.TimeRecordSortDuration, desc: false, limit: len(spans)},
+	}
+
+	for _, tt := range tests {
+		name := string(tt.field)
+		if tt.desc {
+			name += "_desc"
+		}
+		t.Run(name, func(t *testing.T) {
+			// 期待する順序（並び順の値、同じ場合はID）
+			key := keys[tt.field]
+			want := append([]*model.TimeRecord(nil), records...)
+			sort.Slice(want, func(i, j int) bool {
+				ki, kj := key(want[i]), key(want[j])
+				if ki != kj {
+					return (ki < kj) != tt.desc
+				}
+				return (want[i].ID.String() < want[j].ID.String()) != tt.desc
+			})
+
+			got := make([]*model.TimeRecord, 0, len(want))
+			q := model.TimeRecordQuery{SortField: tt.field, Descending: tt.desc, Limit: tt.limit}
+			for pages := 0; ; pages++ {
+				if pages > len(want) {
+					t.Fatal("ページングが終わりません")
+				}
+				page, err := repo.Query(ctx, q)
+				if err != nil {
+					t.Fatalf("Query() error = %v", err)
+				}
+				if len(page.Records) > tt.limit {
+					t.Fatalf("len(Records) = %d, want <= %d", len(page.Records), tt.limit)
+				}
+				got = append(got, page.Records...)
+				if page.NextCursor == "" {
+					break
+				}
+				q.Cursor = page.NextCursor
+			}
+
+			if len(got) != len(want) {
+				t.Fatalf("len(records) = %d, want %d", len(got), len(want))
+			}
+			for i := range want {
+				if got[i].ID != want[i].ID {
+					t.Errorf("records[%d] = %s, want %s", i, got[i].ID, want[i].ID)
+				}
+			}
+		})
+	}
+}
+
+func TestTimeRecordRepositoryImpl_Query_CursorMismatch(t *testing.T) {
+	ctx := context.Background()
+	repo, taskID := newTestTimeRecordRepository(t)
+
+	base := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		start := base.Add(time.Duration(i) * time.Hour)
+		r := &model.TimeRecord{ID: uuid.New(), RunID: uuid.New(), TaskID: taskID, StartTime: start, EndTime: start.Add(time.Hour), Duration: time.Hour}
+		if err := repo.Create(ctx, r); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	page, err := repo.Query(ctx, model.TimeRecordQuery{SortField: model.TimeRecordSortStartTime, Limit: 1})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if page.NextCursor == "" {
+		t.Fatal("NextCursor is empty")
+	}
+
+	tests := []struct {
+		name string
+		q    model.TimeRecordQuery
+	}{
+		{name: "並び順の項目が違う", q: model.TimeRecordQuery{SortField: model.TimeRecordSortDuration, Cursor: page.NextCursor, Limit: 1}},
+		{name: "昇順・降順が違う", q: model.TimeRecordQuery{SortField: model.TimeRecordSortStartTime, Descending: true, Cursor: page.NextCursor, Limit: 1}},
+		{name: "カーソルが不正", q: model.TimeRecordQuery{SortField: model.TimeRecordSortStartTime, Cursor: "!!!", Limit: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := repo.Query(ctx, tt.q); err == nil {
+				t.Error("Query() error = nil, want error")
+			}
+		})
+	}
+}
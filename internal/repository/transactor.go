@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+/*
+ * 複数リポジトリの処理を1つのトランザクションで実行する
+ * トランザクションはコンテキストで受け渡し、各リポジトリはconnで取り出して使用する
+ */
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type transactorImpl struct {
+	db *sqlx.DB
+}
+
+// コンテキストにトランザクションを格納するキー
+type txKey struct{}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param db データベース
+ * @return インスタンス
+ */
+func NewTransactor(db *sql.DB) Transactor {
+	return &transactorImpl{db: sqlx.NewDb(db, "libsql")}
+}
+
+/*
+ * トランザクション内で処理を実行する
+ * fnがエラーを返した場合はロールバック、それ以外はコミットする
+ * 既にトランザクション内の場合はそのトランザクションに参加する
+ *
+ * @param ctx コンテキスト
+ * @param fn トランザクション内で実行する処理
+ * @return エラー
+ */
+func (t *transactorImpl) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	// 既存のトランザクションに参加
+	if _, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+/*
+ * クエリの実行先を取得する
+ * コンテキストにトランザクションがあればそれを、なければDBを返す
+ *
+ * @param ctx コンテキスト
+ * @param db データベース
+ * @return クエリの実行先
+ */
+func conn(ctx context.Context, db *sqlx.DB) sqlx.ExtContext {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return tx
+	}
+	return db
+}
@@ -1,15 +1,21 @@
 package repository
 
 import (
+	"context"
 	"play-wails/internal/model"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type WorkSessionRepository interface {
-	Create(session *model.WorkSession) error
-	FindByID(id uuid.UUID) (*model.WorkSession, error)
-	Update(session *model.WorkSession) error
-	ListByRunID(runID uuid.UUID) ([]*model.WorkSession, error)
-	Delete(id uuid.UUID) error
+	Create(ctx context.Context, session *model.WorkSession) error
+	FindByID(ctx context.Context, id uuid.UUID) (*model.WorkSession, error)
+	Update(ctx context.Context, session *model.WorkSession) error
+	UpdateHeartbeat(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
+	ListByRunID(ctx context.Context, runID uuid.UUID) ([]*model.WorkSession, error)
+	ListByRunIDs(ctx context.Context, runIDs []uuid.UUID) ([]*model.WorkSession, error)
+	ListByRange(ctx context.Context, from time.Time, to time.Time) ([]*model.WorkSession, error)
+	FindRunning(ctx context.Context) ([]*model.WorkSession, error)
+	Delete(ctx context.Context, id uuid.UUID) error
 }
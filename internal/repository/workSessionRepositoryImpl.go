@@ -1,8 +1,10 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"play-wails/internal/model"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,11 +17,12 @@ type workSessionRepositoryImpl struct {
 
 // UUIDはTEXTのためstringで受ける
 type workSessionRow struct {
-	ID        string     `db:"id"`
-	RunID     string     `db:"run_id"`
-	TaskID    string     `db:"task_id"`
-	StartTime time.Time  `db:"start_time"`
-	EndTime   *time.Time `db:"end_time"`
+	ID          string     `db:"id"`
+	RunID       string     `db:"run_id"`
+	TaskID      string     `db:"task_id"`
+	StartTime   time.Time  `db:"start_time"`
+	EndTime     *time.Time `db:"end_time"`
+	HeartbeatAt *time.Time `db:"heartbeat_at"`
 }
 
 /*
@@ -31,8 +34,9 @@ type workSessionRow struct {
 func rowToWorkSession(row *workSessionRow) *model.WorkSession {
 
 	s := &model.WorkSession{
-		StartTime: row.StartTime,
-		EndTime:   row.EndTime,
+		StartTime:   row.StartTime,
+		EndTime:     row.EndTime,
+		HeartbeatAt: row.HeartbeatAt,
 	}
 	s.ID, _ = uuid.Parse(row.ID)
 	s.RunID, _ = uuid.Parse(row.RunID)
@@ -54,31 +58,35 @@ func NewWorkSessionRepositoryImpl(db *sql.DB) WorkSessionRepository {
 /*
  * レコード作成
  *
+ * @param ctx コンテキスト
  * @param session レコード
  * @return エラー
  */
-func (r *workSessionRepositoryImpl) Create(session *model.WorkSession) error {
+func (r *workSessionRepositoryImpl) Create(ctx context.Context, session *model.WorkSession) error {
 	query := `INSERT INTO work_sessions (
 		id
 		, run_id
 		, task_id
 		, start_time
 		, end_time
+		, heartbeat_at
 	) VALUES (
 		:id
 		, :run_id
 		, :task_id
 		, :start_time
 		, :end_time
+		, :heartbeat_at
 	)`
 
 	// インサート処理実行
-	_, err := r.db.NamedExec(query, map[string]interface{}{
-		"id":         session.ID.String(),
-		"run_id":     session.RunID.String(),
-		"task_id":    session.TaskID.String(),
-		"start_time": session.StartTime,
-		"end_time":   session.EndTime,
+	_, err := sqlx.NamedExecContext(ctx, conn(ctx, r.db), query, map[string]interface{}{
+		"id":           session.ID.String(),
+		"run_id":       session.RunID.String(),
+		"task_id":      session.TaskID.String(),
+		"start_time":   session.StartTime,
+		"end_time":     session.EndTime,
+		"heartbeat_at": session.HeartbeatAt,
 	})
 
 	return err
@@ -87,18 +95,20 @@ func (r *workSessionRepositoryImpl) Create(session *model.WorkSession) error {
 /*
  * レコードを取得
  *
+ * @param ctx コンテキスト
  * @param id レコードID
  * @return レコード, エラー
  */
-func (r *workSessionRepositoryImpl) FindByID(id uuid.UUID) (*model.WorkSession, error) {
+func (r *workSessionRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*model.WorkSession, error) {
 	var row workSessionRow
-	err := r.db.Get(&row,
+	err := sqlx.GetContext(ctx, conn(ctx, r.db), &row,
 		`SELECT 
 			id
 			, run_id
 			, task_id
 			, start_time
-			, end_time 
+			, end_time
+			, heartbeat_at 
 		FROM work_sessions 
 		WHERE id = ?`,
 		id.String(),
@@ -119,44 +129,77 @@ func (r *workSessionRepositoryImpl) FindByID(id uuid.UUID) (*model.WorkSession,
 /*
  * レコードを更新
  *
+ * @param ctx コンテキスト
  * @param session レコード
  * @return エラー
  */
-func (r *workSessionRepositoryImpl) Update(session *model.WorkSession) error {
+func (r *workSessionRepositoryImpl) Update(ctx context.Context, session *model.WorkSession) error {
 	query := `UPDATE work_sessions SET 
 		run_id = :run_id
 		, task_id = :task_id
 		, start_time = :start_time
 		, end_time = :end_time
+		, heartbeat_at = :heartbeat_at
 	WHERE id = :id`
 
 	// 更新処理実行
-	_, err := r.db.NamedExec(query, map[string]interface{}{
-		"id":         session.ID.String(),
-		"run_id":     session.RunID.String(),
-		"task_id":    session.TaskID.String(),
-		"start_time": session.StartTime,
-		"end_time":   session.EndTime,
+	_, err := sqlx.NamedExecContext(ctx, conn(ctx, r.db), query, map[string]interface{}{
+		"id":           session.ID.String(),
+		"run_id":       session.RunID.String(),
+		"task_id":      session.TaskID.String(),
+		"start_time":   session.StartTime,
+		"end_time":     session.EndTime,
+		"heartbeat_at": session.HeartbeatAt,
 	})
 	return err
 }
 
+/*
+ * 計測中のレコードのハートビート時刻のみを更新
+ * 読み取り後に停止されたセッションを計測中に戻さないよう、計測中の場合のみ更新する
+ *
+ * @param ctx コンテキスト
+ * @param id ID
+ * @param at ハートビート時刻
+ * @return 更新したか（停止済み・未登録の場合はfalse）, エラー
+ */
+func (r *workSessionRepositoryImpl) UpdateHeartbeat(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE work_sessions SET 
+			heartbeat_at = ? 
+		WHERE id = ? 
+			AND end_time IS NULL`,
+		at, id.String(),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
 /*
  * レコードを取得
  *
+ * @param ctx コンテキスト
  * @param runID レコードID
  * @return レコード, エラー
  */
-func (r *workSessionRepositoryImpl) ListByRunID(runID uuid.UUID) ([]*model.WorkSession, error) {
+func (r *workSessionRepositoryImpl) ListByRunID(ctx context.Context, runID uuid.UUID) ([]*model.WorkSession, error) {
 
 	var rows []workSessionRow
-	err := r.db.Select(&rows,
+	err := sqlx.SelectContext(ctx, conn(ctx, r.db), &rows,
 		`SELECT 
 			id
 			, run_id
 			, task_id
 			, start_time
-			, end_time 
+			, end_time
+			, heartbeat_at 
 		FROM work_sessions 
 		WHERE run_id = ? 
 		ORDER BY start_time`,
@@ -177,14 +220,156 @@ func (r *workSessionRepositoryImpl) ListByRunID(runID uuid.UUID) ([]*model.WorkS
 	return list, nil
 }
 
+/*
+ * 複数の計測実行のレコードをまとめて取得
+ *
+ * @param ctx コンテキスト
+ * @param runIDs 計測実行のグループID一覧
+ * @return レコード一覧（開始時刻順）, エラー
+ */
+func (r *workSessionRepositoryImpl) ListByRunIDs(ctx context.Context, runIDs []uuid.UUID) ([]*model.WorkSession, error) {
+	if len(runIDs) == 0 {
+		return []*model.WorkSession{}, nil
+	}
+
+	// IN句のパラメータを作成
+	ids := make([]string, 0, len(runIDs))
+	for _, id := range runIDs {
+		ids = append(ids, id.String())
+	}
+
+	// IN句のパラメータ数の上限を超えないよう分割して検索
+	const chunk = 500
+	list := make([]*model.WorkSession, 0, len(runIDs))
+	for i := 0; i < len(ids); i += chunk {
+		end := i + chunk
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		query, args, err := sqlx.In(
+			`SELECT 
+				id
+				, run_id
+				, task_id
+				, start_time
+				, end_time
+				, heartbeat_at 
+			FROM work_sessions 
+			WHERE run_id IN (?)`,
+			ids[i:end],
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		var rows []workSessionRow
+		if err := sqlx.SelectContext(ctx, conn(ctx, r.db), &rows, query, args...); err != nil {
+			return nil, err
+		}
+
+		// ワークセッションを全てリストに追加
+		for j := range rows {
+			list = append(list, rowToWorkSession(&rows[j]))
+		}
+	}
+
+	// 分割した検索結果をまとめて開始時刻順に並べる
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].StartTime.Before(list[j].StartTime)
+	})
+
+	return list, nil
+}
+
+/*
+ * 期間と重なるレコードを開始時刻順に取得（計測中のものは終了していない扱い）
+ * 取消済みの計測実行と、計測結果が論理削除済みの計測実行のレコードは除外する
+ *
+ * @param ctx コンテキスト
+ * @param from 期間の開始（含む）
+ * @param to 期間の終了（含まない）
+ * @return レコード一覧, エラー
+ */
+func (r *workSessionRepositoryImpl) ListByRange(ctx context.Context, from time.Time, to time.Time) ([]*model.WorkSession, error) {
+	var rows []workSessionRow
+	err := sqlx.SelectContext(ctx, conn(ctx, r.db), &rows,
+		`SELECT 
+			ws.id
+			, ws.run_id
+			, ws.task_id
+			, ws.start_time
+			, ws.end_time
+			, ws.heartbeat_at 
+		FROM work_sessions ws 
+		JOIN runs r ON r.id = ws.run_id 
+		WHERE r.status <> 'cancelled' 
+			AND julianday(ws.start_time) < julianday(?) 
+			AND (ws.end_time IS NULL OR julianday(ws.end_time) > julianday(?)) 
+			AND NOT EXISTS (
+				SELECT 1 FROM time_records tr WHERE tr.run_id = ws.run_id AND tr.delete_flag = 1
+			) 
+		ORDER BY julianday(ws.start_time)`,
+		to, from,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// ワークセッションを全てリストに追加
+	list := make([]*model.WorkSession, 0, len(rows))
+	for i := range rows {
+		list = append(list, rowToWorkSession(&rows[i]))
+	}
+
+	return list, nil
+}
+
+/*
+ * 実行中（未停止）のレコードを開始時刻の新しい順に取得
+ *
+ * @param ctx コンテキスト
+ * @return レコード一覧, エラー
+ */
+func (r *workSessionRepositoryImpl) FindRunning(ctx context.Context) ([]*model.WorkSession, error) {
+
+	var rows []workSessionRow
+	err := sqlx.SelectContext(ctx, conn(ctx, r.db), &rows,
+		`SELECT 
+			id
+			, run_id
+			, task_id
+			, start_time
+			, end_time
+			, heartbeat_at 
+		FROM work_sessions 
+		WHERE end_time IS NULL 
+		ORDER BY start_time DESC`,
+	)
+
+	// エラーチェック
+	if err != nil {
+		return nil, err
+	}
+
+	// ワークセッションを全てリストに追加
+	list := make([]*model.WorkSession, 0, len(rows))
+	for i := range rows {
+		list = append(list, rowToWorkSession(&rows[i]))
+	}
+
+	return list, nil
+}
+
 /*
  * レコードを削除
  *
+ * @param ctx コンテキスト
  * @param id レコードID
  * @return エラー
  */
-func (r *workSessionRepositoryImpl) Delete(id uuid.UUID) error {
-	_, err := r.db.Exec(
+func (r *workSessionRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
 		`DELETE FROM work_sessions WHERE id = ?`,
 		id.String(),
 	)
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"play-wails/internal/model"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestWorkSessionRepositoryImpl_ListByRunIDs(t *testing.T) {
+	ctx := context.Background()
+	repo := NewWorkSessionRepositoryImpl(openTestDB(t))
+
+	// 分割の境界をまたぐよう、計測実行ごとに開始時刻を逆順にして作成する
+	base := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	runIDs := make([]uuid.UUID, 0)
+	for i := 0; i < 3; i++ {
+		runID := uuid.New()
+		start := base.Add(time.Duration(3-i) * time.Hour)
+		end := start.Add(30 * time.Minute)
+		if err := repo.Create(ctx, &model.WorkSession{ID: uuid.New(), RunID: runID, TaskID: uuid.New(), StartTime: start, EndTime: &end}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		runIDs = append(runIDs, runID)
+		for j := 0; j < 600; j++ {
+			runIDs = append(runIDs, uuid.New())
+		}
+	}
+
+	tests := []struct {
+		name   string
+		runIDs []uuid.UUID
+		want   int
+	}{
+		{name: "指定なし", runIDs: nil, want: 0},
+		{name: "1件", runIDs: runIDs[:1], want: 1},
+		{name: "分割して検索", runIDs: runIDs, want: 3},
+		// SQLiteのパラメータ数の上限（32766）を超える
+		{name: "パラメータ数の上限を超える", runIDs: append(runIDs, make([]uuid.UUID, 40000)...), want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.ListByRunIDs(ctx, tt.runIDs)
+			if err != nil {
+				t.Fatalf("ListByRunIDs() error = %v", err)
+			}
+			if len(got) != tt.want {
+				t.Fatalf("len(sessions) = %d, want %d", len(got), tt.want)
+			}
+			for i := 1; i < len(got); i++ {
+				if got[i].StartTime.Before(got[i-1].StartTime) {
+					t.Errorf("sessions[%d] = %v, 開始時刻順ではありません", i, got[i].StartTime)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"play-wails/internal/model"
+	"play-wails/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+/*
+ * AuditService は計測結果・作業セッションの変更履歴を参照する
+ * 変更履歴の記録は各リポジトリ（NewAuditedTimeRecordRepository等）で行う
+ */
+type AuditService struct {
+	audit repository.AuditLogRepository
+	trepo repository.TimeRecordRepository
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param audit 変更履歴リポジトリ
+ * @param trepo 時間計測レコードリポジトリ
+ * @return インスタンス
+ */
+func NewAuditService(audit repository.AuditLogRepository, trepo repository.TimeRecordRepository) *AuditService {
+	return &AuditService{audit: audit, trepo: trepo}
+}
+
+/*
+ * 計測結果の変更履歴を取得する（古い順）
+ * 計測結果と、同じ計測実行の作業セッションの変更を含む
+ *
+ * @param ctx コンテキスト
+ * @param recordID 計測結果ID
+ * @return 変更履歴, エラー
+ */
+func (s *AuditService) History(ctx context.Context, recordID uuid.UUID) ([]*model.AuditEntry, error) {
+	var runID uuid.UUID
+	record, err := s.trepo.FindByID(ctx, recordID)
+	switch {
+	case err == nil:
+		runID = record.RunID
+	case errors.Is(err, sql.ErrNoRows):
+		// 計測結果が残っていない場合は履歴から計測実行を特定する
+		entries, err := s.audit.ListByRow(ctx, model.AuditTableTimeRecords, recordID)
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) == 0 {
+			return nil, errors.New("【ERROR】計測結果が見つかりません。")
+		}
+		runID = entries[0].RunID
+	default:
+		return nil, err
+	}
+
+	return s.audit.ListByRunID(ctx, runID)
+}
+
+/*
+ * 作業セッションの変更履歴を取得する（古い順）
+ *
+ * @param ctx コンテキスト
+ * @param sessionID 作業セッションID
+ * @return 変更履歴, エラー
+ */
+func (s *AuditService) SessionHistory(ctx context.Context, sessionID uuid.UUID) ([]*model.AuditEntry, error) {
+	return s.audit.ListByRow(ctx, model.AuditTableWorkSessions, sessionID)
+}
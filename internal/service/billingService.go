@@ -0,0 +1,491 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"play-wails/internal/model"
+	"play-wails/internal/repository"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 時間単価の入力誤りの全体メッセージ
+const invalidRateMessage = "【ERROR】時間単価の入力内容に誤りがあります。"
+
+/*
+ * 請求額の計算条件
+ * 期間をまたぐ計測結果はReportと同様に期間内の部分のみを対象とする
+ * ClientID・ProjectIDを指定した場合はそのクライアント・プロジェクトのタスクに絞り込む
+ * UninvoicedOnlyの場合は請求済みの計測結果を除く
+ */
+type BillingQuery struct {
+	From           time.Time
+	To             time.Time
+	TimeZone       string
+	ClientID       *uuid.UUID
+	ProjectID      *uuid.UUID
+	UninvoicedOnly bool
+	Rounding       model.RoundingRule
+}
+
+/*
+ * BillingService は時間単価の管理と、期間内の請求額の計算を行う
+ * 丸めは計算時にのみ適用し、保存されている作業時間は変更しない
+ */
+type BillingService struct {
+	rateRepo    repository.RateRepository
+	trepo       repository.TimeRecordRepository
+	wrepo       repository.WorkSessionRepository
+	taskRepo    repository.TaskRepository
+	projectRepo repository.ProjectRepository
+	clientRepo  repository.ClientRepository
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param rateRepo 時間単価リポジトリ
+ * @param trepo 時間計測レコードリポジトリ
+ * @param wrepo 作業セッションリポジトリ
+ * @param taskRepo タスクリポジトリ
+ * @param projectRepo プロジェクトリポジトリ
+ * @param clientRepo クライアントリポジトリ
+ * @return インスタンス
+ */
+func NewBillingService(rateRepo repository.RateRepository, trepo repository.TimeRecordRepository, wrepo repository.WorkSessionRepository, taskRepo repository.TaskRepository, projectRepo repository.ProjectRepository, clientRepo repository.ClientRepository) *BillingService {
+	return &BillingService{rateRepo: rateRepo, trepo: trepo, wrepo: wrepo, taskRepo: taskRepo, projectRepo: projectRepo, clientRepo: clientRepo}
+}
+
+// 請求額の計算対象の計測結果（startとdurationは期間内に切り詰めた開始時刻と作業時間）
+type billableRecord struct {
+	record   *model.TimeRecord
+	start    time.Time
+	duration time.Duration
+}
+
+/*
+ * 時間単価を設定する
+ * 既存の単価は変更せず、適用開始日時の異なる単価として追加する
+ *
+ * @param ctx コンテキスト
+ * @param in 入力内容（階層・対象・単価・通貨・適用開始日時）
+ * @return 時間単価, エラー（入力の誤りは*model.ValidationError）
+ */
+func (s *BillingService) SetRate(ctx context.Context, in *model.Rate) (*model.Rate, error) {
+	v := model.NewValidationError(invalidRateMessage)
+
+	if !in.Level.IsValid() {
+		v.Add("level", "設定先はclient / project / taskのいずれかを指定してください")
+	} else if err := s.validateRateTarget(ctx, in.Level, in.TargetID); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		v.Add("target_id", "設定先が見つかりません")
+	}
+	if in.HourlyRate < 0 {
+		v.Add("hourly_rate", "単価は0以上を指定してください")
+	}
+	currency := strings.ToUpper(strings.TrimSpace(in.Currency))
+	if !isCurrencyCode(currency) {
+		v.Add("currency", "通貨はISO 4217の3文字のコード（JPY・USDなど）を指定してください")
+	}
+	if in.EffectiveFrom.IsZero() {
+		v.Add("effective_from", "適用開始日時を入力してください")
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	rate := &model.Rate{
+		ID:            uuid.New(),
+		Level:         in.Level,
+		TargetID:      in.TargetID,
+		HourlyRate:    in.HourlyRate,
+		Currency:      currency,
+		EffectiveFrom: in.EffectiveFrom,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := s.rateRepo.Create(ctx, rate); err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
+
+/*
+ * 対象に設定された時間単価の一覧を取得する（適用開始日時の新しい順）
+ *
+ * @param ctx コンテキスト
+ * @param level 階層
+ * @param targetID 対象のID
+ * @return 時間単価一覧, エラー
+ */
+func (s *BillingService) Rates(ctx context.Context, level model.RateLevel, targetID uuid.UUID) ([]*model.Rate, error) {
+	return s.rateRepo.ListByTarget(ctx, level, targetID)
+}
+
+/*
+ * 時間単価を削除する
+ *
+ * @param ctx コンテキスト
+ * @param id 時間単価ID
+ * @return エラー
+ */
+func (s *BillingService) DeleteRate(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.rateRepo.FindByID(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("【ERROR】時間単価が見つかりません。")
+		}
+		return err
+	}
+	return s.rateRepo.Delete(ctx, id)
+}
+
+/*
+ * 期間内の請求額を計算する
+ * 計測結果ごとにタスク → プロジェクト → クライアントの順で適用される単価を探し、
+ * 丸めの単位ごとに請求時間を切り上げて金額を求める
+ *
+ * @param ctx コンテキスト
+ * @param q 計算条件
+ * @return 計算結果, エラー
+ */
+func (s *BillingService) Billable(ctx context.Context, q BillingQuery) (*model.BillableSummary, error) {
+	if !q.From.Before(q.To) {
+		return nil, errors.New("【ERROR】集計期間の開始は終了より前にしてください。")
+	}
+	records, err := s.trepo.ListByRange(ctx, q.From, q.To)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*model.TimeRecord, 0, len(records))
+	for _, r := range records {
+		if q.UninvoicedOnly && r.IsInvoiced() {
+			continue
+		}
+		list = append(list, r)
+	}
+
+	// 期間内の作業時間を計測結果ごとに合計する
+	intervals, err := recordIntervals(ctx, s.wrepo, list)
+	if err != nil {
+		return nil, err
+	}
+	durations := make(map[uuid.UUID]time.Duration, len(list))
+	for _, iv := range intervals {
+		durations[iv.recordID] += iv.durationWithin(q.From, q.To)
+	}
+	billable := make([]billableRecord, 0, len(list))
+	for _, r := range list {
+		d := durations[r.ID]
+		if d <= 0 {
+			continue
+		}
+		start, _ := clip(r.StartTime, r.EndTime, q.From, q.To)
+		billable = append(billable, billableRecord{record: r, start: start, duration: d})
+	}
+	return s.summarize(ctx, billable, q)
+}
+
+/*
+ * 計測結果の請求額を計算する
+ *
+ * @param ctx コンテキスト
+ * @param records 計測結果一覧（期間内に切り詰めた開始時刻と作業時間）
+ * @param q 計算条件（期間は計算結果に記録するのみ）
+ * @return 計算結果, エラー
+ */
+func (s *BillingService) summarize(ctx context.Context, records []billableRecord, q BillingQuery) (*model.BillableSummary, error) {
+	loc, err := loadLocation(q.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := normalizeRounding(q.Rounding)
+	if err != nil {
+		return nil, err
+	}
+	h, err := s.loadHierarchy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rates, err := s.loadRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &model.BillableSummary{
+		From:     q.From,
+		To:       q.To,
+		TimeZone: loc.String(),
+		Rounding: rule,
+		Items:    make([]model.BillableItem, 0),
+		Totals:   make([]model.BillableTotal, 0),
+	}
+
+	// 丸めの単位ごとにまとめる（計測結果ごとの場合は1件ずつ）
+	groups := map[string]*model.BillableItem{}
+	order := make([]string, 0)
+	for _, br := range records {
+		r := br.record
+		task, ok := h.tasks[r.TaskID]
+		if !ok {
+			continue
+		}
+		projectID, clientID := h.parents(task)
+		if q.ClientID != nil && (clientID == nil || *clientID != *q.ClientID) {
+			continue
+		}
+		if q.ProjectID != nil && (projectID == nil || *projectID != *q.ProjectID) {
+			continue
+		}
+		if task.NonBillable || r.NonBillable {
+			summary.NonBillable += br.duration
+			continue
+		}
+
+		rate := rates.resolve(br.start, task.ID, projectID, clientID)
+		date := br.start.In(loc).Format("2006-01-02")
+		key := r.ID.String()
+		if rule.Scope == model.RoundingScopeDay {
+			rateKey := ""
+			if rate != nil {
+				rateKey = rate.ID.String()
+			}
+			key = date + "/" + task.ID.String() + "/" + rateKey
+		}
+
+		item, ok := groups[key]
+		if !ok {
+			item = &model.BillableItem{
+				Date:          date,
+				TaskID:        task.ID,
+				TaskTitle:     task.Title,
+				ProjectID:     projectID,
+				ClientID:      clientID,
+				TimeRecordIDs: make([]uuid.UUID, 0, 1),
+			}
+			if rate != nil {
+				id := rate.ID
+				item.RateID = &id
+				item.HourlyRate = rate.HourlyRate
+				item.Currency = rate.Currency
+			}
+			groups[key] = item
+			order = append(order, key)
+		}
+		item.TimeRecordIDs = append(item.TimeRecordIDs, r.ID)
+		item.Duration += br.duration
+	}
+
+	// 丸めて金額を計算し、通貨ごとに合計する
+	totals := map[string]*model.BillableTotal{}
+	for _, key := range order {
+		item := groups[key]
+		item.BilledDuration = roundUpDuration(item.Duration, rule.Increment)
+		if item.RateID == nil {
+			summary.Unrated += item.BilledDuration
+		} else {
+			item.Amount = billableAmount(item.HourlyRate, item.BilledDuration)
+			t, ok := totals[item.Currency]
+			if !ok {
+				t = &model.BillableTotal{Currency: item.Currency}
+				totals[item.Currency] = t
+			}
+			t.BilledDuration += item.BilledDuration
+			t.Amount += item.Amount
+		}
+		summary.Items = append(summary.Items, *item)
+	}
+	sort.SliceStable(summary.Items, func(i, j int) bool {
+		if summary.Items[i].Date != summary.Items[j].Date {
+			return summary.Items[i].Date < summary.Items[j].Date
+		}
+		return summary.Items[i].TaskTitle < summary.Items[j].TaskTitle
+	})
+	for _, t := range totals {
+		summary.Totals = append(summary.Totals, *t)
+	}
+	sort.Slice(summary.Totals, func(i, j int) bool {
+		return summary.Totals[i].Currency < summary.Totals[j].Currency
+	})
+
+	return summary, nil
+}
+
+/*
+ * 指定された階層の対象が存在するか検証する
+ *
+ * @param ctx コンテキスト
+ * @param level 階層
+ * @param targetID 対象のID
+ * @return エラー（存在しない場合はsql.ErrNoRows）
+ */
+func (s *BillingService) validateRateTarget(ctx context.Context, level model.RateLevel, targetID uuid.UUID) error {
+	var err error
+	switch level {
+	case model.RateLevelClient:
+		_, err = s.clientRepo.FindByID(ctx, targetID)
+	case model.RateLevelProject:
+		_, err = s.projectRepo.FindByID(ctx, targetID)
+	default:
+		_, err = s.taskRepo.FindByID(ctx, targetID)
+	}
+	return err
+}
+
+// タスク → プロジェクト → クライアントの対応
+type billingHierarchy struct {
+	tasks    map[uuid.UUID]*model.Task
+	projects map[uuid.UUID]*model.Project
+}
+
+/*
+ * タスクの所属プロジェクトとクライアントを取得する（所属なしはnil）
+ */
+func (h *billingHierarchy) parents(task *model.Task) (*uuid.UUID, *uuid.UUID) {
+	if task.ProjectID == nil {
+		return nil, nil
+	}
+	p, ok := h.projects[*task.ProjectID]
+	if !ok {
+		return task.ProjectID, nil
+	}
+	return task.ProjectID, p.ClientID
+}
+
+/*
+ * タスク・プロジェクトをすべて取得する（アーカイブ済みを含む）
+ */
+func (s *BillingService) loadHierarchy(ctx context.Context) (*billingHierarchy, error) {
+	tasks, err := s.taskRepo.List(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := s.projectRepo.List(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &billingHierarchy{
+		tasks:    make(map[uuid.UUID]*model.Task, len(tasks)),
+		projects: make(map[uuid.UUID]*model.Project, len(projects)),
+	}
+	for _, t := range tasks {
+		h.tasks[t.ID] = t
+	}
+	for _, p := range projects {
+		h.projects[p.ID] = p
+	}
+	return h, nil
+}
+
+// 階層・対象ごとの時間単価（適用開始日時の新しい順）
+type rateTable map[string][]*model.Rate
+
+/*
+ * 時間単価をすべて取得し、階層・対象ごとにまとめる
+ */
+func (s *BillingService) loadRates(ctx context.Context) (rateTable, error) {
+	rates, err := s.rateRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	table := rateTable{}
+	for _, r := range rates {
+		key := rateKey(r.Level, r.TargetID)
+		table[key] = append(table[key], r)
+	}
+	return table, nil
+}
+
+/*
+ * 計測結果に適用する時間単価を探す（タスク → プロジェクト → クライアントの順、見つからない場合はnil）
+ *
+ * @param at 計測結果の開始時刻
+ * @param taskID タスクID
+ * @param projectID プロジェクトID（なしはnil）
+ * @param clientID クライアントID（なしはnil）
+ * @return 時間単価
+ */
+func (t rateTable) resolve(at time.Time, taskID uuid.UUID, projectID *uuid.UUID, clientID *uuid.UUID) *model.Rate {
+	keys := []string{rateKey(model.RateLevelTask, taskID)}
+	if projectID != nil {
+		keys = append(keys, rateKey(model.RateLevelProject, *projectID))
+	}
+	if clientID != nil {
+		keys = append(keys, rateKey(model.RateLevelClient, *clientID))
+	}
+	for _, key := range keys {
+		for _, r := range t[key] {
+			if !r.EffectiveFrom.After(at) {
+				return r
+			}
+		}
+	}
+	return nil
+}
+
+func rateKey(level model.RateLevel, targetID uuid.UUID) string {
+	return string(level) + "/" + targetID.String()
+}
+
+/*
+ * 丸めの設定を検証する（単位の省略は計測結果ごと）
+ * 丸めの単位は1〜60分の分単位（6分・15分・30分など）、0の場合は丸めない
+ *
+ * @param rule 丸めの設定
+ * @return 検証後の設定, エラー
+ */
+func normalizeRounding(rule model.RoundingRule) (model.RoundingRule, error) {
+	if rule.Scope == "" {
+		rule.Scope = model.RoundingScopeRecord
+	}
+	switch rule.Scope {
+	case model.RoundingScopeRecord, model.RoundingScopeDay:
+	default:
+		return rule, fmt.Errorf("【ERROR】丸めの単位が不正です: %s（record / day）", rule.Scope)
+	}
+	if rule.Increment < 0 || rule.Increment > time.Hour || rule.Increment%time.Minute != 0 {
+		return rule, errors.New("【ERROR】丸めの間隔は0〜60分の分単位で指定してください。")
+	}
+	return rule, nil
+}
+
+/*
+ * 作業時間を丸めの間隔に切り上げる（間隔が0の場合はそのまま）
+ */
+func roundUpDuration(d time.Duration, increment time.Duration) time.Duration {
+	if increment <= 0 || d <= 0 {
+		return d
+	}
+	return (d + increment - 1) / increment * increment
+}
+
+/*
+ * 請求時間と時間単価から金額を求める（通貨の最小単位で四捨五入）
+ */
+func billableAmount(hourlyRate int64, billed time.Duration) int64 {
+	return int64(math.Round(float64(hourlyRate) * billed.Hours()))
+}
+
+/*
+ * ISO 4217の通貨コード（英大文字3文字）か判定
+ */
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
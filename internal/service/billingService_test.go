@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"play-wails/internal/model"
+	"play-wails/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestRoundUpDuration(t *testing.T) {
+	tests := []struct {
+		name      string
+		d         time.Duration
+		increment time.Duration
+		want      time.Duration
+	}{
+		{name: "丸めない", d: 7*time.Minute + 30*time.Second, increment: 0, want: 7*time.Minute + 30*time.Second},
+		{name: "ちょうど", d: 30 * time.Minute, increment: 15 * time.Minute, want: 30 * time.Minute},
+		{name: "1ナノ秒超えたら切り上げ", d: 30*time.Minute + time.Nanosecond, increment: 15 * time.Minute, want: 45 * time.Minute},
+		{name: "6分単位", d: 61 * time.Minute, increment: 6 * time.Minute, want: 66 * time.Minute},
+		{name: "間隔未満は1単位", d: time.Second, increment: time.Hour, want: time.Hour},
+		{name: "0は0のまま", d: 0, increment: 15 * time.Minute, want: 0},
+		{name: "負の間隔は丸めない", d: 10 * time.Minute, increment: -time.Minute, want: 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := roundUpDuration(tt.d, tt.increment); got != tt.want {
+				t.Errorf("roundUpDuration(%v, %v) = %v, want %v", tt.d, tt.increment, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBillableAmount(t *testing.T) {
+	tests := []struct {
+		name       string
+		hourlyRate int64
+		billed     time.Duration
+		want       int64
+	}{
+		{name: "1時間", hourlyRate: 5000, billed: time.Hour, want: 5000},
+		{name: "15分", hourlyRate: 5000, billed: 15 * time.Minute, want: 1250},
+		{name: "端数は四捨五入（切り捨て側）", hourlyRate: 1000, billed: 20 * time.Minute, want: 333},
+		{name: "端数は四捨五入（切り上げ側）", hourlyRate: 2000, billed: 20 * time.Minute, want: 667},
+		{name: "ちょうど0.5は切り上げ", hourlyRate: 1, billed: 30 * time.Minute, want: 1},
+		{name: "最小単位（セント）で計算", hourlyRate: 12550, billed: 90 * time.Minute, want: 18825},
+		{name: "請求時間0", hourlyRate: 5000, billed: 0, want: 0},
+		{name: "単価0", hourlyRate: 0, billed: time.Hour, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := billableAmount(tt.hourlyRate, tt.billed); got != tt.want {
+				t.Errorf("billableAmount(%d, %v) = %d, want %d", tt.hourlyRate, tt.billed, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeRounding(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    model.RoundingRule
+		want    model.RoundingRule
+		wantErr bool
+	}{
+		{name: "単位の省略は計測結果ごと", rule: model.RoundingRule{Increment: 15 * time.Minute}, want: model.RoundingRule{Increment: 15 * time.Minute, Scope: model.RoundingScopeRecord}},
+		{name: "日ごと", rule: model.RoundingRule{Increment: 6 * time.Minute, Scope: model.RoundingScopeDay}, want: model.RoundingRule{Increment: 6 * time.Minute, Scope: model.RoundingScopeDay}},
+		{name: "丸めない", rule: model.RoundingRule{}, want: model.RoundingRule{Scope: model.RoundingScopeRecord}},
+		{name: "60分まで", rule: model.RoundingRule{Increment: time.Hour}, want: model.RoundingRule{Increment: time.Hour, Scope: model.RoundingScopeRecord}},
+		{name: "60分を超える", rule: model.RoundingRule{Increment: 61 * time.Minute}, wantErr: true},
+		{name: "分単位ではない", rule: model.RoundingRule{Increment: 90 * time.Second}, wantErr: true},
+		{name: "負の間隔", rule: model.RoundingRule{Increment: -time.Minute}, wantErr: true},
+		{name: "単位が不正", rule: model.RoundingRule{Increment: time.Minute, Scope: "week"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeRounding(tt.rule)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalizeRounding() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("normalizeRounding() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateTable_Resolve(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+	rateRepo := repository.NewRateRepositoryImpl(conn)
+	s := NewBillingService(rateRepo, nil, nil, nil, nil, nil)
+
+	taskID, otherTaskID, projectID, clientID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	rates := []*model.Rate{
+		{Level: model.RateLevelClient, TargetID: clientID, HourlyRate: 5000, EffectiveFrom: date(1, 1)},
+		{Level: model.RateLevelClient, TargetID: clientID, HourlyRate: 6000, EffectiveFrom: date(7, 1)},
+		{Level: model.RateLevelProject, TargetID: projectID, HourlyRate: 8000, EffectiveFrom: date(4, 1)},
+		{Level: model.RateLevelTask, TargetID: taskID, HourlyRate: 10000, EffectiveFrom: date(10, 1)},
+	}
+	for _, r := range rates {
+		r.ID = uuid.New()
+		r.Currency = "JPY"
+		r.CreatedAt, r.UpdatedAt = time.Now(), time.Now()
+		if err := rateRepo.Create(ctx, r); err != nil {
+			t.Fatalf("時間単価を作成できません: %v", err)
+		}
+	}
+	table, err := s.loadRates(ctx)
+	if err != nil {
+		t.Fatalf("loadRates() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		at        time.Time
+		taskID    uuid.UUID
+		projectID *uuid.UUID
+		clientID  *uuid.UUID
+		want      int64 // 0は単価なし
+	}{
+		{name: "タスクの単価が優先", at: date(10, 15), taskID: taskID, projectID: &projectID, clientID: &clientID, want: 10000},
+		{name: "タスクの単価の適用前はプロジェクト", at: date(9, 30), taskID: taskID, projectID: &projectID, clientID: &clientID, want: 8000},
+		{name: "プロジェクトの単価の適用前はクライアント", at: date(3, 31), taskID: taskID, projectID: &projectID, clientID: &clientID, want: 5000},
+		{name: "クライアントは適用日時が最も新しい単価", at: date(9, 30), taskID: otherTaskID, clientID: &clientID, want: 6000},
+		{name: "適用日時ちょうどから適用", at: date(7, 1), taskID: otherTaskID, clientID: &clientID, want: 6000},
+		{name: "適用日時の直前は前の単価", at: date(7, 1).Add(-time.Nanosecond), taskID: otherTaskID, clientID: &clientID, want: 5000},
+		{name: "どの単価も適用前", at: date(1, 1).Add(-time.Hour), taskID: otherTaskID, projectID: &projectID, clientID: &clientID},
+		{name: "プロジェクト・クライアントなし", at: date(9, 30), taskID: taskID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := table.resolve(tt.at, tt.taskID, tt.projectID, tt.clientID)
+			if tt.want == 0 {
+				if got != nil {
+					t.Errorf("resolve() = %d, want nil", got.HourlyRate)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("resolve() = nil, want %d", tt.want)
+			}
+			if got.HourlyRate != tt.want {
+				t.Errorf("resolve() = %d, want %d", got.HourlyRate, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"play-wails/internal/model"
+	"play-wails/internal/repository"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ClientService struct {
+	repo repository.ClientRepository
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param repo クライアントリポジトリ
+ * @return インスタンス
+ */
+func NewClientService(repo repository.ClientRepository) *ClientService {
+	return &ClientService{repo: repo}
+}
+
+/*
+ * クライアントを新規作成する
+ *
+ * @param ctx コンテキスト
+ * @param name 名前
+ * @return クライアント, エラー
+ */
+func (s *ClientService) Create(ctx context.Context, name string) (*model.Client, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("【ERROR】クライアント名を入力してください。")
+	}
+
+	now := time.Now()
+	client := &model.Client{
+		ID:        uuid.New(),
+		Name:      name,
+		Archived:  false,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.repo.Create(ctx, client); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+/*
+ * 指定IDのクライアントを取得する
+ *
+ * @param ctx コンテキスト
+ * @param id クライアントID
+ * @return クライアント, エラー
+ */
+func (s *ClientService) Get(ctx context.Context, id uuid.UUID) (*model.Client, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+/*
+ * クライアント一覧を取得する
+ *
+ * @param ctx コンテキスト
+ * @param includeArchived true のときアーカイブ済みも含める
+ * @return クライアント一覧, エラー
+ */
+func (s *ClientService) List(ctx context.Context, includeArchived bool) ([]*model.Client, error) {
+	return s.repo.List(ctx, includeArchived)
+}
+
+/*
+ * クライアントの名前を更新する
+ *
+ * @param ctx コンテキスト
+ * @param client クライアント
+ * @return 更新後のクライアント, エラー
+ */
+func (s *ClientService) Update(ctx context.Context, client *model.Client) (*model.Client, error) {
+	current, err := s.repo.FindByID(ctx, client.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 入力チェック
+	name := strings.TrimSpace(client.Name)
+	if name == "" {
+		return nil, errors.New("【ERROR】クライアント名を入力してください。")
+	}
+
+	current.Name = name
+	current.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, current); err != nil {
+		return nil, err
+	}
+
+	return current, nil
+}
+
+/*
+ * クライアントのアーカイブ状態を切り替える
+ * アーカイブ済みのクライアントのプロジェクトのタスクでは計測を開始できない
+ *
+ * @param ctx コンテキスト
+ * @param id クライアントID
+ * @param archived アーカイブするか
+ * @return エラー
+ */
+func (s *ClientService) SetArchived(ctx context.Context, id uuid.UUID, archived bool) error {
+	client, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	client.Archived = archived
+	client.UpdatedAt = time.Now()
+
+	return s.repo.Update(ctx, client)
+}
+
+/*
+ * クライアントを削除する
+ * プロジェクトから参照されている場合は削除できない
+ *
+ * @param ctx コンテキスト
+ * @param id クライアントID
+ * @return エラー
+ */
+func (s *ClientService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.repo.Delete(ctx, id)
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"play-wails/internal/model"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// iCalendarのUIDのドメイン部分（UIDは「ID@calendarUIDDomain」）
+const calendarUIDDomain = "play-wails"
+
+// iCalendarのUTC日時の書式
+const calendarTimeLayout = "20060102T150405Z"
+
+// カレンダーの1件分
+type calendarEvent struct {
+	uid         string
+	summary     string
+	description string
+	start       time.Time
+	end         time.Time
+}
+
+/*
+ * 期間内の作業セッションまたは計測結果をiCalendar（.ics）で書き出す
+ * UIDは作業セッション（計測結果）のIDから作成するため、再出力しても同じ予定として扱われる
+ * 計測中のセッション・取消済みの計測実行・論理削除済みの計測結果は除外する
+ *
+ * @param ctx コンテキスト
+ * @param w 書き出し先
+ * @param opts エクスポートの条件
+ * @return 書き出した予定の件数, エラー
+ */
+func (s *ExportService) ExportCalendar(ctx context.Context, w io.Writer, opts model.CalendarExportOptions) (int, error) {
+	if !opts.From.Before(opts.To) {
+		return 0, errors.New("【ERROR】エクスポート期間の開始は終了より前にしてください。")
+	}
+
+	tasks, err := s.taskRepo.List(ctx, true)
+	if err != nil {
+		return 0, err
+	}
+	taskByID := make(map[uuid.UUID]*model.Task, len(tasks))
+	for _, t := range tasks {
+		taskByID[t.ID] = t
+	}
+	summary := func(taskID uuid.UUID) (string, string) {
+		if t, ok := taskByID[taskID]; ok {
+			return t.Title, t.Description
+		}
+		return "", ""
+	}
+
+	events := make([]calendarEvent, 0)
+	switch opts.Unit {
+	case model.CalendarEventSession, "":
+		sessions, err := s.wrepo.ListByRange(ctx, opts.From, opts.To)
+		if err != nil {
+			return 0, err
+		}
+		for _, sess := range sessions {
+			if sess.EndTime == nil {
+				continue
+			}
+			title, desc := summary(sess.TaskID)
+			events = append(events, calendarEvent{
+				uid:         sess.ID.String() + "@" + calendarUIDDomain,
+				summary:     title,
+				description: desc,
+				start:       sess.StartTime,
+				end:         *sess.EndTime,
+			})
+		}
+	case model.CalendarEventRecord:
+		records, err := s.trepo.ListByRange(ctx, opts.From, opts.To)
+		if err != nil {
+			return 0, err
+		}
+		for _, r := range records {
+			title, desc := summary(r.TaskID)
+			events = append(events, calendarEvent{
+				uid:         "record-" + r.ID.String() + "@" + calendarUIDDomain,
+				summary:     title,
+				description: strings.TrimSpace(desc + "\n作業時間: " + formatHMS(r.Duration)),
+				start:       r.StartTime,
+				end:         r.EndTime,
+			})
+		}
+	default:
+		return 0, fmt.Errorf("【ERROR】カレンダーに出力する単位が不正です: %s（session / record）", opts.Unit)
+	}
+
+	if err := writeCalendar(w, events, time.Now()); err != nil {
+		return 0, err
+	}
+	return len(events), nil
+}
+
+/*
+ * iCalendar（RFC 5545）の形式で書き出す
+ * 日時はUTC、改行はCRLF、75バイトを超える行は折り返す
+ */
+func writeCalendar(w io.Writer, events []calendarEvent, now time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(name string, value string) {
+		writeCalendarLine(bw, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//"+calendarUIDDomain+"//Time Records//JA")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", "作業記録")
+	for _, e := range events {
+		line("BEGIN", "VEVENT")
+		line("UID", e.uid)
+		line("DTSTAMP", now.UTC().Format(calendarTimeLayout))
+		line("DTSTART", e.start.UTC().Format(calendarTimeLayout))
+		line("DTEND", e.end.UTC().Format(calendarTimeLayout))
+		line("SUMMARY", escapeCalendarText(e.summary))
+		if e.description != "" {
+			line("DESCRIPTION", escapeCalendarText(e.description))
+		}
+		line("TRANSP", "OPAQUE")
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+
+	return bw.Flush()
+}
+
+/*
+ * 1行を書き出す（75バイトを超える場合は文字の途中で切らずに折り返す）
+ */
+func writeCalendarLine(w *bufio.Writer, s string) {
+	const limit = 75
+	width := limit
+	for len(s) > width {
+		cut := width
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.WriteString(s[:cut])
+		w.WriteString("\r\n ")
+		s = s[cut:]
+		// 折り返した行は先頭の空白を含めて75バイト
+		width = limit - 1
+	}
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
+
+/*
+ * TEXT型の値をエスケープする
+ */
+func escapeCalendarText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
@@ -0,0 +1,389 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"play-wails/internal/model"
+	"play-wails/internal/repository"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ExportService struct {
+	trepo    repository.TimeRecordRepository
+	wrepo    repository.WorkSessionRepository
+	taskRepo repository.TaskRepository
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param trepo 時間計測レコードリポジトリ
+ * @param wrepo 作業セッションリポジトリ
+ * @param taskRepo タスクリポジトリ
+ * @return インスタンス
+ */
+func NewExportService(trepo repository.TimeRecordRepository, wrepo repository.WorkSessionRepository, taskRepo repository.TaskRepository) *ExportService {
+	return &ExportService{trepo: trepo, wrepo: wrepo, taskRepo: taskRepo}
+}
+
+// 作業セッションを出力する場合のCSVの追加項目
+var exportSessionColumns = []string{
+	"session_id",
+	"session_start_time",
+	"session_end_time",
+	"session_duration",
+}
+
+// エクスポートする1件分のデータ
+type exportRow struct {
+	record   *model.TimeRecord
+	title    string
+	sessions []*model.WorkSession
+}
+
+/*
+ * 期間内の計測結果をCSVまたはJSON Linesで書き出す（論理削除済みは除外）
+ *
+ * @param ctx コンテキスト
+ * @param w 書き出し先
+ * @param opts エクスポートの条件
+ * @return 書き出した計測結果の件数, エラー
+ */
+func (s *ExportService) Export(ctx context.Context, w io.Writer, opts model.ExportOptions) (int, error) {
+	opts, loc, err := normalizeExportOptions(opts)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := s.rows(ctx, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	f := exportFormatter{columns: opts.Columns, durationFormat: opts.DurationFormat, loc: loc}
+	switch opts.Format {
+	case model.ExportFormatJSONL:
+		err = f.writeJSONL(w, rows, opts.IncludeSessions)
+	default:
+		err = f.writeCSV(w, rows, opts.IncludeSessions)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(rows), nil
+}
+
+/*
+ * エクスポートの条件を検証し、未指定の項目を既定値で補う
+ *
+ * @param opts エクスポートの条件
+ * @return 補正後の条件, タイムゾーン, エラー
+ */
+func normalizeExportOptions(opts model.ExportOptions) (model.ExportOptions, *time.Location, error) {
+	if !opts.From.Before(opts.To) {
+		return opts, nil, errors.New("【ERROR】エクスポート期間の開始は終了より前にしてください。")
+	}
+
+	switch opts.Format {
+	case "":
+		opts.Format = model.ExportFormatCSV
+	case model.ExportFormatCSV, model.ExportFormatJSONL:
+	default:
+		return opts, nil, fmt.Errorf("【ERROR】エクスポート形式が不正です: %s（csv / jsonl）", opts.Format)
+	}
+
+	switch opts.DurationFormat {
+	case "":
+		opts.DurationFormat = model.DurationFormatHMS
+	case model.DurationFormatHMS, model.DurationFormatHours, model.DurationFormatNanoseconds:
+	default:
+		return opts, nil, fmt.Errorf("【ERROR】作業時間の表記が不正です: %s（hms / hours / ns）", opts.DurationFormat)
+	}
+
+	if len(opts.Columns) == 0 {
+		opts.Columns = model.DefaultExportColumns
+	}
+	seen := make(map[model.ExportColumn]bool, len(opts.Columns))
+	for _, c := range opts.Columns {
+		if !c.IsValid() {
+			return opts, nil, fmt.Errorf("【ERROR】エクスポート項目が不正です: %s", c)
+		}
+		if seen[c] {
+			return opts, nil, fmt.Errorf("【ERROR】エクスポート項目が重複しています: %s", c)
+		}
+		seen[c] = true
+	}
+
+	loc, err := loadLocation(opts.TimeZone)
+	if err != nil {
+		return opts, nil, err
+	}
+	return opts, loc, nil
+}
+
+/*
+ * 出力する計測結果と、必要に応じて作業セッションを取得する
+ */
+func (s *ExportService) rows(ctx context.Context, opts model.ExportOptions) ([]exportRow, error) {
+	records, err := s.trepo.ListByRange(ctx, opts.From, opts.To)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.taskRepo.List(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	titles := make(map[uuid.UUID]string, len(tasks))
+	for _, t := range tasks {
+		titles[t.ID] = t.Title
+	}
+
+	sessionsByRun := map[uuid.UUID][]*model.WorkSession{}
+	if opts.IncludeSessions && len(records) > 0 {
+		runIDs := make([]uuid.UUID, 0, len(records))
+		for _, r := range records {
+			runIDs = append(runIDs, r.RunID)
+		}
+		sessions, err := s.wrepo.ListByRunIDs(ctx, runIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, sess := range sessions {
+			sessionsByRun[sess.RunID] = append(sessionsByRun[sess.RunID], sess)
+		}
+	}
+
+	rows := make([]exportRow, 0, len(records))
+	for _, r := range records {
+		rows = append(rows, exportRow{record: r, title: titles[r.TaskID], sessions: sessionsByRun[r.RunID]})
+	}
+	return rows, nil
+}
+
+// JSON Linesに出力する作業セッション
+type exportSession struct {
+	ID        string      `json:"id"`
+	StartTime interface{} `json:"start_time"`
+	EndTime   interface{} `json:"end_time"`
+	Duration  interface{} `json:"duration"`
+}
+
+// 値の書式をまとめたもの
+type exportFormatter struct {
+	columns        []model.ExportColumn
+	durationFormat model.DurationFormat
+	loc            *time.Location
+}
+
+/*
+ * CSVで書き出す（1行目は項目名）
+ */
+func (f exportFormatter) writeCSV(w io.Writer, rows []exportRow, includeSessions bool) error {
+	cw := csv.NewWriter(w)
+
+	header := make([]string, 0, len(f.columns)+len(exportSessionColumns))
+	for _, c := range f.columns {
+		header = append(header, string(c))
+	}
+	if includeSessions {
+		header = append(header, exportSessionColumns...)
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		values := make([]string, 0, len(header))
+		for _, c := range f.columns {
+			values = append(values, f.text(f.recordValue(row, c)))
+		}
+
+		if !includeSessions {
+			if err := cw.Write(values); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// セッションがない場合もセッション項目を空にして1行出力する
+		if len(row.sessions) == 0 {
+			if err := cw.Write(append(values, "", "", "", "")); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, sess := range row.sessions {
+			line := append(append([]string{}, values...),
+				sess.ID.String(),
+				f.text(f.timeValue(&sess.StartTime)),
+				f.text(f.timeValue(sess.EndTime)),
+				f.text(f.sessionDuration(sess)),
+			)
+			if err := cw.Write(line); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+/*
+ * JSON Linesで書き出す（計測結果ごとに1行のオブジェクト、項目は指定した順）
+ */
+func (f exportFormatter) writeJSONL(w io.Writer, rows []exportRow, includeSessions bool) error {
+	for _, row := range rows {
+		var buf bytes.Buffer
+		buf.WriteByte('{')
+		for i, c := range f.columns {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSONField(&buf, string(c), f.recordValue(row, c)); err != nil {
+				return err
+			}
+		}
+
+		if includeSessions {
+			sessions := make([]exportSession, 0, len(row.sessions))
+			for _, sess := range row.sessions {
+				sessions = append(sessions, exportSession{
+					ID:        sess.ID.String(),
+					StartTime: f.timeValue(&sess.StartTime),
+					EndTime:   f.timeValue(sess.EndTime),
+					Duration:  f.sessionDuration(sess),
+				})
+			}
+			if len(f.columns) > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSONField(&buf, "sessions", sessions); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteString("}\n")
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+ * JSONのキーと値を書き出す
+ */
+func writeJSONField(buf *bytes.Buffer, key string, value interface{}) error {
+	k, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+	v, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	buf.Write(k)
+	buf.WriteByte(':')
+	buf.Write(v)
+	return nil
+}
+
+/*
+ * 計測結果の項目の値を取得する
+ */
+func (f exportFormatter) recordValue(row exportRow, c model.ExportColumn) interface{} {
+	r := row.record
+	switch c {
+	case model.ExportColumnID:
+		return r.ID.String()
+	case model.ExportColumnRunID:
+		return r.RunID.String()
+	case model.ExportColumnTaskID:
+		return r.TaskID.String()
+	case model.ExportColumnTaskTitle:
+		return row.title
+	case model.ExportColumnStartTime:
+		return f.timeValue(&r.StartTime)
+	case model.ExportColumnEndTime:
+		return f.timeValue(&r.EndTime)
+	case model.ExportColumnDuration:
+		return f.durationValue(r.Duration)
+	}
+	return nil
+}
+
+/*
+ * 時刻を指定のタイムゾーンのRFC3339文字列にする（未設定の場合はnil）
+ */
+func (f exportFormatter) timeValue(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.In(f.loc).Format(time.RFC3339)
+}
+
+/*
+ * 作業セッションの作業時間を取得する（計測中の場合はnil）
+ */
+func (f exportFormatter) sessionDuration(sess *model.WorkSession) interface{} {
+	if sess.EndTime == nil {
+		return nil
+	}
+	return f.durationValue(sess.EndTime.Sub(sess.StartTime))
+}
+
+/*
+ * 作業時間を指定の表記にする
+ * hmsは文字列、hoursは小数、nsは整数
+ */
+func (f exportFormatter) durationValue(d time.Duration) interface{} {
+	switch f.durationFormat {
+	case model.DurationFormatHours:
+		v, _ := strconv.ParseFloat(strconv.FormatFloat(d.Hours(), 'f', 2, 64), 64)
+		return v
+	case model.DurationFormatNanoseconds:
+		return d.Nanoseconds()
+	default:
+		return formatHMS(d)
+	}
+}
+
+/*
+ * 作業時間をhh:mm:ssにする（24時間を超える場合も時間で表す）
+ */
+func formatHMS(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	secs := int64(d.Round(time.Second) / time.Second)
+	return fmt.Sprintf("%s%02d:%02d:%02d", sign, secs/3600, secs/60%60, secs%60)
+}
+
+/*
+ * CSVに書き出す文字列にする
+ */
+func (f exportFormatter) text(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', 2, 64)
+	case int64:
+		return strconv.FormatInt(t, 10)
+	}
+	return fmt.Sprint(v)
+}
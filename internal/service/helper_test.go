@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"play-wails/infarstructure/db"
+	"play-wails/internal/model"
+	"play-wails/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+ * テスト用にインメモリDBを開き、マイグレーションを適用する
+ * テスト終了時に閉じる
+ */
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	d, err := db.Open(db.Config{Mode: db.ModeMemory})
+	if err != nil {
+		t.Fatalf("DBを開けません: %v", err)
+	}
+	t.Cleanup(func() { d.Close() })
+	if _, err := d.Migrate(context.Background()); err != nil {
+		t.Fatalf("マイグレーションに失敗しました: %v", err)
+	}
+	return d.DB()
+}
+
+/*
+ * テスト用のタスクを作成する
+ */
+func createTestTask(t *testing.T, repo repository.TaskRepository, title string) *model.Task {
+	t.Helper()
+	now := time.Now()
+	task := &model.Task{
+		ID:        uuid.New(),
+		Title:     title,
+		Status:    model.TaskStatusTodo,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := repo.Create(context.Background(), task); err != nil {
+		t.Fatalf("タスクを作成できません: %v", err)
+	}
+	return task
+}
+
+/*
+ * テスト用の計測結果を作成する（作業時間は開始〜終了）
+ */
+func createTestRecord(t *testing.T, repo repository.TimeRecordRepository, taskID uuid.UUID, start time.Time, end time.Time) *model.TimeRecord {
+	t.Helper()
+	record := &model.TimeRecord{
+		ID:        uuid.New(),
+		RunID:     uuid.New(),
+		TaskID:    taskID,
+		StartTime: start,
+		EndTime:   end,
+		Duration:  end.Sub(start),
+	}
+	if err := repo.Create(context.Background(), record); err != nil {
+		t.Fatalf("計測結果を作成できません: %v", err)
+	}
+	return record
+}
+
+/*
+ * タイムゾーンを読み込む（テスト用）
+ */
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("タイムゾーンを読み込めません: %v", err)
+	}
+	return loc
+}
@@ -0,0 +1,345 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"play-wails/internal/model"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 取り込み元の1行分の値（errは行単位の解析エラー）
+type importRow struct {
+	line        int
+	project     string
+	description string
+	task        string
+	start       time.Time
+	end         time.Time
+	err         error
+}
+
+// 形式ごとの列名（小文字で比較する）
+type importColumns struct {
+	project     string
+	description string
+	task        string
+	startDate   string
+	startTime   string
+	endDate     string
+	endTime     string
+	start       string
+	end         string
+}
+
+// Toggl・Clockifyの詳細レポートは日付と時刻が別の列
+var importColumnsByFormat = map[model.ImportFormat]importColumns{
+	model.ImportFormatToggl: {
+		project:     "project",
+		description: "description",
+		startDate:   "start date",
+		startTime:   "start time",
+		endDate:     "end date",
+		endTime:     "end time",
+	},
+	model.ImportFormatClockify: {
+		project:     "project",
+		description: "description",
+		startDate:   "start date",
+		startTime:   "start time",
+		endDate:     "end date",
+		endTime:     "end time",
+	},
+	model.ImportFormatCSV: {
+		task:        "task",
+		description: "description",
+		start:       "start",
+		end:         "end",
+	},
+}
+
+// 年が先頭の日付の書式（月と日の順序に関係なく解釈できる）
+var importYearFirstDateLayouts = []string{
+	"2006-1-2",
+	"2006/1/2",
+	"2006.1.2",
+}
+
+// 年が最後の日付の書式（Clockifyの既定は月/日/年、地域の設定により日/月/年や日.月.年）
+var importYearLastDateLayouts = map[model.ImportDateOrder][]string{
+	model.ImportDateOrderMDY: {"1/2/2006", "1-2-2006", "1.2.2006"},
+	model.ImportDateOrderDMY: {"2/1/2006", "2-1-2006", "2.1.2006"},
+}
+
+// 年が最後の日付（月と日の順序を判別する対象）
+var importYearLastDate = regexp.MustCompile(`^(\d{1,2})[/.\-](\d{1,2})[/.\-]\d{4}$`)
+
+// 時刻の書式
+var importTimeLayouts = []string{
+	"15:04:05",
+	"15:04",
+	"03:04:05 PM",
+	"3:04:05 PM",
+	"03:04 PM",
+	"3:04 PM",
+}
+
+// 日時の書式（オフセットなしの場合は指定のタイムゾーンで解釈する）
+var importDateTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+}
+
+/*
+ * CSVを解析して取り込み元の行一覧を取得する
+ * 必須の列がない場合やCSVとして読めない場合はエラー、行単位の不備は各行のerrに設定する
+ *
+ * @param r 読み込み元
+ * @param format ファイルの形式
+ * @param order 年が最後の日付の月と日の順序（空の場合はファイル全体から判別する）
+ * @param loc オフセットなしの日時を解釈するタイムゾーン
+ * @return 行一覧, エラー
+ */
+func parseImport(r io.Reader, format model.ImportFormat, order model.ImportDateOrder, loc *time.Location) ([]importRow, error) {
+	cols, ok := importColumnsByFormat[format]
+	if !ok {
+		return nil, fmt.Errorf("【ERROR】取り込み形式が不正です: %s（toggl / clockify / csv）", format)
+	}
+	switch order {
+	case model.ImportDateOrderAuto, model.ImportDateOrderMDY, model.ImportDateOrderDMY:
+	default:
+		return nil, fmt.Errorf("【ERROR】日付の順序が不正です: %s（mdy / dmy、空の場合は自動判別）", order)
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("【ERROR】ファイルが空です。")
+		}
+		return nil, err
+	}
+
+	// 見出しの位置を取得（先頭のBOMは除く）
+	index := make(map[string]int, len(header))
+	for i, h := range header {
+		if i == 0 {
+			h = strings.TrimPrefix(h, "\ufeff")
+		}
+		index[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	required := []string{cols.startDate, cols.startTime, cols.endDate, cols.endTime, cols.start, cols.end, cols.task}
+	if format != model.ImportFormatCSV {
+		required = append(required, cols.project, cols.description)
+	}
+	for _, name := range required {
+		if name == "" {
+			continue
+		}
+		if _, ok := index[name]; !ok {
+			return nil, fmt.Errorf("【ERROR】列「%s」が見つかりません。", name)
+		}
+	}
+
+	// 日付の順序を判別するため、先に全行を読み込む（開始日付・開始時刻・終了日付・終了時刻）
+	rows := make([]importRow, 0)
+	values := make([][4]string, 0)
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+
+		// 列の値を取得（存在しない列は空文字）
+		get := func(name string) string {
+			i, ok := index[name]
+			if name == "" || !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		// 空行は読み飛ばす
+		empty := true
+		for _, v := range record {
+			if strings.TrimSpace(v) != "" {
+				empty = false
+				break
+			}
+		}
+		if empty {
+			continue
+		}
+
+		rows = append(rows, importRow{
+			line:        line,
+			project:     get(cols.project),
+			description: get(cols.description),
+			task:        get(cols.task),
+		})
+		if format == model.ImportFormatCSV {
+			values = append(values, [4]string{get(cols.start), "", get(cols.end), ""})
+		} else {
+			values = append(values, [4]string{get(cols.startDate), get(cols.startTime), get(cols.endDate), get(cols.endTime)})
+		}
+	}
+
+	// 日付の月と日の順序は行ごとに推測せず、ファイル全体で1つに決める
+	if format != model.ImportFormatCSV && order == model.ImportDateOrderAuto {
+		dates := make([]string, 0, len(values)*2)
+		for _, v := range values {
+			dates = append(dates, v[0], v[2])
+		}
+		if order, err = detectImportDateOrder(dates); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range rows {
+		row, v := &rows[i], values[i]
+		if format == model.ImportFormatCSV {
+			row.start, row.err = parseImportDateTime(v[0], loc)
+			if row.err == nil {
+				row.end, row.err = parseImportDateTime(v[2], loc)
+			}
+		} else {
+			row.start, row.err = parseImportDateAndTime(v[0], v[1], order, loc)
+			if row.err == nil {
+				row.end, row.err = parseImportDateAndTime(v[2], v[3], order, loc)
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+/*
+ * 年が最後の日付の月と日の順序をファイル全体の日付から判別する
+ * 13以上の値が先頭にあれば日/月/年、2番目にあれば月/日/年とし、
+ * 両方ある場合や、月と日が異なるのにどちらも12以下の日付しかない場合は判別できないためエラーにする
+ *
+ * @param dates 日付の一覧
+ * @return 日付の順序（年が最後の日付がない場合、または月と日が同じ日付のみの場合は空）, エラー
+ */
+func detectImportDateOrder(dates []string) (model.ImportDateOrder, error) {
+	dayFirst, monthFirst, ambiguous := "", "", ""
+	for _, d := range dates {
+		m := importYearLastDate.FindStringSubmatch(d)
+		if m == nil {
+			continue
+		}
+		first, _ := strconv.Atoi(m[1])
+		second, _ := strconv.Atoi(m[2])
+		switch {
+		case first > 12 && dayFirst == "":
+			dayFirst = d
+		case second > 12 && monthFirst == "":
+			monthFirst = d
+		case first <= 12 && second <= 12 && first != second && ambiguous == "":
+			ambiguous = d
+		}
+	}
+
+	switch {
+	case dayFirst != "" && monthFirst != "":
+		return "", fmt.Errorf("【ERROR】日付の順序がファイル内で一致しません（%s は日/月/年、%s は月/日/年）。", dayFirst, monthFirst)
+	case dayFirst != "":
+		return model.ImportDateOrderDMY, nil
+	case monthFirst != "":
+		return model.ImportDateOrderMDY, nil
+	case ambiguous != "":
+		return "", fmt.Errorf("【ERROR】日付 %s が月/日/年か日/月/年か判別できません。日付の順序（mdy / dmy）を指定してください。", ambiguous)
+	}
+	return model.ImportDateOrderAuto, nil
+}
+
+/*
+ * 別々の列の日付と時刻を解析する
+ * 年が最後の日付は指定の順序でのみ解釈する
+ * （順序が決まっていない場合は、判別の結果、月と日が同じ日付しかないためどちらで解釈しても同じ）
+ */
+func parseImportDateAndTime(date string, clock string, order model.ImportDateOrder, loc *time.Location) (time.Time, error) {
+	if order == model.ImportDateOrderAuto {
+		order = model.ImportDateOrderMDY
+	}
+	layouts := append(importYearFirstDateLayouts[:len(importYearFirstDateLayouts):len(importYearFirstDateLayouts)], importYearLastDateLayouts[order]...)
+	for _, dl := range layouts {
+		for _, tl := range importTimeLayouts {
+			if t, err := time.ParseInLocation(dl+" "+tl, date+" "+clock, loc); err == nil {
+				return t, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("日時を解析できません: %s %s", date, clock)
+}
+
+/*
+ * 1つの列の日時を解析する
+ */
+func parseImportDateTime(value string, loc *time.Location) (time.Time, error) {
+	for _, l := range importDateTimeLayouts {
+		if t, err := time.ParseInLocation(l, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("日時を解析できません: %s", value)
+}
+
+/*
+ * 行のタスク名を取得する
+ */
+func (r importRow) taskTitle(mapping model.ImportTaskMapping) string {
+	if r.task != "" {
+		return r.task
+	}
+	switch mapping {
+	case model.ImportTaskMappingDescription:
+		if r.description != "" {
+			return r.description
+		}
+		return r.project
+	case model.ImportTaskMappingProjectDescription:
+		if r.project != "" && r.description != "" {
+			return r.project + " / " + r.description
+		}
+		return r.project + r.description
+	default:
+		if r.project != "" {
+			return r.project
+		}
+		return r.description
+	}
+}
+
+/*
+ * 再取り込みの判定に使う行のハッシュ
+ * 取り込み形式やタスクの割り当て方に依存しないよう、元の値と時刻（UTC）から作成する
+ */
+func (r importRow) fingerprint() string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		r.project,
+		r.description,
+		r.task,
+		r.start.UTC().Format(time.RFC3339Nano),
+		r.end.UTC().Format(time.RFC3339Nano),
+	}, "\x1f")))
+	return hex.EncodeToString(sum[:])
+}
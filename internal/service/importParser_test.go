@@ -0,0 +1,246 @@
+package service
+
+import (
+	"play-wails/internal/model"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseImport_Formats(t *testing.T) {
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+
+	tests := []struct {
+		name   string
+		format model.ImportFormat
+		input  string
+		order  model.ImportDateOrder
+		want   []importRow
+	}{
+		{
+			name:   "Toggl：BOM付き、年が先頭の日付",
+			format: model.ImportFormatToggl,
+			input: "\ufeffUser,Email,Client,Project,Task,Description,Billable,Start date,Start time,End date,End time,Duration\n" +
+				"me,me@example.com,Acme,Website,,デザイン,Yes,2026-10-01,09:00:00,2026-10-01,10:30:00,01:30:00\n",
+			want: []importRow{
+				{line: 2, project: "Website", description: "デザイン", start: time.Date(2026, 10, 1, 9, 0, 0, 0, tokyo), end: time.Date(2026, 10, 1, 10, 30, 0, 0, tokyo)},
+			},
+		},
+		{
+			name:   "Clockify：月/日/年とAM/PM、空行は読み飛ばす",
+			format: model.ImportFormatClockify,
+			input: "Project,Client,Description,Start Date,Start Time,End Date,End Time\n" +
+				"Website,Acme,レビュー,10/13/2026,11:30 PM,10/14/2026,12:15 AM\n" +
+				",,,,,,\n" +
+				"Internal,,,10/01/2026,09:00:00 AM,10/01/2026,01:00:00 PM\n",
+			want: []importRow{
+				{line: 2, project: "Website", description: "レビュー", start: time.Date(2026, 10, 13, 23, 30, 0, 0, tokyo), end: time.Date(2026, 10, 14, 0, 15, 0, 0, tokyo)},
+				{line: 4, project: "Internal", start: time.Date(2026, 10, 1, 9, 0, 0, 0, tokyo), end: time.Date(2026, 10, 1, 13, 0, 0, 0, tokyo)},
+			},
+		},
+		{
+			name:   "Clockify：日.月.年を指定",
+			format: model.ImportFormatClockify,
+			order:  model.ImportDateOrderDMY,
+			input: "Project,Description,Start Date,Start Time,End Date,End Time\n" +
+				"Website,,03.04.2026,09:00,03.04.2026,10:00\n",
+			want: []importRow{
+				{line: 2, project: "Website", start: time.Date(2026, 4, 3, 9, 0, 0, 0, tokyo), end: time.Date(2026, 4, 3, 10, 0, 0, 0, tokyo)},
+			},
+		},
+		{
+			name:   "CSV：オフセット付きとオフセットなし、列の順序は問わない",
+			format: model.ImportFormatCSV,
+			input: "End,Task,Start,Description\n" +
+				"2026-10-01T10:00:00Z,設計,2026-10-01T09:00:00Z,\n" +
+				"2026/10/02 18:00,設計,2026/10/02 17:00,打ち合わせ\n",
+			want: []importRow{
+				{line: 2, task: "設計", start: time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC), end: time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)},
+				{line: 3, task: "設計", description: "打ち合わせ", start: time.Date(2026, 10, 2, 17, 0, 0, 0, tokyo), end: time.Date(2026, 10, 2, 18, 0, 0, 0, tokyo)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := parseImport(strings.NewReader(tt.input), tt.format, tt.order, tokyo)
+			if err != nil {
+				t.Fatalf("parseImport() error = %v", err)
+			}
+			if len(rows) != len(tt.want) {
+				t.Fatalf("len(rows) = %d, want %d", len(rows), len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := rows[i]
+				if got.err != nil {
+					t.Errorf("rows[%d].err = %v", i, got.err)
+					continue
+				}
+				if got.line != want.line || got.project != want.project || got.description != want.description || got.task != want.task {
+					t.Errorf("rows[%d] = {%d %q %q %q}, want {%d %q %q %q}", i,
+						got.line, got.project, got.description, got.task, want.line, want.project, want.description, want.task)
+				}
+				if !got.start.Equal(want.start) || !got.end.Equal(want.end) {
+					t.Errorf("rows[%d] = %v〜%v, want %v〜%v", i, got.start, got.end, want.start, want.end)
+				}
+			}
+		})
+	}
+}
+
+func TestParseImport_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  model.ImportFormat
+		order   model.ImportDateOrder
+		input   string
+		wantErr string
+	}{
+		{name: "形式が不正", format: "harvest", input: "a\n", wantErr: "取り込み形式が不正です"},
+		{name: "日付の順序が不正", format: model.ImportFormatClockify, order: "ymd", input: "a\n", wantErr: "日付の順序が不正です"},
+		{name: "空のファイル", format: model.ImportFormatCSV, input: "", wantErr: "ファイルが空です"},
+		{name: "必須の列がない", format: model.ImportFormatCSV, input: "task,start\n", wantErr: "列「end」が見つかりません"},
+		{name: "Togglはプロジェクトの列が必須", format: model.ImportFormatToggl, input: "Description,Start date,Start time,End date,End time\n", wantErr: "列「project」が見つかりません"},
+		{
+			name:    "月と日の順序を判別できない",
+			format:  model.ImportFormatClockify,
+			input:   "Project,Description,Start Date,Start Time,End Date,End Time\nWebsite,,03/04/2026,09:00,03/04/2026,10:00\n",
+			wantErr: "判別できません",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseImport(strings.NewReader(tt.input), tt.format, tt.order, time.UTC)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseImport() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseImport_RowErrors(t *testing.T) {
+	input := "task,start,end\n" +
+		"設計,2026-10-01 09:00,2026-10-01 10:00\n" +
+		"設計,昨日,2026-10-01 10:00\n" +
+		"設計,2026-10-01 09:00,\n"
+
+	rows, err := parseImport(strings.NewReader(input), model.ImportFormatCSV, model.ImportDateOrderAuto, time.UTC)
+	if err != nil {
+		t.Fatalf("parseImport() error = %v", err)
+	}
+	wantErr := []bool{false, true, true}
+	if len(rows) != len(wantErr) {
+		t.Fatalf("len(rows) = %d, want %d", len(rows), len(wantErr))
+	}
+	for i, want := range wantErr {
+		if (rows[i].err != nil) != want {
+			t.Errorf("rows[%d].err = %v, want error %v", i, rows[i].err, want)
+		}
+	}
+}
+
+func TestDetectImportDateOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		dates   []string
+		want    model.ImportDateOrder
+		wantErr string
+	}{
+		{name: "日付なし", dates: nil, want: model.ImportDateOrderAuto},
+		{name: "年が先頭の日付のみ", dates: []string{"2026-10-01", "2026/10/13"}, want: model.ImportDateOrderAuto},
+		{name: "月と日が同じ日付のみ", dates: []string{"01/01/2026", "10.10.2026"}, want: model.ImportDateOrderAuto},
+		{name: "2番目が13以上なら月/日/年", dates: []string{"03/04/2026", "10/13/2026"}, want: model.ImportDateOrderMDY},
+		{name: "先頭が13以上なら日/月/年", dates: []string{"03.04.2026", "13.10.2026"}, want: model.ImportDateOrderDMY},
+		{name: "区切りが混在しても判別する", dates: []string{"3-4-2026", "31/12/2026"}, want: model.ImportDateOrderDMY},
+		{name: "両方の順序がある", dates: []string{"13/10/2026", "10/13/2026"}, wantErr: "一致しません"},
+		{name: "どちらも12以下", dates: []string{"2026-10-13", "03/04/2026"}, wantErr: "03/04/2026"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := detectImportDateOrder(tt.dates)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("detectImportDateOrder() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("detectImportDateOrder() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("detectImportDateOrder() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseImportDateAndTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		date    string
+		clock   string
+		order   model.ImportDateOrder
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "年が先頭", date: "2026-10-01", clock: "09:05", want: time.Date(2026, 10, 1, 9, 5, 0, 0, time.UTC)},
+		{name: "月/日/年", date: "03/04/2026", clock: "09:05:30", order: model.ImportDateOrderMDY, want: time.Date(2026, 3, 4, 9, 5, 30, 0, time.UTC)},
+		{name: "日/月/年", date: "03/04/2026", clock: "09:05:30", order: model.ImportDateOrderDMY, want: time.Date(2026, 4, 3, 9, 5, 30, 0, time.UTC)},
+		{name: "午前12時は0時", date: "2026-10-01", clock: "12:30 AM", want: time.Date(2026, 10, 1, 0, 30, 0, 0, time.UTC)},
+		{name: "午後", date: "2026-10-01", clock: "1:30:00 PM", want: time.Date(2026, 10, 1, 13, 30, 0, 0, time.UTC)},
+		{name: "日/月/年では13月はない", date: "10/13/2026", clock: "09:00", order: model.ImportDateOrderDMY, wantErr: true},
+		{name: "時刻が不正", date: "2026-10-01", clock: "25:00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseImportDateAndTime(tt.date, tt.clock, tt.order, time.UTC)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseImportDateAndTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseImportDateAndTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOverlapsAny(t *testing.T) {
+	base := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time {
+		return base.Add(time.Duration(minutes) * time.Minute)
+	}
+	intervals := []importInterval{
+		{start: at(0), end: at(60), line: 2},
+		{start: at(120), end: at(180), line: 3},
+	}
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		wantLine int
+	}{
+		{name: "前にある", start: at(-60), end: at(-30)},
+		{name: "終了が開始と接する", start: at(-60), end: at(0)},
+		{name: "開始が終了と接する", start: at(60), end: at(120)},
+		{name: "一部が重なる", start: at(30), end: at(90), wantLine: 2},
+		{name: "内側にある", start: at(130), end: at(140), wantLine: 3},
+		{name: "両方を含む場合は先の区間", start: at(-10), end: at(200), wantLine: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := overlapsAny(tt.start, tt.end, intervals)
+			line := 0
+			if got != nil {
+				line = got.line
+			}
+			if line != tt.wantLine {
+				t.Errorf("overlapsAny() line = %d, want %d", line, tt.wantLine)
+			}
+		})
+	}
+}
@@ -0,0 +1,347 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"play-wails/internal/model"
+	"play-wails/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ImportService struct {
+	tx       repository.Transactor
+	taskRepo repository.TaskRepository
+	runRepo  repository.RunRepository
+	wrepo    repository.WorkSessionRepository
+	trepo    repository.TimeRecordRepository
+	fprepo   repository.ImportFingerprintRepository
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param tx トランザクション
+ * @param taskRepo タスクリポジトリ
+ * @param runRepo 計測実行リポジトリ
+ * @param wrepo 作業セッションリポジトリ
+ * @param trepo 時間計測レコードリポジトリ
+ * @param fprepo 取り込み済みデータリポジトリ
+ * @return インスタンス
+ */
+func NewImportService(tx repository.Transactor, taskRepo repository.TaskRepository, runRepo repository.RunRepository, wrepo repository.WorkSessionRepository, trepo repository.TimeRecordRepository, fprepo repository.ImportFingerprintRepository) *ImportService {
+	return &ImportService{tx: tx, taskRepo: taskRepo, runRepo: runRepo, wrepo: wrepo, trepo: trepo, fprepo: fprepo}
+}
+
+// 1つのトランザクションで保存する行数
+// 長い履歴を取り込む場合に、1つのトランザクションが長時間になりすぎないよう分割する
+const importBatchSize = 200
+
+// 重なりの判定に使う区間
+type importInterval struct {
+	start time.Time
+	end   time.Time
+	line  int
+}
+
+// 取り込み予定の1件（エントリと作成に必要な値）
+type importPlan struct {
+	entry       *model.ImportEntry
+	row         importRow
+	taskID      uuid.UUID
+	fingerprint string
+}
+
+/*
+ * 取り込み内容を確認する（何も保存しない）
+ *
+ * @param ctx コンテキスト
+ * @param r 読み込み元
+ * @param opts 取り込みの条件
+ * @return 作成・スキップされる予定の内容, エラー
+ */
+func (s *ImportService) Preview(ctx context.Context, r io.Reader, opts model.ImportOptions) (*model.ImportResult, error) {
+	result, _, _, err := s.plan(ctx, r, opts)
+	if err != nil {
+		return nil, err
+	}
+	result.DryRun = true
+	return result, nil
+}
+
+/*
+ * 取り込みを実行する
+ * 行ごとに計測実行（完了）・作業セッション・計測結果を作成し、見つからないタスクは作成する
+ * importBatchSize 行ごとにトランザクションをコミットする
+ * 途中で失敗した場合もコミット済みの行は取り込み済みとして記録されるため、再実行すると続きから取り込む
+ *
+ * @param ctx コンテキスト
+ * @param r 読み込み元
+ * @param opts 取り込みの条件
+ * @return 取り込み結果, エラー
+ */
+func (s *ImportService) Import(ctx context.Context, r io.Reader, opts model.ImportOptions) (*model.ImportResult, error) {
+	ctx = repository.WithAuditReason(ctx, fmt.Sprintf("%s からの取り込み", opts.Format))
+	result, plans, newTasks, err := s.plan(ctx, r, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// タスクを先に作成（再実行時は同じタイトルの既存タスクとして見つかる）
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		for _, task := range newTasks {
+			if err := s.taskRepo.Create(ctx, task); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < len(plans); i += importBatchSize {
+		end := i + importBatchSize
+		if end > len(plans) {
+			end = len(plans)
+		}
+		batch := plans[i:end]
+		err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+			for _, p := range batch {
+				if err := s.importRow(ctx, p, opts); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("【ERROR】%d件を取り込んだところで失敗しました。再度取り込むと続きから取り込みます: %w", i, err)
+		}
+	}
+
+	return result, nil
+}
+
+/*
+ * 1行分の計測実行・作業セッション・計測結果・取り込み記録を作成する
+ *
+ * @param ctx コンテキスト
+ * @param p 取り込み予定の1件
+ * @param opts 取り込みの条件
+ * @return エラー
+ */
+func (s *ImportService) importRow(ctx context.Context, p importPlan, opts model.ImportOptions) error {
+	run := &model.Run{
+		ID:        uuid.New(),
+		TaskID:    p.taskID,
+		Status:    model.RunStatusCompleted,
+		CreatedAt: p.row.start,
+		UpdatedAt: p.row.end,
+	}
+	if err := s.runRepo.Create(ctx, run); err != nil {
+		return err
+	}
+
+	end := p.row.end
+	session := &model.WorkSession{
+		ID:        uuid.New(),
+		RunID:     run.ID,
+		TaskID:    p.taskID,
+		StartTime: p.row.start,
+		EndTime:   &end,
+	}
+	if err := s.wrepo.Create(ctx, session); err != nil {
+		return err
+	}
+
+	record := &model.TimeRecord{
+		ID:        uuid.New(),
+		RunID:     run.ID,
+		TaskID:    p.taskID,
+		StartTime: p.row.start,
+		EndTime:   p.row.end,
+		Duration:  p.row.end.Sub(p.row.start),
+	}
+	if err := s.trepo.Create(ctx, record); err != nil {
+		return err
+	}
+
+	if err := s.fprepo.Create(ctx, &model.ImportFingerprint{
+		ID:           p.fingerprint,
+		Source:       opts.Format,
+		TimeRecordID: record.ID,
+		CreatedAt:    time.Now(),
+	}); err != nil {
+		return err
+	}
+	p.entry.TimeRecordID = &record.ID
+	return nil
+}
+
+/*
+ * ファイルを解析し、行ごとに作成するかスキップするかを決める
+ *
+ * @param ctx コンテキスト
+ * @param r 読み込み元
+ * @param opts 取り込みの条件
+ * @return 取り込み結果, 作成する行, 作成するタスク（タスク名ごと）, エラー
+ */
+func (s *ImportService) plan(ctx context.Context, r io.Reader, opts model.ImportOptions) (*model.ImportResult, []importPlan, map[string]*model.Task, error) {
+	switch opts.TaskMapping {
+	case "":
+		opts.TaskMapping = model.ImportTaskMappingProject
+	case model.ImportTaskMappingProject, model.ImportTaskMappingDescription, model.ImportTaskMappingProjectDescription:
+	default:
+		return nil, nil, nil, fmt.Errorf("【ERROR】タスクの割り当て方が不正です: %s（project / description / project_description）", opts.TaskMapping)
+	}
+	loc, err := loadLocation(opts.TimeZone)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	rows, err := parseImport(r, opts.Format, opts.DateOrder, loc)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// 既存のタスク（同じタイトルが複数ある場合は先に見つかったもの）
+	tasks, err := s.taskRepo.List(ctx, true)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	taskIDs := make(map[string]uuid.UUID, len(tasks))
+	for _, t := range tasks {
+		if _, ok := taskIDs[t.Title]; !ok {
+			taskIDs[t.Title] = t.ID
+		}
+	}
+
+	// 取り込み済みの行と、重なりを判定する既存の作業セッション
+	fingerprints := make([]string, 0, len(rows))
+	var from, to time.Time
+	for _, row := range rows {
+		if row.err != nil || !row.end.After(row.start) {
+			continue
+		}
+		fingerprints = append(fingerprints, row.fingerprint())
+		if from.IsZero() || row.start.Before(from) {
+			from = row.start
+		}
+		if row.end.After(to) {
+			to = row.end
+		}
+	}
+	imported, err := s.fprepo.FindExisting(ctx, fingerprints)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	existing := make([]importInterval, 0)
+	if !opts.AllowOverlaps && len(fingerprints) > 0 {
+		sessions, err := s.wrepo.ListByRange(ctx, from, to)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		now := time.Now()
+		for _, sess := range sessions {
+			end := now
+			if sess.EndTime != nil {
+				end = *sess.EndTime
+			}
+			existing = append(existing, importInterval{start: sess.StartTime, end: end})
+		}
+	}
+
+	result := &model.ImportResult{Entries: make([]*model.ImportEntry, 0, len(rows))}
+	plans := make([]importPlan, 0, len(rows))
+	newTasks := map[string]*model.Task{}
+	seen := map[string]int{}
+	accepted := make([]importInterval, 0, len(rows))
+
+	for _, row := range rows {
+		e := &model.ImportEntry{Line: row.line, TaskTitle: row.taskTitle(opts.TaskMapping), Status: model.ImportEntrySkip}
+		result.Entries = append(result.Entries, e)
+
+		if row.err != nil {
+			e.Reason = row.err.Error()
+			continue
+		}
+		start, end := row.start, row.end
+		e.StartTime, e.EndTime = &start, &end
+		e.Duration = end.Sub(start)
+
+		fp := row.fingerprint()
+		switch {
+		case e.TaskTitle == "":
+			e.Reason = "タスク名がありません"
+		case !end.After(start):
+			e.Reason = "終了時刻が開始時刻以前です"
+		case imported[fp]:
+			e.Reason = "取り込み済みです"
+		case seen[fp] > 0:
+			e.Reason = fmt.Sprintf("%d行目と重複しています", seen[fp])
+		}
+		if e.Reason != "" {
+			continue
+		}
+		seen[fp] = row.line
+
+		if !opts.AllowOverlaps {
+			if overlapsAny(start, end, existing) != nil {
+				e.Reason = "既存の作業セッションと重なっています"
+				continue
+			}
+			if o := overlapsAny(start, end, accepted); o != nil {
+				e.Reason = fmt.Sprintf("%d行目と重なっています", o.line)
+				continue
+			}
+		}
+		accepted = append(accepted, importInterval{start: start, end: end, line: row.line})
+
+		// タスクがなければ作成する
+		taskID, ok := taskIDs[e.TaskTitle]
+		if !ok {
+			task, ok := newTasks[e.TaskTitle]
+			if !ok {
+				now := time.Now()
+				task = &model.Task{
+					ID:        uuid.New(),
+					Title:     e.TaskTitle,
+					Status:    model.TaskStatusTodo,
+					CreatedAt: now,
+					UpdatedAt: now,
+				}
+				newTasks[e.TaskTitle] = task
+			}
+			taskID = task.ID
+			e.NewTask = true
+		}
+
+		e.Status = model.ImportEntryCreate
+		plans = append(plans, importPlan{entry: e, row: row, taskID: taskID, fingerprint: fp})
+	}
+
+	for _, e := range result.Entries {
+		if e.Status == model.ImportEntryCreate {
+			result.Created++
+		} else {
+			result.Skipped++
+		}
+	}
+	result.TasksCreated = len(newTasks)
+
+	return result, plans, newTasks, nil
+}
+
+/*
+ * 区間と重なる最初の区間を取得する（なければnil）
+ */
+func overlapsAny(start time.Time, end time.Time, intervals []importInterval) *importInterval {
+	for i := range intervals {
+		if start.Before(intervals[i].end) && intervals[i].start.Before(end) {
+			return &intervals[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"play-wails/internal/model"
+	"play-wails/internal/repository"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestImportService_Preview(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+	taskRepo := repository.NewTaskRepositoryImpl(conn)
+	runRepo := repository.NewRunRepositoryImpl(conn)
+	wrepo := repository.NewWorkSessionRepositoryImpl(conn)
+	s := NewImportService(repository.NewTransactor(conn), taskRepo, runRepo, wrepo,
+		repository.NewTimeRecordRepositoryImpl(conn), repository.NewImportFingerprintRepositoryImpl(conn))
+
+	// 既存のタスクと作業セッション（10/1 09:00〜10:00）
+	task := createTestTask(t, taskRepo, "Website")
+	end := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+	run := &model.Run{ID: uuid.New(), TaskID: task.ID, Status: model.RunStatusCompleted, CreatedAt: end.Add(-time.Hour), UpdatedAt: end}
+	if err := runRepo.Create(ctx, run); err != nil {
+		t.Fatalf("計測実行を作成できません: %v", err)
+	}
+	if err := wrepo.Create(ctx, &model.WorkSession{ID: uuid.New(), RunID: run.ID, TaskID: task.ID, StartTime: end.Add(-time.Hour), EndTime: &end}); err != nil {
+		t.Fatalf("作業セッションを作成できません: %v", err)
+	}
+
+	input := "task,start,end\n" +
+		"Website,2026-10-01 09:30,2026-10-01 10:30\n" + // 2: 既存の作業セッションと重なる
+		"Website,2026-10-01 11:00,2026-10-01 12:00\n" + // 3
+		"Website,2026-10-01 11:00,2026-10-01 12:00\n" + // 4: 3行目と同じ
+		"Website,2026-10-01 11:30,2026-10-01 12:30\n" + // 5: 3行目と重なる
+		"New,2026-10-01 13:00,2026-10-01 14:00\n" + // 6: タスクを作成する
+		"Website,2026-10-01 15:00,2026-10-01 14:00\n" + // 7: 終了が開始より前
+		",2026-10-01 16:00,2026-10-01 17:00\n" + // 8: タスク名なし
+		"Website,昨日,2026-10-01 17:00\n" // 9: 日時が不正
+
+	type want struct {
+		status  model.ImportEntryStatus
+		reason  string
+		newTask bool
+	}
+	tests := []struct {
+		name          string
+		allowOverlaps bool
+		imported      bool
+		want          map[int]want
+	}{
+		{
+			name: "重なりと重複はスキップする",
+			want: map[int]want{
+				2: {status: model.ImportEntrySkip, reason: "既存の作業セッションと重なっています"},
+				3: {status: model.ImportEntryCreate},
+				4: {status: model.ImportEntrySkip, reason: "3行目と重複しています"},
+				5: {status: model.ImportEntrySkip, reason: "3行目と重なっています"},
+				6: {status: model.ImportEntryCreate, newTask: true},
+				7: {status: model.ImportEntrySkip, reason: "終了時刻が開始時刻以前です"},
+				8: {status: model.ImportEntrySkip, reason: "タスク名がありません"},
+				9: {status: model.ImportEntrySkip, reason: "日時を解析できません"},
+			},
+		},
+		{
+			name:          "重なりを許可する",
+			allowOverlaps: true,
+			want: map[int]want{
+				2: {status: model.ImportEntryCreate},
+				3: {status: model.ImportEntryCreate},
+				4: {status: model.ImportEntrySkip, reason: "3行目と重複しています"},
+				5: {status: model.ImportEntryCreate},
+				6: {status: model.ImportEntryCreate, newTask: true},
+			},
+		},
+		{
+			name:     "取り込み後は取り込み済みとしてスキップする",
+			imported: true,
+			want: map[int]want{
+				3: {status: model.ImportEntrySkip, reason: "取り込み済みです"},
+				4: {status: model.ImportEntrySkip, reason: "取り込み済みです"},
+				6: {status: model.ImportEntrySkip, reason: "取り込み済みです"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := model.ImportOptions{Format: model.ImportFormatCSV, TimeZone: "UTC", AllowOverlaps: tt.allowOverlaps}
+			if tt.imported {
+				if _, err := s.Import(ctx, strings.NewReader(input), opts); err != nil {
+					t.Fatalf("Import() error = %v", err)
+				}
+			}
+
+			result, err := s.Preview(ctx, strings.NewReader(input), opts)
+			if err != nil {
+				t.Fatalf("Preview() error = %v", err)
+			}
+			if !result.DryRun {
+				t.Error("DryRun = false, want true")
+			}
+			entries := map[int]*model.ImportEntry{}
+			for _, e := range result.Entries {
+				entries[e.Line] = e
+			}
+			for line, w := range tt.want {
+				e, ok := entries[line]
+				if !ok {
+					t.Errorf("%d行目がありません", line)
+					continue
+				}
+				if e.Status != w.status || !strings.HasPrefix(e.Reason, w.reason) || e.NewTask != w.newTask {
+					t.Errorf("%d行目 = {%s %q %v}, want {%s %q %v}", line, e.Status, e.Reason, e.NewTask, w.status, w.reason, w.newTask)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+)
+
+// PDFのページサイズ（A4、pt）
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 50
+)
+
+// 明細の行の高さと1ページの下端
+const (
+	pdfLineHeight = 20
+	pdfBottom     = 80
+)
+
+// 明細の列の右端（内容は左端）
+const (
+	pdfColDescription = pdfMargin
+	pdfColHours       = 340
+	pdfColRate        = 445
+	pdfColAmount      = pdfPageWidth - pdfMargin
+)
+
+// ページの内容
+type pdfPage struct {
+	buf bytes.Buffer
+}
+
+/*
+ * 文字列を書く
+ *
+ * @param x 左端
+ * @param y ベースライン
+ * @param size 文字サイズ
+ * @param s 文字列
+ */
+func (p *pdfPage) text(x, y, size float64, s string) {
+	fmt.Fprintf(&p.buf, "BT /F1 %.1f Tf %.2f %.2f Td <%s> Tj ET\n", size, x, y, pdfHexString(s))
+}
+
+/*
+ * 右揃えで文字列を書く
+ *
+ * @param right 右端
+ * @param y ベースライン
+ * @param size 文字サイズ
+ * @param s 文字列
+ */
+func (p *pdfPage) textRight(right, y, size float64, s string) {
+	p.text(right-pdfTextWidth(s, size), y, size, s)
+}
+
+/*
+ * 横線を引く
+ */
+func (p *pdfPage) line(x1, y, x2, width float64) {
+	fmt.Fprintf(&p.buf, "%.2f w %.2f %.2f m %.2f %.2f l S\n", width, x1, y, x2, y)
+}
+
+/*
+ * 請求書をPDFで書き出す
+ * 日本語を表示するため、PDFビューアが持つ標準の日本語フォント（HeiseiKakuGo-W5）を埋め込まずに参照する
+ *
+ * @param w 出力先
+ * @param view 請求書の表示内容
+ * @return エラー
+ */
+func writeInvoicePDF(w io.Writer, view *invoiceView) error {
+	invoice := view.Invoice
+	var pages []*pdfPage
+
+	newPage := func() (*pdfPage, float64) {
+		page := &pdfPage{}
+		pages = append(pages, page)
+		y := float64(pdfPageHeight - pdfMargin)
+		page.textRight(pdfColAmount, y, 10, "請求書番号: "+invoice.Number)
+		page.textRight(pdfColAmount, y-14, 10, "発行日: "+view.IssuedOn)
+		return page, y
+	}
+	header := func(page *pdfPage, y float64) float64 {
+		page.text(pdfColDescription, y, 10, "内容")
+		page.textRight(pdfColHours, y, 10, "時間")
+		page.textRight(pdfColRate, y, 10, "単価")
+		page.textRight(pdfColAmount, y, 10, "金額")
+		page.line(pdfMargin, y-6, pdfColAmount, 0.8)
+		return y - pdfLineHeight
+	}
+
+	page, y := newPage()
+	y -= 50
+	page.text(pdfMargin, y, 24, "請求書")
+	if view.Void {
+		page.text(pdfMargin+110, y, 16, "取消済み（"+view.VoidedOn+"）")
+	}
+	y -= 45
+	page.text(pdfMargin, y, 14, invoice.ClientName+" 御中")
+	page.line(pdfMargin, y-6, pdfMargin+260, 0.8)
+	y -= 28
+	page.text(pdfMargin, y, 10, "対象期間: "+view.PeriodFrom+" 〜 "+view.PeriodTo)
+	y -= 24
+	page.text(pdfMargin, y, 12, "ご請求金額: "+formatMoney(invoice.Total, invoice.Currency))
+	y -= 36
+	y = header(page, y)
+
+	for _, line := range invoice.Lines {
+		if y < pdfBottom {
+			page, y = newPage()
+			y = header(page, y-40)
+		}
+		page.text(pdfColDescription, y, 10, pdfTruncate(line.Description, pdfColHours-pdfColDescription-70, 10))
+		page.textRight(pdfColHours, y, 10, formatHours(line.BilledDuration))
+		page.textRight(pdfColRate, y, 10, formatMoney(line.HourlyRate, invoice.Currency))
+		page.textRight(pdfColAmount, y, 10, formatMoney(line.Amount, invoice.Currency))
+		page.line(pdfMargin, y-6, pdfColAmount, 0.3)
+		y -= pdfLineHeight
+	}
+
+	if y < pdfBottom {
+		page, y = newPage()
+		y -= 40
+	}
+	page.text(pdfColDescription, y, 11, "合計")
+	page.textRight(pdfColHours, y, 11, formatHours(view.TotalDuration))
+	page.textRight(pdfColAmount, y, 11, formatMoney(invoice.Total, invoice.Currency))
+	page.line(pdfMargin, y-6, pdfColAmount, 1.2)
+
+	return writePDF(w, pages)
+}
+
+/*
+ * ページをPDF 1.4として書き出す
+ * オブジェクト番号は 1:カタログ 2:ページツリー 3〜5:フォント 6以降:ページと内容
+ */
+func writePDF(w io.Writer, pages []*pdfPage) error {
+	var objects []string
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+i*2)
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		"<< /Type /Font /Subtype /Type0 /BaseFont /HeiseiKakuGo-W5 /Encoding /UniJIS-UCS2-HW-H /DescendantFonts [4 0 R] >>",
+		"<< /Type /Font /Subtype /CIDFontType0 /BaseFont /HeiseiKakuGo-W5 /CIDSystemInfo << /Registry (Adobe) /Ordering (Japan1) /Supplement 2 >> /FontDescriptor 5 0 R /DW 1000 /W [231 389 500 631 631 500] >>",
+		"<< /Type /FontDescriptor /FontName /HeiseiKakuGo-W5 /Flags 4 /FontBBox [-92 -250 1010 922] /ItalicAngle 0 /Ascent 880 /Descent -120 /CapHeight 737 /StemV 114 >>",
+	)
+	for i, page := range pages {
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", pdfPageWidth, pdfPageHeight, 7+i*2),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.buf.Len(), page.buf.String()),
+		)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+/*
+ * 文字列をUTF-16BEの16進文字列にする（UniJIS-UCS2-HW-Hで表示するため）
+ */
+func pdfHexString(s string) string {
+	var b strings.Builder
+	for _, c := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&b, "%04X", c)
+	}
+	return b.String()
+}
+
+/*
+ * 文字列の幅（ASCIIは半角、それ以外は全角として計算する）
+ */
+func pdfTextWidth(s string, size float64) float64 {
+	width := 0.0
+	for _, c := range s {
+		if c < 0x80 {
+			width += 0.5
+		} else {
+			width += 1
+		}
+	}
+	return width * size
+}
+
+/*
+ * 幅に収まらない文字列を省略する
+ */
+func pdfTruncate(s string, width, size float64) string {
+	if pdfTextWidth(s, size) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && pdfTextWidth(string(runes)+"…", size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"io"
+	"play-wails/internal/model"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 補助単位のない通貨（金額をそのまま表示する）
+var zeroDecimalCurrencies = map[string]bool{
+	"JPY": true, "KRW": true, "VND": true, "CLP": true, "ISK": true,
+}
+
+// 請求書のHTML
+var invoiceHTML = template.Must(template.New("invoice").Funcs(template.FuncMap{
+	"money": formatMoney,
+	"hours": formatHours,
+}).Parse(`<!DOCTYPE html>
+<html lang="ja">
+<head>
+<meta charset="utf-8">
+<title>請求書 {{.Invoice.Number}}</title>
+<style>
+body { font-family: sans-serif; margin: 40px; color: #222; }
+h1 { font-size: 28px; letter-spacing: 0.5em; }
+.meta { float: right; text-align: right; }
+.client { font-size: 18px; margin: 32px 0 8px; }
+table { width: 100%; border-collapse: collapse; margin-top: 24px; }
+th, td { border-bottom: 1px solid #999; padding: 6px 8px; }
+th { background: #eee; text-align: left; }
+td.num, th.num { text-align: right; }
+.total td { font-weight: bold; border-bottom: 2px solid #222; }
+.void { color: #c00; font-size: 20px; font-weight: bold; }
+</style>
+</head>
+<body>
+<div class="meta">
+<div>請求書番号: {{.Invoice.Number}}</div>
+<div>発行日: {{.IssuedOn}}</div>
+</div>
+<h1>請求書</h1>
+{{if .Void}}<div class="void">取消済み（{{.VoidedOn}}）</div>{{end}}
+<div class="client">{{.Invoice.ClientName}} 御中</div>
+<div>対象期間: {{.PeriodFrom}} 〜 {{.PeriodTo}}</div>
+<table>
+<thead>
+<tr><th>No.</th><th>内容</th><th class="num">時間</th><th class="num">単価</th><th class="num">金額</th></tr>
+</thead>
+<tbody>
+{{range .Invoice.Lines}}<tr><td>{{.LineNo}}</td><td>{{.Description}}</td><td class="num">{{hours .BilledDuration}}</td><td class="num">{{money .HourlyRate $.Invoice.Currency}}</td><td class="num">{{money .Amount $.Invoice.Currency}}</td></tr>
+{{end}}<tr class="total"><td></td><td>合計</td><td class="num">{{hours .TotalDuration}}</td><td></td><td class="num">{{money .Invoice.Total .Invoice.Currency}}</td></tr>
+</tbody>
+</table>
+</body>
+</html>
+`))
+
+// 請求書の表示内容（日付は請求書のタイムゾーン）
+type invoiceView struct {
+	Invoice       *model.Invoice
+	IssuedOn      string
+	PeriodFrom    string
+	PeriodTo      string
+	Void          bool
+	VoidedOn      string
+	TotalDuration time.Duration
+}
+
+/*
+ * 請求書をHTMLで書き出す
+ *
+ * @param ctx コンテキスト
+ * @param w 出力先
+ * @param id 請求書ID
+ * @return 請求書, エラー
+ */
+func (s *InvoiceService) RenderHTML(ctx context.Context, w io.Writer, id uuid.UUID) (*model.Invoice, error) {
+	invoice, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	view, err := newInvoiceView(invoice)
+	if err != nil {
+		return nil, err
+	}
+	if err := invoiceHTML.Execute(w, view); err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+/*
+ * 請求書をPDFで書き出す
+ *
+ * @param ctx コンテキスト
+ * @param w 出力先
+ * @param id 請求書ID
+ * @return 請求書, エラー
+ */
+func (s *InvoiceService) RenderPDF(ctx context.Context, w io.Writer, id uuid.UUID) (*model.Invoice, error) {
+	invoice, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	view, err := newInvoiceView(invoice)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeInvoicePDF(w, view); err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+/*
+ * 請求書の表示内容を作成する
+ */
+func newInvoiceView(invoice *model.Invoice) (*invoiceView, error) {
+	loc, err := loadLocation(invoice.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	view := &invoiceView{
+		Invoice:    invoice,
+		IssuedOn:   invoice.IssuedAt.In(loc).Format("2006-01-02"),
+		PeriodFrom: invoice.PeriodFrom.In(loc).Format("2006-01-02"),
+		// 期間の終了は含まないため前日までを表示
+		PeriodTo: invoice.PeriodTo.Add(-time.Nanosecond).In(loc).Format("2006-01-02"),
+		Void:     invoice.Status == model.InvoiceStatusVoid,
+	}
+	if invoice.VoidedAt != nil {
+		view.VoidedOn = invoice.VoidedAt.In(loc).Format("2006-01-02")
+	}
+	for _, line := range invoice.Lines {
+		view.TotalDuration += line.BilledDuration
+	}
+	return view, nil
+}
+
+/*
+ * 金額を通貨に合わせて表示する（12,000 JPY・1,234.56 USD）
+ *
+ * @param amount 通貨の最小単位の金額
+ * @param currency 通貨
+ * @return 表示する文字列
+ */
+func formatMoney(amount int64, currency string) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	if zeroDecimalCurrencies[currency] {
+		return sign + groupThousands(amount) + " " + currency
+	}
+	return fmt.Sprintf("%s%s.%02d %s", sign, groupThousands(amount/100), amount%100, currency)
+}
+
+/*
+ * 3桁ごとにカンマで区切る
+ */
+func groupThousands(n int64) string {
+	s := fmt.Sprintf("%d", n)
+	var b strings.Builder
+	for i, c := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+/*
+ * 請求時間を時間単位で表示する（1.25 h）
+ */
+func formatHours(d time.Duration) string {
+	return fmt.Sprintf("%.2f h", d.Hours())
+}
@@ -0,0 +1,352 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"play-wails/internal/model"
+	"play-wails/internal/repository"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 請求書番号の接頭辞（INV-発行年-連番）
+const invoiceNumberPrefix = "INV"
+
+/*
+ * InvoiceService は未請求の計測結果から請求書を発行し、計測結果を請求済みとしてロックする
+ * 請求書を取り消すとロックを解除する（請求書番号は再利用しない）
+ */
+type InvoiceService struct {
+	tx          repository.Transactor
+	repo        repository.InvoiceRepository
+	trepo       repository.TimeRecordRepository
+	clientRepo  repository.ClientRepository
+	projectRepo repository.ProjectRepository
+	billing     *BillingService
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param tx トランザクション
+ * @param repo 請求書リポジトリ
+ * @param trepo 時間計測レコードリポジトリ
+ * @param clientRepo クライアントリポジトリ
+ * @param projectRepo プロジェクトリポジトリ
+ * @param billing 請求サービス
+ * @return インスタンス
+ */
+func NewInvoiceService(tx repository.Transactor, repo repository.InvoiceRepository, trepo repository.TimeRecordRepository, clientRepo repository.ClientRepository, projectRepo repository.ProjectRepository, billing *BillingService) *InvoiceService {
+	return &InvoiceService{tx: tx, repo: repo, trepo: trepo, clientRepo: clientRepo, projectRepo: projectRepo, billing: billing}
+}
+
+/*
+ * 期間内の未請求の計測結果から請求書を発行する
+ * 明細はタスク・単価ごとにまとめ、対象の計測結果は請求済みとして編集できなくする
+ * 単価が未設定の計測結果がある場合や、複数の通貨が混在し通貨の指定がない場合は発行しない
+ *
+ * @param ctx コンテキスト
+ * @param in 発行条件
+ * @return 請求書, エラー
+ */
+func (s *InvoiceService) Issue(ctx context.Context, in model.InvoiceInput) (*model.Invoice, error) {
+	var invoice *model.Invoice
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		client, err := s.clientRepo.FindByID(ctx, in.ClientID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.New("【ERROR】指定されたクライアントが存在しません。")
+			}
+			return err
+		}
+
+		summary, err := s.billing.Billable(ctx, BillingQuery{
+			From:           in.From,
+			To:             in.To,
+			TimeZone:       in.TimeZone,
+			ClientID:       &client.ID,
+			UninvoicedOnly: true,
+			Rounding:       in.Rounding,
+		})
+		if err != nil {
+			return err
+		}
+
+		// 単価が未設定の計測結果は請求できない（請求時間が0のものは除く）
+		rated := make([]model.BillableItem, 0, len(summary.Items))
+		for _, item := range summary.Items {
+			if item.RateID == nil {
+				if item.BilledDuration > 0 {
+					return errors.New("【ERROR】時間単価が未設定の計測結果があります。単価を設定するか、請求対象外にしてください。")
+				}
+				continue
+			}
+			rated = append(rated, item)
+		}
+
+		items, currency, err := selectCurrency(rated, in.Currency)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return errors.New("【ERROR】期間内に未請求の計測結果がありません。")
+		}
+
+		invoice, err = s.newInvoice(ctx, client, summary, items, currency)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.Create(ctx, invoice); err != nil {
+			return err
+		}
+
+		// 計測結果を請求済みにする
+		ctx = repository.WithAuditReason(ctx, fmt.Sprintf("請求書 %s の発行", invoice.Number))
+		for _, item := range items {
+			for _, id := range item.TimeRecordIDs {
+				record, err := s.trepo.FindByID(ctx, id)
+				if err != nil {
+					return err
+				}
+				// 期間外の部分は請求されないまま請求済みになるため、期間をまたぐ計測結果は発行しない
+				if record.StartTime.Before(in.From) || record.EndTime.After(in.To) {
+					return fmt.Errorf("【ERROR】期間の境界をまたぐ計測結果があります（%s〜%s）。期間を調整するか、計測結果を分割してから発行してください。", record.StartTime.Format(time.RFC3339), record.EndTime.Format(time.RFC3339))
+				}
+				record.InvoiceID = &invoice.ID
+				if err := s.trepo.Update(ctx, record); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+/*
+ * 請求書を取り消し、計測結果のロックを解除する
+ *
+ * @param ctx コンテキスト
+ * @param id 請求書ID
+ * @param reason 変更理由（省略可）
+ * @return 請求書, エラー
+ */
+func (s *InvoiceService) Void(ctx context.Context, id uuid.UUID, reason string) (*model.Invoice, error) {
+	var invoice *model.Invoice
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		invoice, err = s.find(ctx, id)
+		if err != nil {
+			return err
+		}
+		if invoice.Status == model.InvoiceStatusVoid {
+			return errors.New("【ERROR】請求書は既に取り消されています。")
+		}
+
+		now := time.Now()
+		invoice.Status = model.InvoiceStatusVoid
+		invoice.VoidedAt = &now
+		invoice.UpdatedAt = now
+		if err := s.repo.UpdateStatus(ctx, invoice); err != nil {
+			return err
+		}
+
+		// 計測結果のロックを解除
+		ctx = repository.WithAuditReason(ctx, reason)
+		ctx = repository.WithAuditReason(ctx, fmt.Sprintf("請求書 %s の取消", invoice.Number))
+		records, err := s.trepo.ListByInvoiceID(ctx, invoice.ID)
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			record.InvoiceID = nil
+			if err := s.trepo.Update(ctx, record); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+/*
+ * 請求書を明細付きで取得する
+ *
+ * @param ctx コンテキスト
+ * @param id 請求書ID
+ * @return 請求書, エラー
+ */
+func (s *InvoiceService) Get(ctx context.Context, id uuid.UUID) (*model.Invoice, error) {
+	return s.find(ctx, id)
+}
+
+/*
+ * 請求書の一覧を取得する（発行日時の新しい順、明細は含まない）
+ *
+ * @param ctx コンテキスト
+ * @param clientID 絞り込むクライアントID（nilの場合は全件）
+ * @return 請求書一覧, エラー
+ */
+func (s *InvoiceService) List(ctx context.Context, clientID *uuid.UUID) ([]*model.Invoice, error) {
+	return s.repo.List(ctx, clientID)
+}
+
+/*
+ * 請求書を取得する
+ *
+ * @param ctx コンテキスト
+ * @param id 請求書ID
+ * @return 請求書, エラー
+ */
+func (s *InvoiceService) find(ctx context.Context, id uuid.UUID) (*model.Invoice, error) {
+	invoice, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("【ERROR】請求書が見つかりません。")
+		}
+		return nil, err
+	}
+	return invoice, nil
+}
+
+/*
+ * 請求明細をまとめて請求書を作成する（保存はしない）
+ *
+ * @param ctx コンテキスト
+ * @param client クライアント
+ * @param summary 請求額の計算結果
+ * @param items 請求書に含める請求明細
+ * @param currency 通貨
+ * @return 請求書, エラー
+ */
+func (s *InvoiceService) newInvoice(ctx context.Context, client *model.Client, summary *model.BillableSummary, items []model.BillableItem, currency string) (*model.Invoice, error) {
+	projects, err := s.projectRepo.List(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	projectNames := make(map[uuid.UUID]string, len(projects))
+	for _, p := range projects {
+		projectNames[p.ID] = p.Name
+	}
+
+	now := time.Now()
+	number, err := s.nextNumber(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	invoice := &model.Invoice{
+		ID:         uuid.New(),
+		Number:     number,
+		ClientID:   client.ID,
+		ClientName: client.Name,
+		PeriodFrom: summary.From,
+		PeriodTo:   summary.To,
+		TimeZone:   summary.TimeZone,
+		Currency:   currency,
+		Rounding:   summary.Rounding,
+		Status:     model.InvoiceStatusIssued,
+		IssuedAt:   now,
+		Lines:      make([]model.InvoiceLine, 0),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	// タスク・単価ごとにまとめる
+	lines := map[string]*model.InvoiceLine{}
+	order := make([]string, 0)
+	for _, item := range items {
+		key := item.TaskID.String() + "/" + item.RateID.String()
+		line, ok := lines[key]
+		if !ok {
+			description := item.TaskTitle
+			if item.ProjectID != nil && projectNames[*item.ProjectID] != "" {
+				description = projectNames[*item.ProjectID] + " / " + item.TaskTitle
+			}
+			line = &model.InvoiceLine{
+				ID:          uuid.New(),
+				InvoiceID:   invoice.ID,
+				TaskID:      item.TaskID,
+				Description: description,
+				HourlyRate:  item.HourlyRate,
+			}
+			lines[key] = line
+			order = append(order, key)
+		}
+		line.BilledDuration += item.BilledDuration
+		line.Amount += item.Amount
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return lines[order[i]].Description < lines[order[j]].Description
+	})
+	for i, key := range order {
+		line := lines[key]
+		line.LineNo = i + 1
+		invoice.Total += line.Amount
+		invoice.Lines = append(invoice.Lines, *line)
+	}
+	return invoice, nil
+}
+
+/*
+ * 発行年の次の請求書番号を取得する（INV-2026-0001）
+ *
+ * @param ctx コンテキスト
+ * @param issuedAt 発行日時
+ * @return 請求書番号, エラー
+ */
+func (s *InvoiceService) nextNumber(ctx context.Context, issuedAt time.Time) (string, error) {
+	prefix := fmt.Sprintf("%s-%04d-", invoiceNumberPrefix, issuedAt.Year())
+	last, err := s.repo.LastNumber(ctx, prefix)
+	if err != nil {
+		return "", err
+	}
+	seq := 0
+	if last != "" {
+		seq, err = strconv.Atoi(strings.TrimPrefix(last, prefix))
+		if err != nil {
+			return "", fmt.Errorf("【ERROR】請求書番号が不正です: %s", last)
+		}
+	}
+	return fmt.Sprintf("%s%04d", prefix, seq+1), nil
+}
+
+/*
+ * 請求明細を1つの通貨に絞り込む
+ * 通貨を指定しない場合は請求明細の通貨が1つであること
+ *
+ * @param items 請求明細
+ * @param currency 通貨（空の場合は請求明細の通貨）
+ * @return 絞り込んだ請求明細, 通貨, エラー
+ */
+func selectCurrency(items []model.BillableItem, currency string) ([]model.BillableItem, string, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		for _, item := range items {
+			if currency != "" && item.Currency != currency {
+				return nil, "", errors.New("【ERROR】複数の通貨の計測結果があります。通貨を指定してください。")
+			}
+			currency = item.Currency
+		}
+		return items, currency, nil
+	}
+
+	list := make([]model.BillableItem, 0, len(items))
+	for _, item := range items {
+		if item.Currency == currency {
+			list = append(list, item)
+		}
+	}
+	return list, currency, nil
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"play-wails/internal/model"
+	"play-wails/internal/repository"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+ * 作業セッションの時間の重なりの扱い
+ */
+type OverlapPolicy string
+
+const (
+	// 重なりを許可する（一覧で確認のみ）
+	OverlapPolicyAllow OverlapPolicy = "allow"
+	// 開始・再開・手入力・編集で他の作業セッションと重なる場合は拒否する
+	OverlapPolicyReject OverlapPolicy = "reject"
+)
+
+/*
+ * 設定値から重なりの扱いを取得する（未指定の場合は許可）
+ *
+ * @param value 設定値
+ * @return 重なりの扱い, エラー
+ */
+func ParseOverlapPolicy(value string) (OverlapPolicy, error) {
+	switch p := OverlapPolicy(strings.ToLower(strings.TrimSpace(value))); p {
+	case "":
+		return OverlapPolicyAllow, nil
+	case OverlapPolicyAllow, OverlapPolicyReject:
+		return p, nil
+	default:
+		return "", fmt.Errorf("【ERROR】重なりの扱いが不正です: %s（allow / reject）", value)
+	}
+}
+
+// 計測中の作業セッションの終了時刻として扱う時刻（終わりのない区間）
+var openSessionEnd = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+/*
+ * OverlapService は作業セッションの時間の重なりを検出する
+ * 取消済みの計測実行と、論理削除済みの計測結果の作業セッションは対象外
+ */
+type OverlapService struct {
+	wrepo    repository.WorkSessionRepository
+	taskRepo repository.TaskRepository
+	policy   OverlapPolicy
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param wrepo 作業セッションリポジトリ
+ * @param taskRepo タスクリポジトリ
+ * @param policy 重なりの扱い
+ * @return インスタンス
+ */
+func NewOverlapService(wrepo repository.WorkSessionRepository, taskRepo repository.TaskRepository, policy OverlapPolicy) *OverlapService {
+	return &OverlapService{wrepo: wrepo, taskRepo: taskRepo, policy: policy}
+}
+
+/*
+ * 期間内の作業セッションの重なりを全て取得する（過去データの確認・修正用）
+ *
+ * @param ctx コンテキスト
+ * @param from 期間の開始（含む）
+ * @param to 期間の終了（含まない）
+ * @return 重なり一覧（重なりの開始時刻順）, エラー
+ */
+func (s *OverlapService) Find(ctx context.Context, from time.Time, to time.Time) ([]*model.SessionOverlap, error) {
+	if !from.Before(to) {
+		return nil, errors.New("【ERROR】期間の開始は終了より前にしてください。")
+	}
+
+	sessions, err := s.wrepo.ListByRange(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	sortSessions(sessions)
+	titles, err := s.taskTitles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 開始時刻順に走査し、まだ終わっていない作業セッションとだけ比較する
+	now := time.Now()
+	list := make([]*model.SessionOverlap, 0)
+	active := make([]*model.WorkSession, 0)
+	for _, sess := range sessions {
+		kept := active[:0]
+		for _, a := range active {
+			if sessionEnd(a).After(sess.StartTime) {
+				kept = append(kept, a)
+			}
+		}
+		active = kept
+
+		for _, a := range active {
+			list = append(list, newSessionOverlap(a, sess, titles, now))
+		}
+		active = append(active, sess)
+	}
+
+	return list, nil
+}
+
+/*
+ * 作業セッションと重なる他の作業セッションを取得する
+ *
+ * @param ctx コンテキスト
+ * @param sess 確認する作業セッション（保存前のものでもよい）
+ * @param ignore 確認から除く作業セッションID
+ * @return 重なり一覧, エラー
+ */
+func (s *OverlapService) Check(ctx context.Context, sess *model.WorkSession, ignore ...uuid.UUID) ([]*model.SessionOverlap, error) {
+	others, err := s.wrepo.ListByRange(ctx, sess.StartTime, sessionEnd(sess))
+	if err != nil {
+		return nil, err
+	}
+	titles, err := s.taskTitles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	list := make([]*model.SessionOverlap, 0)
+	for _, o := range others {
+		if o.ID == sess.ID || containsUUID(ignore, o.ID) {
+			continue
+		}
+		if sess.StartTime.Before(sessionEnd(o)) && o.StartTime.Before(sessionEnd(sess)) {
+			if o.StartTime.After(sess.StartTime) {
+				list = append(list, newSessionOverlap(sess, o, titles, now))
+			} else {
+				list = append(list, newSessionOverlap(o, sess, titles, now))
+			}
+		}
+	}
+	return list, nil
+}
+
+/*
+ * 重なりを拒否する設定の場合、作業セッションが他と重なっていればエラーを返す
+ *
+ * @param ctx コンテキスト
+ * @param sess 確認する作業セッション（保存前のものでもよい）
+ * @param ignore 確認から除く作業セッションID
+ * @return エラー（重なる場合は*model.ValidationError）
+ */
+func (s *OverlapService) Enforce(ctx context.Context, sess *model.WorkSession, ignore ...uuid.UUID) error {
+	if s == nil || s.policy != OverlapPolicyReject {
+		return nil
+	}
+
+	overlaps, err := s.Check(ctx, sess, ignore...)
+	if err != nil {
+		return err
+	}
+
+	v := model.NewValidationError("【ERROR】他の作業セッションと時間が重なっています。")
+	for _, o := range overlaps {
+		title := o.FirstTaskTitle
+		if o.FirstSessionID == sess.ID {
+			title = o.SecondTaskTitle
+		}
+		v.Add("start_time", fmt.Sprintf("タスク「%s」の作業セッションと%s〜%sが重なっています",
+			title, formatLocalTime(o.Start), formatLocalTime(o.End)))
+	}
+	return v.Err()
+}
+
+/*
+ * タスクIDからタイトルを引くための一覧
+ */
+func (s *OverlapService) taskTitles(ctx context.Context) (map[uuid.UUID]string, error) {
+	tasks, err := s.taskRepo.List(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	titles := make(map[uuid.UUID]string, len(tasks))
+	for _, t := range tasks {
+		titles[t.ID] = t.Title
+	}
+	return titles, nil
+}
+
+/*
+ * 作業セッションの終了時刻（計測中の場合は終わりのない区間として扱う）
+ */
+func sessionEnd(sess *model.WorkSession) time.Time {
+	if sess.EndTime == nil {
+		return openSessionEnd
+	}
+	return *sess.EndTime
+}
+
+/*
+ * 2つの作業セッションの重なりを作成する（firstは開始時刻が早い方）
+ */
+func newSessionOverlap(first *model.WorkSession, second *model.WorkSession, titles map[uuid.UUID]string, now time.Time) *model.SessionOverlap {
+	start := second.StartTime
+	end := sessionEnd(first)
+	if sessionEnd(second).Before(end) {
+		end = sessionEnd(second)
+	}
+	// 計測中どうしの重なりは現在時刻までとする
+	if end.After(now) {
+		end = now
+	}
+	if end.Before(start) {
+		end = start
+	}
+
+	return &model.SessionOverlap{
+		FirstSessionID:  first.ID,
+		FirstRunID:      first.RunID,
+		FirstTaskID:     first.TaskID,
+		FirstTaskTitle:  titles[first.TaskID],
+		SecondSessionID: second.ID,
+		SecondRunID:     second.RunID,
+		SecondTaskID:    second.TaskID,
+		SecondTaskTitle: titles[second.TaskID],
+		SameRun:         first.RunID == second.RunID,
+		Start:           start,
+		End:             end,
+		Duration:        end.Sub(start),
+	}
+}
@@ -0,0 +1,391 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"play-wails/internal/model"
+	"play-wails/internal/repository"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ポモドーロの各フェーズの長さの上限
+const maxPomodoroPhase = 24 * time.Hour
+
+// タイマーによる1回のフェーズの切り替え（作業セッションの停止・再開）のタイムアウト
+const pomodoroTickTimeout = 10 * time.Second
+
+/*
+ * フェーズが変わったときに呼ばれる通知先（フロントへのイベント送信など）
+ */
+type PomodoroNotifier func(state model.PomodoroState)
+
+/*
+ * PomodoroService は作業セッションの計測実行の上でポモドーロのタイマーを動かす
+ * 作業の終了時刻に作業セッションを停止し、休憩の終了後に同じ RunID で計測を再開する
+ * ウィンドウが非表示でも時刻がずれないよう、タイマーはバックエンドで壁時計の時刻と比較する
+ */
+type PomodoroService struct {
+	sessions *WorkSessionService
+	notify   PomodoroNotifier
+
+	// フェーズの切り替えの排他（作業セッションの停止・再開の間も保持する）
+	opMu sync.Mutex
+
+	// 設定・状態の排他（DBの呼び出し中は保持しないため、状態の取得は待たされない）
+	// 状態の変更は opMu と mu の両方を取得して行う
+	mu       sync.Mutex
+	settings model.PomodoroSettings
+	state    model.PomodoroState
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param sessions 作業セッションサービス
+ * @param notify フェーズが変わったときの通知先（nilの場合は通知しない）
+ * @return インスタンス
+ */
+func NewPomodoroService(sessions *WorkSessionService, notify PomodoroNotifier) *PomodoroService {
+	settings := model.DefaultPomodoroSettings()
+	return &PomodoroService{
+		sessions: sessions,
+		notify:   notify,
+		settings: settings,
+		state:    model.PomodoroState{Phase: model.PomodoroPhaseIdle, Settings: settings},
+	}
+}
+
+/*
+ * 設定を取得する
+ *
+ * @return 設定
+ */
+func (s *PomodoroService) Settings() model.PomodoroSettings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.settings
+}
+
+/*
+ * 設定を変更する
+ * 実行中の場合は次のフェーズから適用する
+ *
+ * @param settings 設定
+ * @return 設定, エラー
+ */
+func (s *PomodoroService) SetSettings(settings model.PomodoroSettings) (model.PomodoroSettings, error) {
+	v := model.NewValidationError("【ERROR】ポモドーロの設定に誤りがあります。")
+	validLength := func(d time.Duration) bool {
+		return d > 0 && d <= maxPomodoroPhase
+	}
+	if !validLength(settings.Work) {
+		v.Add("work", "0より長く、24時間以内で指定してください")
+	}
+	if !validLength(settings.ShortBreak) {
+		v.Add("short_break", "0より長く、24時間以内で指定してください")
+	}
+	if !validLength(settings.LongBreak) {
+		v.Add("long_break", "0より長く、24時間以内で指定してください")
+	}
+	if settings.CyclesPerLongBreak < 1 {
+		v.Add("cycles_per_long_break", "1以上で指定してください")
+	}
+	if err := v.Err(); err != nil {
+		return model.PomodoroSettings{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.settings = settings
+	return settings, nil
+}
+
+/*
+ * 現在の状態を取得する
+ *
+ * @return 状態
+ */
+func (s *PomodoroService) State() model.PomodoroState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
+
+/*
+ * ポモドーロを開始する（作業から始める）
+ * runIDを指定した場合は一時停止中の計測実行を再開し、省略した場合は新規に計測を開始する
+ *
+ * @param ctx コンテキスト
+ * @param taskID タスクID
+ * @param runID 再開する計測実行のグループID（nilの場合は新規）
+ * @return 状態, エラー
+ */
+func (s *PomodoroService) Start(ctx context.Context, taskID uuid.UUID, runID *uuid.UUID) (model.PomodoroState, error) {
+	s.opMu.Lock()
+	defer s.opMu.Unlock()
+
+	if s.state.Phase != model.PomodoroPhaseIdle {
+		return s.State(), errors.New("【ERROR】ポモドーロは既に実行中です。")
+	}
+
+	var session *model.WorkSession
+	var err error
+	if runID != nil {
+		session, err = s.sessions.Resume(ctx, taskID, *runID)
+	} else {
+		session, err = s.sessions.Start(ctx, taskID)
+	}
+	if err != nil {
+		return s.State(), err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = model.PomodoroState{
+		TaskID:   &session.TaskID,
+		RunID:    &session.RunID,
+		Settings: s.settings,
+	}
+	s.enterWork(session)
+	return s.state, nil
+}
+
+/*
+ * ポモドーロを終了する
+ * 作業中の場合は作業セッションを停止する（計測実行は一時停止のまま残るため、完了・再開できる）
+ *
+ * @param ctx コンテキスト
+ * @return 状態, エラー
+ */
+func (s *PomodoroService) Stop(ctx context.Context) (model.PomodoroState, error) {
+	s.opMu.Lock()
+	defer s.opMu.Unlock()
+
+	if s.state.Phase == model.PomodoroPhaseIdle {
+		return s.State(), errors.New("【ERROR】ポモドーロは実行されていません。")
+	}
+
+	if s.state.Phase == model.PomodoroPhaseWork {
+		running, err := s.isSessionRunning(ctx)
+		if err != nil {
+			return s.State(), err
+		}
+		if running {
+			if err := s.sessions.Stop(ctx, *s.state.SessionID); err != nil {
+				return s.State(), err
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.finish("")
+	return s.state, nil
+}
+
+/*
+ * 現在のフェーズを終了し、次のフェーズに進む
+ *
+ * @param ctx コンテキスト
+ * @return 状態, エラー
+ */
+func (s *PomodoroService) Skip(ctx context.Context) (model.PomodoroState, error) {
+	s.opMu.Lock()
+	defer s.opMu.Unlock()
+
+	if s.state.Phase == model.PomodoroPhaseIdle {
+		return s.State(), errors.New("【ERROR】ポモドーロは実行されていません。")
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	s.state.PhaseEndsAt = &now
+	s.mu.Unlock()
+
+	err := s.advance(ctx, now)
+	return s.State(), err
+}
+
+/*
+ * 終了時刻を過ぎたフェーズを進める
+ * スリープなどで複数のフェーズを過ぎていた場合は、作業の停止は本来の終了時刻で行う
+ *
+ * @param ctx コンテキスト
+ * @return 状態
+ */
+func (s *PomodoroService) Tick(ctx context.Context) model.PomodoroState {
+	s.opMu.Lock()
+	defer s.opMu.Unlock()
+
+	if err := s.advance(ctx, time.Now()); err != nil && !errors.Is(ctx.Err(), context.Canceled) {
+		log.Printf("【WARN】ポモドーロのフェーズの切り替えに失敗しました: %v", err)
+	}
+	return s.State()
+}
+
+/*
+ * タイマーを開始する
+ *
+ * @param ctx コンテキスト
+ * @param interval 終了時刻を確認する間隔
+ */
+func (s *PomodoroService) StartTimer(ctx context.Context, interval time.Duration) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				tctx, cancel := context.WithTimeout(ctx, pomodoroTickTimeout)
+				s.Tick(tctx)
+				cancel()
+			}
+		}
+	}()
+}
+
+/*
+ * アプリ終了時の処理
+ * タイマーを停止する（計測中の作業セッションは通常の計測と同様に扱う）
+ */
+func (s *PomodoroService) Shutdown() {
+	if s.cancel != nil {
+		s.cancel()
+		<-s.done
+	}
+}
+
+/*
+ * 終了時刻を過ぎたフェーズを進める（opMuを取得して呼び出す）
+ * 自動の停止・再開に失敗した場合はポモドーロを終了する
+ * （アプリ終了によるキャンセルやタイムアウトの場合は終了せず、次の確認で再試行する）
+ */
+func (s *PomodoroService) advance(ctx context.Context, now time.Time) error {
+	for s.state.Phase != model.PomodoroPhaseIdle && !now.Before(*s.state.PhaseEndsAt) {
+		var err error
+		if s.state.Phase == model.PomodoroPhaseWork {
+			err = s.endWork(repository.WithAuditReason(ctx, "ポモドーロの作業終了"))
+		} else {
+			err = s.endBreak(repository.WithAuditReason(ctx, "ポモドーロの休憩終了"))
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				s.mu.Lock()
+				s.finish(err.Error())
+				s.mu.Unlock()
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+ * 作業を終了し、作業セッションを停止して休憩に入る
+ */
+func (s *PomodoroService) endWork(ctx context.Context) error {
+	running, err := s.isSessionRunning(ctx)
+	if err != nil {
+		return err
+	}
+	if !running {
+		return errors.New("【ERROR】作業セッションが停止されたため、ポモドーロを終了しました。")
+	}
+
+	endedAt := *s.state.PhaseEndsAt
+	if err := s.sessions.StopAt(ctx, *s.state.SessionID, endedAt); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.CompletedCycles++
+	phase := model.PomodoroPhaseShortBreak
+	if s.state.CompletedCycles%s.settings.CyclesPerLongBreak == 0 {
+		phase = model.PomodoroPhaseLongBreak
+	}
+	s.enterPhase(phase, endedAt, nil)
+	return nil
+}
+
+/*
+ * 休憩を終了し、同じ RunID で計測を再開して作業に入る
+ */
+func (s *PomodoroService) endBreak(ctx context.Context) error {
+	session, err := s.sessions.Resume(ctx, *s.state.TaskID, *s.state.RunID)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.enterWork(session)
+	return nil
+}
+
+/*
+ * 作業セッションの開始時刻から作業に入る（muを取得して呼び出す）
+ */
+func (s *PomodoroService) enterWork(session *model.WorkSession) {
+	s.enterPhase(model.PomodoroPhaseWork, session.StartTime, &session.ID)
+}
+
+/*
+ * フェーズを切り替えて通知する（muを取得して呼び出す）
+ */
+func (s *PomodoroService) enterPhase(phase model.PomodoroPhase, startedAt time.Time, sessionID *uuid.UUID) {
+	s.state.Settings = s.settings
+	endsAt := startedAt.Add(s.settings.Length(phase))
+	s.state.Phase = phase
+	s.state.SessionID = sessionID
+	s.state.PhaseStartedAt = &startedAt
+	s.state.PhaseEndsAt = &endsAt
+	s.state.Error = ""
+	s.emit()
+}
+
+/*
+ * ポモドーロを終了して通知する（muを取得して呼び出す、計測実行の情報は最後の状態として残す）
+ */
+func (s *PomodoroService) finish(reason string) {
+	s.state.Phase = model.PomodoroPhaseIdle
+	s.state.SessionID = nil
+	s.state.PhaseStartedAt = nil
+	s.state.PhaseEndsAt = nil
+	s.state.Error = reason
+	s.emit()
+}
+
+/*
+ * 作業中の作業セッションが計測中のままか判定する（手動で停止された場合はfalse、opMuを取得して呼び出す）
+ */
+func (s *PomodoroService) isSessionRunning(ctx context.Context) (bool, error) {
+	session, err := s.sessions.Current(ctx, *s.state.SessionID)
+	if err != nil {
+		return false, err
+	}
+	return session.IsRunning(), nil
+}
+
+/*
+ * 現在の状態を通知する
+ */
+func (s *PomodoroService) emit() {
+	if s.notify != nil {
+		s.notify(s.state)
+	}
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"play-wails/internal/model"
+	"play-wails/internal/repository"
+	"testing"
+	"time"
+)
+
+/*
+ * テスト用のポモドーロサービスとタスクを作成する
+ */
+func newTestPomodoroService(t *testing.T) (*PomodoroService, *model.Task) {
+	t.Helper()
+	conn := openTestDB(t)
+	taskRepo := repository.NewTaskRepositoryImpl(conn)
+	wrepo := repository.NewWorkSessionRepositoryImpl(conn)
+	sessions := NewWorkSessionService(repository.NewTransactor(conn), wrepo, repository.NewTimeRecordRepositoryImpl(conn), taskRepo,
+		repository.NewRunRepositoryImpl(conn), RunningPolicyReject, NewOverlapService(wrepo, taskRepo, OverlapPolicyReject))
+	return NewPomodoroService(sessions, nil), createTestTask(t, taskRepo, "設計")
+}
+
+func TestPomodoroService_Skip(t *testing.T) {
+	ctx := context.Background()
+	s, task := newTestPomodoroService(t)
+	if _, err := s.SetSettings(model.PomodoroSettings{Work: time.Hour, ShortBreak: time.Hour, LongBreak: time.Hour, CyclesPerLongBreak: 2}); err != nil {
+		t.Fatalf("SetSettings() error = %v", err)
+	}
+
+	state, err := s.Start(ctx, task.ID, nil)
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	runID := *state.RunID
+
+	// 作業 → 短い休憩 → 作業 → 長い休憩
+	want := []struct {
+		phase  model.PomodoroPhase
+		cycles int
+	}{
+		{model.PomodoroPhaseShortBreak, 1},
+		{model.PomodoroPhaseWork, 1},
+		{model.PomodoroPhaseLongBreak, 2},
+		{model.PomodoroPhaseWork, 2},
+	}
+	for i, w := range want {
+		state, err = s.Skip(ctx)
+		if err != nil {
+			t.Fatalf("Skip() #%d error = %v", i, err)
+		}
+		if state.Phase != w.phase || state.CompletedCycles != w.cycles {
+			t.Errorf("Skip() #%d = {%s %d}, want {%s %d}", i, state.Phase, state.CompletedCycles, w.phase, w.cycles)
+		}
+		if *state.RunID != runID {
+			t.Errorf("Skip() #%d RunID = %s, want %s", i, *state.RunID, runID)
+		}
+	}
+
+	state, err = s.Stop(ctx)
+	if err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	if state.Phase != model.PomodoroPhaseIdle {
+		t.Errorf("Stop() Phase = %s, want %s", state.Phase, model.PomodoroPhaseIdle)
+	}
+}
+
+func TestPomodoroService_StateDuringTransition(t *testing.T) {
+	s, task := newTestPomodoroService(t)
+	if _, err := s.Start(context.Background(), task.ID, nil); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	// フェーズの切り替え中（作業セッションの停止・再開の最中）でも状態は取得できる
+	s.opMu.Lock()
+	defer s.opMu.Unlock()
+
+	got := make(chan model.PomodoroState, 1)
+	go func() { got <- s.State() }()
+	select {
+	case state := <-got:
+		if state.Phase != model.PomodoroPhaseWork {
+			t.Errorf("State() Phase = %s, want %s", state.Phase, model.PomodoroPhaseWork)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("State() がフェーズの切り替えを待っています")
+	}
+}
+
+func TestPomodoroService_TickTimeout(t *testing.T) {
+	s, task := newTestPomodoroService(t)
+	if _, err := s.SetSettings(model.PomodoroSettings{Work: time.Millisecond, ShortBreak: time.Hour, LongBreak: time.Hour, CyclesPerLongBreak: 4}); err != nil {
+		t.Fatalf("SetSettings() error = %v", err)
+	}
+	if _, err := s.Start(context.Background(), task.ID, nil); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	// タイムアウトした場合はポモドーロを終了せず、次の確認で再試行する
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	if state := s.Tick(ctx); state.Phase != model.PomodoroPhaseWork || state.Error != "" {
+		t.Errorf("Tick() = {%s %q}, want {%s \"\"}", state.Phase, state.Error, model.PomodoroPhaseWork)
+	}
+
+	if state := s.Tick(context.Background()); state.Phase != model.PomodoroPhaseShortBreak {
+		t.Errorf("Tick() Phase = %s, want %s", state.Phase, model.PomodoroPhaseShortBreak)
+	}
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"play-wails/internal/model"
+	"play-wails/internal/repository"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProjectService struct {
+	repo       repository.ProjectRepository
+	clientRepo repository.ClientRepository
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param repo プロジェクトリポジトリ
+ * @param clientRepo クライアントリポジトリ
+ * @return インスタンス
+ */
+func NewProjectService(repo repository.ProjectRepository, clientRepo repository.ClientRepository) *ProjectService {
+	return &ProjectService{repo: repo, clientRepo: clientRepo}
+}
+
+/*
+ * プロジェクトを新規作成する
+ *
+ * @param ctx コンテキスト
+ * @param name 名前
+ * @param clientID クライアントID（nilの場合はクライアントなし）
+ * @return プロジェクト, エラー
+ */
+func (s *ProjectService) Create(ctx context.Context, name string, clientID *uuid.UUID) (*model.Project, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("【ERROR】プロジェクト名を入力してください。")
+	}
+	if err := s.validateClient(ctx, clientID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	project := &model.Project{
+		ID:        uuid.New(),
+		ClientID:  clientID,
+		Name:      name,
+		Archived:  false,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.repo.Create(ctx, project); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+/*
+ * 指定IDのプロジェクトを取得する
+ *
+ * @param ctx コンテキスト
+ * @param id プロジェクトID
+ * @return プロジェクト, エラー
+ */
+func (s *ProjectService) Get(ctx context.Context, id uuid.UUID) (*model.Project, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+/*
+ * プロジェクト一覧を取得する
+ *
+ * @param ctx コンテキスト
+ * @param includeArchived true のときアーカイブ済みも含める
+ * @return プロジェクト一覧, エラー
+ */
+func (s *ProjectService) List(ctx context.Context, includeArchived bool) ([]*model.Project, error) {
+	return s.repo.List(ctx, includeArchived)
+}
+
+/*
+ * プロジェクトの名前・クライアントを更新する
+ *
+ * @param ctx コンテキスト
+ * @param project プロジェクト
+ * @return 更新後のプロジェクト, エラー
+ */
+func (s *ProjectService) Update(ctx context.Context, project *model.Project) (*model.Project, error) {
+	current, err := s.repo.FindByID(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 入力チェック
+	name := strings.TrimSpace(project.Name)
+	if name == "" {
+		return nil, errors.New("【ERROR】プロジェクト名を入力してください。")
+	}
+	if err := s.validateClient(ctx, project.ClientID); err != nil {
+		return nil, err
+	}
+
+	current.Name = name
+	current.ClientID = project.ClientID
+	current.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, current); err != nil {
+		return nil, err
+	}
+
+	return current, nil
+}
+
+/*
+ * プロジェクトのアーカイブ状態を切り替える
+ * アーカイブ済みのプロジェクトのタスクは計測の開始対象に表示されず、計測を開始できない
+ * 計測結果はそのまま残る
+ *
+ * @param ctx コンテキスト
+ * @param id プロジェクトID
+ * @param archived アーカイブするか
+ * @return エラー
+ */
+func (s *ProjectService) SetArchived(ctx context.Context, id uuid.UUID, archived bool) error {
+	project, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	project.Archived = archived
+	project.UpdatedAt = time.Now()
+
+	return s.repo.Update(ctx, project)
+}
+
+/*
+ * プロジェクトを削除する
+ * タスクから参照されている場合は削除できない
+ *
+ * @param ctx コンテキスト
+ * @param id プロジェクトID
+ * @return エラー
+ */
+func (s *ProjectService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.repo.Delete(ctx, id)
+}
+
+/*
+ * 指定されたクライアントが存在するか検証する（nilの場合は何もしない）
+ *
+ * @param ctx コンテキスト
+ * @param clientID クライアントID
+ * @return エラー
+ */
+func (s *ProjectService) validateClient(ctx context.Context, clientID *uuid.UUID) error {
+	if clientID == nil {
+		return nil
+	}
+	if _, err := s.clientRepo.FindByID(ctx, *clientID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("【ERROR】指定されたクライアントが存在しません。")
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"play-wails/internal/model"
+	"play-wails/internal/repository"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+ * 起動時に見つかった停止漏れセッションの扱い
+ */
+type RecoveryMode string
+
+const (
+	// ユーザーに確認する（自動では停止しない）
+	RecoveryModePrompt RecoveryMode = "prompt"
+	// 最後のハートビート時刻で停止する
+	RecoveryModeHeartbeat RecoveryMode = "heartbeat"
+	// 前回のアプリ終了時刻で停止する（記録がなければハートビート時刻）
+	RecoveryModeShutdown RecoveryMode = "shutdown"
+)
+
+/*
+ * 停止漏れセッションに対してユーザーが選択する処理
+ */
+type RecoveryAction string
+
+const (
+	RecoveryActionHeartbeat RecoveryAction = "heartbeat"
+	RecoveryActionShutdown  RecoveryAction = "shutdown"
+	RecoveryActionNow       RecoveryAction = "now"
+	RecoveryActionContinue  RecoveryAction = "continue"
+)
+
+/*
+ * 設定値から復旧モードを取得する（未指定の場合は確認）
+ *
+ * @param value 設定値
+ * @return 復旧モード, エラー
+ */
+func ParseRecoveryMode(value string) (RecoveryMode, error) {
+	switch m := RecoveryMode(strings.ToLower(strings.TrimSpace(value))); m {
+	case "":
+		return RecoveryModePrompt, nil
+	case RecoveryModePrompt, RecoveryModeHeartbeat, RecoveryModeShutdown:
+		return m, nil
+	default:
+		return "", fmt.Errorf("【ERROR】復旧モードが不正です: %s（prompt / heartbeat / shutdown）", value)
+	}
+}
+
+/*
+ * RecoveryService はアプリの異常終了で停止されなかった作業セッションを復旧する
+ * 計測中はハートビートを定期的に保存し、起動時にそれを元に停止時刻を決める
+ */
+type RecoveryService struct {
+	wrepo     repository.WorkSessionRepository
+	stateRepo repository.AppStateRepository
+	sessions  *WorkSessionService
+	mode      RecoveryMode
+	startedAt time.Time
+
+	// ユーザーの確認待ちの停止漏れセッション
+	mu      sync.Mutex
+	orphans map[uuid.UUID]bool
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param wrepo 作業セッションリポジトリ
+ * @param stateRepo アプリ状態リポジトリ
+ * @param sessions 作業セッションサービス
+ * @param mode 復旧モード
+ * @return インスタンス
+ */
+func NewRecoveryService(wrepo repository.WorkSessionRepository, stateRepo repository.AppStateRepository, sessions *WorkSessionService, mode RecoveryMode) *RecoveryService {
+	return &RecoveryService{
+		wrepo:     wrepo,
+		stateRepo: stateRepo,
+		sessions:  sessions,
+		mode:      mode,
+		startedAt: time.Now(),
+		orphans:   map[uuid.UUID]bool{},
+	}
+}
+
+/*
+ * 起動時の復旧処理
+ * 今回の起動より前から計測中のままのセッションを停止漏れとして検出し、
+ * 復旧モードに従って停止する。確認モードの場合は停止せずに返す
+ *
+ * @param ctx コンテキスト
+ * @return 確認待ちの停止漏れセッション一覧, エラー
+ */
+func (s *RecoveryService) Recover(ctx context.Context) ([]*model.WorkSession, error) {
+	running, err := s.wrepo.FindRunning(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sess := range running {
+		if !s.isOrphan(sess) {
+			continue
+		}
+
+		switch s.mode {
+		case RecoveryModeHeartbeat:
+			err = s.Resolve(ctx, sess.ID, RecoveryActionHeartbeat)
+		case RecoveryModeShutdown:
+			err = s.Resolve(ctx, sess.ID, RecoveryActionShutdown)
+		default:
+			s.mu.Lock()
+			s.orphans[sess.ID] = true
+			s.mu.Unlock()
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.Orphans(ctx)
+}
+
+/*
+ * 今回の起動より前から計測中のままか判定する
+ */
+func (s *RecoveryService) isOrphan(sess *model.WorkSession) bool {
+	return sess.IsRunning() && sess.LastSeen().Before(s.startedAt)
+}
+
+/*
+ * 確認待ちの停止漏れセッション一覧を取得する
+ *
+ * @param ctx コンテキスト
+ * @return 作業セッション一覧, エラー
+ */
+func (s *RecoveryService) Orphans(ctx context.Context) ([]*model.WorkSession, error) {
+	running, err := s.wrepo.FindRunning(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]*model.WorkSession, 0)
+	for _, sess := range running {
+		if s.orphans[sess.ID] {
+			list = append(list, sess)
+		}
+	}
+	return list, nil
+}
+
+/*
+ * 停止漏れセッションを指定した方法で処理する
+ * - heartbeat: 最後のハートビート時刻で停止
+ * - shutdown: 前回のアプリ終了時刻で停止（記録がなければハートビート時刻）
+ * - now: 現在時刻で停止
+ * - continue: 計測を継続
+ *
+ * @param ctx コンテキスト
+ * @param sessionID 作業セッションID
+ * @param action 処理
+ * @return エラー
+ */
+func (s *RecoveryService) Resolve(ctx context.Context, sessionID uuid.UUID, action RecoveryAction) error {
+	ctx = repository.WithAuditReason(ctx, fmt.Sprintf("停止漏れの復旧（%s）", action))
+	sess, err := s.wrepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if !sess.IsRunning() {
+		return errors.New("【ERROR】作業セッションは既に停止されています。")
+	}
+
+	var stopAt time.Time
+	switch action {
+	case RecoveryActionHeartbeat:
+		stopAt = sess.LastSeen()
+	case RecoveryActionShutdown:
+		stopAt = sess.LastSeen()
+		shutdownAt, err := s.stateRepo.GetTime(ctx, repository.AppStateLastShutdownAt)
+		if err != nil {
+			return err
+		}
+		if shutdownAt != nil && shutdownAt.After(sess.StartTime) {
+			stopAt = *shutdownAt
+		}
+	case RecoveryActionNow:
+		stopAt = time.Now()
+	case RecoveryActionContinue:
+		// 確認待ちから外し、以降はハートビートの対象にする
+		updated, err := s.wrepo.UpdateHeartbeat(ctx, sessionID, time.Now())
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return errors.New("【ERROR】作業セッションは既に停止されています。")
+		}
+		s.forget(sessionID)
+		return nil
+	default:
+		return fmt.Errorf("【ERROR】復旧方法が不正です: %s", action)
+	}
+
+	if err := s.sessions.StopAt(ctx, sessionID, stopAt); err != nil {
+		return err
+	}
+	s.forget(sessionID)
+	return nil
+}
+
+func (s *RecoveryService) forget(sessionID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.orphans, sessionID)
+}
+
+/*
+ * 計測中のセッションにハートビートを保存する（確認待ちのものは除く）
+ *
+ * @param ctx コンテキスト
+ * @return エラー
+ */
+func (s *RecoveryService) Heartbeat(ctx context.Context) error {
+	running, err := s.wrepo.FindRunning(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, sess := range running {
+		s.mu.Lock()
+		orphan := s.orphans[sess.ID]
+		s.mu.Unlock()
+		if orphan {
+			continue
+		}
+
+		// 読み取り後に停止されたセッションは更新しない
+		if _, err := s.wrepo.UpdateHeartbeat(ctx, sess.ID, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+ * ハートビートの定期保存を開始する
+ *
+ * @param ctx コンテキスト
+ * @param interval 保存間隔
+ */
+func (s *RecoveryService) StartHeartbeat(ctx context.Context, interval time.Duration) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				hctx, cancel := context.WithTimeout(ctx, interval)
+				if err := s.Heartbeat(hctx); err != nil && ctx.Err() == nil {
+					log.Printf("【WARN】ハートビートの保存に失敗しました: %v", err)
+				}
+				cancel()
+			}
+		}
+	}()
+}
+
+/*
+ * アプリ終了時の処理
+ * ハートビートを停止し、最後のハートビートと終了時刻を保存する
+ *
+ * @param ctx コンテキスト
+ * @return エラー
+ */
+func (s *RecoveryService) Shutdown(ctx context.Context) error {
+	if s.cancel != nil {
+		s.cancel()
+		<-s.done
+	}
+
+	if err := s.Heartbeat(ctx); err != nil {
+		return err
+	}
+	return s.stateRepo.SetTime(ctx, repository.AppStateLastShutdownAt, time.Now())
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"play-wails/internal/model"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+ * 期間内の計測結果をクライアント → プロジェクト → タスクの階層ごとに合計する
+ * 期間をまたぐ計測結果はReportと同様に期間内の部分のみを集計する
+ * アーカイブ済みのクライアント・プロジェクト・タスクの計測結果も含める
+ *
+ * @param ctx コンテキスト
+ * @param from 期間の開始（含む）
+ * @param to 期間の終了（含まない）
+ * @return 階層ごとの合計, エラー
+ */
+func (s *ReportService) Rollup(ctx context.Context, from time.Time, to time.Time) (*model.Rollup, error) {
+	if !from.Before(to) {
+		return nil, errors.New("【ERROR】集計期間の開始は終了より前にしてください。")
+	}
+
+	intervals, err := s.intervals(ctx, ReportQuery{From: from, To: to})
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.taskRepo.List(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := s.projectRepo.List(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	clients, err := s.clientRepo.List(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// タスク → プロジェクト → クライアントの対応
+	titles := make(map[uuid.UUID]string, len(tasks))
+	taskProject := make(map[uuid.UUID]*uuid.UUID, len(tasks))
+	for _, t := range tasks {
+		titles[t.ID] = t.Title
+		taskProject[t.ID] = t.ProjectID
+	}
+	projectByID := make(map[uuid.UUID]*model.Project, len(projects))
+	for _, p := range projects {
+		projectByID[p.ID] = p
+	}
+	clientByID := make(map[uuid.UUID]*model.Client, len(clients))
+	for _, c := range clients {
+		clientByID[c.ID] = c
+	}
+
+	// プロジェクト（なしはuuid.Nil）ごとのタスク別合計
+	projectTasks := map[uuid.UUID]map[uuid.UUID]time.Duration{}
+	var total time.Duration
+	for _, iv := range intervals {
+		d := iv.durationWithin(from, to)
+		if d <= 0 {
+			continue
+		}
+		projectKey := uuid.Nil
+		if pid := taskProject[iv.taskID]; pid != nil {
+			projectKey = *pid
+		}
+		if projectTasks[projectKey] == nil {
+			projectTasks[projectKey] = map[uuid.UUID]time.Duration{}
+		}
+		projectTasks[projectKey][iv.taskID] += d
+		total += d
+	}
+
+	// クライアント（なしはuuid.Nil）ごとにプロジェクトをまとめる
+	clientTotals := map[uuid.UUID]*model.RollupClientTotal{}
+	for projectKey, taskTotals := range projectTasks {
+		pt := model.RollupProjectTotal{Tasks: toTaskTotals(taskTotals, titles)}
+		for _, t := range pt.Tasks {
+			pt.Duration += t.Duration
+		}
+
+		clientKey := uuid.Nil
+		if p, ok := projectByID[projectKey]; ok {
+			id := p.ID
+			pt.ProjectID = &id
+			pt.ProjectName = p.Name
+			pt.Archived = p.Archived
+			if p.ClientID != nil {
+				clientKey = *p.ClientID
+			}
+		}
+
+		ct, ok := clientTotals[clientKey]
+		if !ok {
+			ct = &model.RollupClientTotal{}
+			if c, ok := clientByID[clientKey]; ok {
+				id := c.ID
+				ct.ClientID = &id
+				ct.ClientName = c.Name
+				ct.Archived = c.Archived
+			}
+			clientTotals[clientKey] = ct
+		}
+		ct.Duration += pt.Duration
+		ct.Projects = append(ct.Projects, pt)
+	}
+
+	rollup := &model.Rollup{
+		From:    from,
+		To:      to,
+		Total:   total,
+		Clients: make([]model.RollupClientTotal, 0, len(clientTotals)),
+	}
+	for _, ct := range clientTotals {
+		sort.Slice(ct.Projects, func(i, j int) bool {
+			return rollupLess(ct.Projects[i].ProjectID == nil, ct.Projects[i].ProjectName, ct.Projects[j].ProjectID == nil, ct.Projects[j].ProjectName)
+		})
+		rollup.Clients = append(rollup.Clients, *ct)
+	}
+	sort.Slice(rollup.Clients, func(i, j int) bool {
+		return rollupLess(rollup.Clients[i].ClientID == nil, rollup.Clients[i].ClientName, rollup.Clients[j].ClientID == nil, rollup.Clients[j].ClientName)
+	})
+
+	return rollup, nil
+}
+
+/*
+ * 階層の並び順（名前順、所属なしは最後）
+ */
+func rollupLess(aNone bool, aName string, bNone bool, bName string) bool {
+	if aNone != bNone {
+		return bNone
+	}
+	return aName < bName
+}
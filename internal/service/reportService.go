@@ -0,0 +1,366 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"play-wails/internal/model"
+	"play-wails/internal/repository"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReportService struct {
+	trepo       repository.TimeRecordRepository
+	wrepo       repository.WorkSessionRepository
+	taskRepo    repository.TaskRepository
+	projectRepo repository.ProjectRepository
+	clientRepo  repository.ClientRepository
+	tagRepo     repository.TagRepository
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param trepo 時間計測レコードリポジトリ
+ * @param wrepo 作業セッションリポジトリ
+ * @param taskRepo タスクリポジトリ
+ * @param projectRepo プロジェクトリポジトリ
+ * @param clientRepo クライアントリポジトリ
+ * @param tagRepo タグリポジトリ
+ * @return インスタンス
+ */
+func NewReportService(trepo repository.TimeRecordRepository, wrepo repository.WorkSessionRepository, taskRepo repository.TaskRepository, projectRepo repository.ProjectRepository, clientRepo repository.ClientRepository, tagRepo repository.TagRepository) *ReportService {
+	return &ReportService{trepo: trepo, wrepo: wrepo, taskRepo: taskRepo, projectRepo: projectRepo, clientRepo: clientRepo, tagRepo: tagRepo}
+}
+
+/*
+ * 集計条件
+ * TagIDsはいずれかのタグが計測結果またはそのタスクに付いているものを対象とする
+ */
+type ReportQuery struct {
+	From     time.Time
+	To       time.Time
+	Period   model.ReportPeriod
+	TimeZone string
+	TaskIDs  []uuid.UUID
+	TagIDs   []uuid.UUID
+}
+
+// 集計対象の区間（weightは区間の長さに掛ける係数）
+type reportInterval struct {
+	recordID uuid.UUID
+	taskID   uuid.UUID
+	start    time.Time
+	end      time.Time
+	weight   float64
+}
+
+/*
+ * 区間のうち集計期間内の作業時間
+ */
+func (iv reportInterval) durationWithin(from time.Time, to time.Time) time.Duration {
+	start, end := clip(iv.start, iv.end, from, to)
+	if !start.Before(end) {
+		return 0
+	}
+	return time.Duration(float64(end.Sub(start)) * iv.weight)
+}
+
+/*
+ * 期間内の計測結果を日・ISO週・月ごと、タスクごとに集計する
+ * 日付の区切りは指定したタイムゾーンで判定し、日をまたぐ計測は区切りで分割する
+ *
+ * @param ctx コンテキスト
+ * @param q 集計条件
+ * @return 集計結果, エラー
+ */
+func (s *ReportService) Report(ctx context.Context, q ReportQuery) (*model.Report, error) {
+	if !q.From.Before(q.To) {
+		return nil, errors.New("【ERROR】集計期間の開始は終了より前にしてください。")
+	}
+	loc, err := loadLocation(q.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	switch q.Period {
+	case model.ReportPeriodDay, model.ReportPeriodWeek, model.ReportPeriodMonth:
+	default:
+		return nil, fmt.Errorf("【ERROR】集計単位が不正です: %s（day / week / month）", q.Period)
+	}
+
+	intervals, err := s.intervals(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	titles, err := s.taskTitles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 期間の区切りごとに分割して集計
+	periods := map[string]*model.ReportPeriodTotal{}
+	periodTasks := map[string]map[uuid.UUID]time.Duration{}
+	taskTotals := map[uuid.UUID]time.Duration{}
+	var total time.Duration
+
+	for _, iv := range intervals {
+		start, end := clip(iv.start, iv.end, q.From, q.To)
+		for cur := start; cur.Before(end); {
+			pStart, pEnd, key := periodOf(cur.In(loc), q.Period)
+			segEnd := end
+			if pEnd.Before(segEnd) {
+				segEnd = pEnd
+			}
+			d := time.Duration(float64(segEnd.Sub(cur)) * iv.weight)
+
+			p, ok := periods[key]
+			if !ok {
+				p = &model.ReportPeriodTotal{Key: key, Start: pStart, End: pEnd}
+				periods[key] = p
+				periodTasks[key] = map[uuid.UUID]time.Duration{}
+			}
+			p.Duration += d
+			periodTasks[key][iv.taskID] += d
+			taskTotals[iv.taskID] += d
+			total += d
+
+			cur = segEnd
+		}
+	}
+
+	report := &model.Report{
+		From:     q.From,
+		To:       q.To,
+		TimeZone: loc.String(),
+		Period:   q.Period,
+		Total:    total,
+		Periods:  make([]model.ReportPeriodTotal, 0, len(periods)),
+		Tasks:    toTaskTotals(taskTotals, titles),
+	}
+	for key, p := range periods {
+		p.Tasks = toTaskTotals(periodTasks[key], titles)
+		report.Periods = append(report.Periods, *p)
+	}
+	sort.Slice(report.Periods, func(i, j int) bool {
+		return report.Periods[i].Start.Before(report.Periods[j].Start)
+	})
+
+	return report, nil
+}
+
+/*
+ * 条件に合う期間内の計測結果を集計対象の区間に変換する
+ */
+func (s *ReportService) intervals(ctx context.Context, q ReportQuery) ([]reportInterval, error) {
+	records, err := s.trepo.ListByRange(ctx, q.From, q.To)
+	if err != nil {
+		return nil, err
+	}
+	records = filterByTask(records, q.TaskIDs)
+	records, err = s.filterByTag(ctx, records, q.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+	return recordIntervals(ctx, s.wrepo, records)
+}
+
+/*
+ * 計測結果を集計対象の区間に変換する
+ * 作業セッションの合計が計測結果と一致する場合はセッション単位（休憩を除いた実時間）、
+ * 手入力や編集で一致しない場合は計測結果の開始〜終了に作業時間を按分する
+ * 期間で集計する場合は、各区間を期間内に切り詰めて合計する（durationWithin）
+ *
+ * @param ctx コンテキスト
+ * @param wrepo 作業セッションリポジトリ
+ * @param records 計測結果一覧
+ * @return 区間一覧, エラー
+ */
+func recordIntervals(ctx context.Context, wrepo repository.WorkSessionRepository, records []*model.TimeRecord) ([]reportInterval, error) {
+	// 計測実行ごとの作業セッションをまとめて取得
+	runIDs := make([]uuid.UUID, 0, len(records))
+	for _, r := range records {
+		runIDs = append(runIDs, r.RunID)
+	}
+	sessions, err := wrepo.ListByRunIDs(ctx, runIDs)
+	if err != nil {
+		return nil, err
+	}
+	byRun := map[uuid.UUID][]*model.WorkSession{}
+	for _, sess := range sessions {
+		byRun[sess.RunID] = append(byRun[sess.RunID], sess)
+	}
+
+	list := make([]reportInterval, 0, len(records))
+	for _, r := range records {
+		runSessions := byRun[r.RunID]
+
+		var sum time.Duration
+		for _, sess := range runSessions {
+			sum += sess.Duration()
+		}
+
+		if len(runSessions) > 0 && sum == r.Duration {
+			for _, sess := range runSessions {
+				if sess.EndTime == nil {
+					continue
+				}
+				list = append(list, reportInterval{recordID: r.ID, taskID: r.TaskID, start: sess.StartTime, end: *sess.EndTime, weight: 1})
+			}
+			continue
+		}
+
+		span := r.EndTime.Sub(r.StartTime)
+		if span <= 0 {
+			continue
+		}
+		list = append(list, reportInterval{
+			recordID: r.ID,
+			taskID:   r.TaskID,
+			start:    r.StartTime,
+			end:      r.EndTime,
+			weight:   float64(r.Duration) / float64(span),
+		})
+	}
+	return list, nil
+}
+
+/*
+ * タスクIDとタイトルの対応を取得する
+ */
+func (s *ReportService) taskTitles(ctx context.Context) (map[uuid.UUID]string, error) {
+	tasks, err := s.taskRepo.List(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	titles := make(map[uuid.UUID]string, len(tasks))
+	for _, t := range tasks {
+		titles[t.ID] = t.Title
+	}
+	return titles, nil
+}
+
+/*
+ * タイムゾーン名からロケーションを取得する（未指定の場合はローカル）
+ *
+ * @param name タイムゾーン名（例: Asia/Tokyo）
+ * @return ロケーション, エラー
+ */
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("【ERROR】タイムゾーンが不正です: %s", name)
+	}
+	return loc, nil
+}
+
+/*
+ * 指定時刻を含む期間の開始・終了とキーを取得する
+ * 開始・終了は日付で計算するため、夏時間の切り替え日も正しく区切る
+ */
+func periodOf(t time.Time, period model.ReportPeriod) (time.Time, time.Time, string) {
+	loc := t.Location()
+	y, m, d := t.Date()
+
+	switch period {
+	case model.ReportPeriodWeek:
+		// ISO週は月曜始まり
+		offset := (int(t.Weekday()) + 6) % 7
+		start := time.Date(y, m, d-offset, 0, 0, 0, 0, loc)
+		year, week := start.ISOWeek()
+		return start, start.AddDate(0, 0, 7), fmt.Sprintf("%04d-W%02d", year, week)
+	case model.ReportPeriodMonth:
+		start := time.Date(y, m, 1, 0, 0, 0, 0, loc)
+		return start, start.AddDate(0, 1, 0), start.Format("2006-01")
+	default:
+		start := time.Date(y, m, d, 0, 0, 0, 0, loc)
+		return start, start.AddDate(0, 0, 1), start.Format("2006-01-02")
+	}
+}
+
+/*
+ * 区間を集計期間内に切り詰める
+ */
+func clip(start time.Time, end time.Time, from time.Time, to time.Time) (time.Time, time.Time) {
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	return start, end
+}
+
+/*
+ * タスクIDで絞り込む（指定なしの場合は全件）
+ */
+func filterByTask(records []*model.TimeRecord, taskIDs []uuid.UUID) []*model.TimeRecord {
+	if len(taskIDs) == 0 {
+		return records
+	}
+	allowed := make(map[uuid.UUID]bool, len(taskIDs))
+	for _, id := range taskIDs {
+		allowed[id] = true
+	}
+	list := make([]*model.TimeRecord, 0, len(records))
+	for _, r := range records {
+		if allowed[r.TaskID] {
+			list = append(list, r)
+		}
+	}
+	return list
+}
+
+/*
+ * タグで絞り込む（計測結果またはタスクにいずれかのタグが付いているもの、指定なしの場合は全件）
+ */
+func (s *ReportService) filterByTag(ctx context.Context, records []*model.TimeRecord, tagIDs []uuid.UUID) ([]*model.TimeRecord, error) {
+	if len(tagIDs) == 0 {
+		return records, nil
+	}
+	taskIDs, err := s.tagRepo.TaskIDsByTags(ctx, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+	recordIDs, err := s.tagRepo.TimeRecordIDsByTags(ctx, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := make(map[uuid.UUID]bool, len(taskIDs)+len(recordIDs))
+	for _, id := range taskIDs {
+		allowed[id] = true
+	}
+	for _, id := range recordIDs {
+		allowed[id] = true
+	}
+	list := make([]*model.TimeRecord, 0, len(records))
+	for _, r := range records {
+		if allowed[r.ID] || allowed[r.TaskID] {
+			list = append(list, r)
+		}
+	}
+	return list, nil
+}
+
+/*
+ * タスクごとの合計を作業時間の多い順に並べる
+ */
+func toTaskTotals(totals map[uuid.UUID]time.Duration, titles map[uuid.UUID]string) []model.ReportTaskTotal {
+	list := make([]model.ReportTaskTotal, 0, len(totals))
+	for id, d := range totals {
+		list = append(list, model.ReportTaskTotal{TaskID: id, TaskTitle: titles[id], Duration: d})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Duration != list[j].Duration {
+			return list[i].Duration > list[j].Duration
+		}
+		return list[i].TaskTitle < list[j].TaskTitle
+	})
+	return list
+}
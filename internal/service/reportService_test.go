@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"play-wails/internal/model"
+	"play-wails/internal/repository"
+	"testing"
+	"time"
+)
+
+func TestPeriodOf(t *testing.T) {
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+	newYork := mustLoadLocation(t, "America/New_York")
+
+	tests := []struct {
+		name      string
+		t         time.Time
+		period    model.ReportPeriod
+		wantStart time.Time
+		wantEnd   time.Time
+		wantKey   string
+	}{
+		{
+			name:      "日：タイムゾーンの日付で区切る",
+			t:         time.Date(2026, 10, 1, 23, 30, 0, 0, tokyo),
+			period:    model.ReportPeriodDay,
+			wantStart: time.Date(2026, 10, 1, 0, 0, 0, 0, tokyo),
+			wantEnd:   time.Date(2026, 10, 2, 0, 0, 0, 0, tokyo),
+			wantKey:   "2026-10-01",
+		},
+		{
+			name:      "日：夏時間の開始日は23時間",
+			t:         time.Date(2026, 3, 8, 12, 0, 0, 0, newYork),
+			period:    model.ReportPeriodDay,
+			wantStart: time.Date(2026, 3, 8, 0, 0, 0, 0, newYork),
+			wantEnd:   time.Date(2026, 3, 9, 0, 0, 0, 0, newYork),
+			wantKey:   "2026-03-08",
+		},
+		{
+			name:      "週：日曜は前の月曜から",
+			t:         time.Date(2026, 10, 18, 23, 0, 0, 0, tokyo),
+			period:    model.ReportPeriodWeek,
+			wantStart: time.Date(2026, 10, 12, 0, 0, 0, 0, tokyo),
+			wantEnd:   time.Date(2026, 10, 19, 0, 0, 0, 0, tokyo),
+			wantKey:   "2026-W42",
+		},
+		{
+			name:      "週：年始は前年の月曜から始まるISO週",
+			t:         time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC),
+			period:    model.ReportPeriodWeek,
+			wantStart: time.Date(2025, 12, 29, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+			wantKey:   "2026-W01",
+		},
+		{
+			name:      "週：年末年始は前年の第53週",
+			t:         time.Date(2027, 1, 1, 9, 0, 0, 0, time.UTC),
+			period:    model.ReportPeriodWeek,
+			wantStart: time.Date(2026, 12, 28, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2027, 1, 4, 0, 0, 0, 0, time.UTC),
+			wantKey:   "2026-W53",
+		},
+		{
+			name:      "月：月初から翌月初まで",
+			t:         time.Date(2026, 2, 15, 12, 0, 0, 0, tokyo),
+			period:    model.ReportPeriodMonth,
+			wantStart: time.Date(2026, 2, 1, 0, 0, 0, 0, tokyo),
+			wantEnd:   time.Date(2026, 3, 1, 0, 0, 0, 0, tokyo),
+			wantKey:   "2026-02",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, key := periodOf(tt.t, tt.period)
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) || key != tt.wantKey {
+				t.Errorf("periodOf() = %v, %v, %q; want %v, %v, %q", start, end, key, tt.wantStart, tt.wantEnd, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestReportService_Report(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+	taskRepo := repository.NewTaskRepositoryImpl(conn)
+	trepo := repository.NewTimeRecordRepositoryImpl(conn)
+	s := NewReportService(trepo, repository.NewWorkSessionRepositoryImpl(conn), taskRepo,
+		repository.NewProjectRepositoryImpl(conn), repository.NewClientRepositoryImpl(conn), repository.NewTagRepositoryImpl(conn))
+
+	task := createTestTask(t, taskRepo, "設計")
+	utc := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2026, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	// 東京では 10/1 23:00〜10/2 01:00
+	createTestRecord(t, trepo, task.ID, utc(10, 1, 14), utc(10, 1, 16))
+	// 東京では 日曜 10/18 23:00〜月曜 10/19 01:00（ISO週の境界）
+	createTestRecord(t, trepo, task.ID, utc(10, 18, 14), utc(10, 18, 16))
+	// 10/31〜11/1 をまたぐ（月の境界）
+	createTestRecord(t, trepo, task.ID, utc(10, 31, 23), utc(11, 1, 1))
+
+	tests := []struct {
+		name      string
+		q         ReportQuery
+		wantTotal time.Duration
+		want      map[string]time.Duration
+	}{
+		{
+			name:      "日：東京では日付の境界で分割する",
+			q:         ReportQuery{From: utc(10, 1, 0), To: utc(10, 2, 0), Period: model.ReportPeriodDay, TimeZone: "Asia/Tokyo"},
+			wantTotal: 2 * time.Hour,
+			want:      map[string]time.Duration{"2026-10-01": time.Hour, "2026-10-02": time.Hour},
+		},
+		{
+			name:      "日：UTCでは同じ日",
+			q:         ReportQuery{From: utc(10, 1, 0), To: utc(10, 2, 0), Period: model.ReportPeriodDay, TimeZone: "UTC"},
+			wantTotal: 2 * time.Hour,
+			want:      map[string]time.Duration{"2026-10-01": 2 * time.Hour},
+		},
+		{
+			name:      "日：期間外の部分は含めない",
+			q:         ReportQuery{From: utc(10, 1, 15), To: utc(10, 2, 0), Period: model.ReportPeriodDay, TimeZone: "Asia/Tokyo"},
+			wantTotal: time.Hour,
+			want:      map[string]time.Duration{"2026-10-02": time.Hour},
+		},
+		{
+			name:      "週：東京では月曜で分割する",
+			q:         ReportQuery{From: utc(10, 18, 0), To: utc(10, 19, 0), Period: model.ReportPeriodWeek, TimeZone: "Asia/Tokyo"},
+			wantTotal: 2 * time.Hour,
+			want:      map[string]time.Duration{"2026-W42": time.Hour, "2026-W43": time.Hour},
+		},
+		{
+			name:      "週：UTCでは同じ週",
+			q:         ReportQuery{From: utc(10, 18, 0), To: utc(10, 19, 0), Period: model.ReportPeriodWeek, TimeZone: "UTC"},
+			wantTotal: 2 * time.Hour,
+			want:      map[string]time.Duration{"2026-W42": 2 * time.Hour},
+		},
+		{
+			name:      "月：UTCでは月の境界で分割する",
+			q:         ReportQuery{From: utc(10, 31, 0), To: utc(11, 2, 0), Period: model.ReportPeriodMonth, TimeZone: "UTC"},
+			wantTotal: 2 * time.Hour,
+			want:      map[string]time.Duration{"2026-10": time.Hour, "2026-11": time.Hour},
+		},
+		{
+			name:      "月：東京ではどちらも11月",
+			q:         ReportQuery{From: utc(10, 31, 0), To: utc(11, 2, 0), Period: model.ReportPeriodMonth, TimeZone: "Asia/Tokyo"},
+			wantTotal: 2 * time.Hour,
+			want:      map[string]time.Duration{"2026-11": 2 * time.Hour},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := s.Report(ctx, tt.q)
+			if err != nil {
+				t.Fatalf("Report() error = %v", err)
+			}
+			if report.Total != tt.wantTotal {
+				t.Errorf("Total = %v, want %v", report.Total, tt.wantTotal)
+			}
+			got := map[string]time.Duration{}
+			for _, p := range report.Periods {
+				got[p.Key] = p.Duration
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("Periods = %v, want %v", got, tt.want)
+			}
+			for key, d := range tt.want {
+				if got[key] != d {
+					t.Errorf("Periods[%s] = %v, want %v", key, got[key], d)
+				}
+			}
+		})
+	}
+}
+
+func TestReportService_Report_InvalidQuery(t *testing.T) {
+	s := NewReportService(nil, nil, nil, nil, nil, nil)
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		q    ReportQuery
+	}{
+		{name: "期間が逆", q: ReportQuery{From: from, To: from, Period: model.ReportPeriodDay}},
+		{name: "タイムゾーンが不正", q: ReportQuery{From: from, To: from.Add(time.Hour), Period: model.ReportPeriodDay, TimeZone: "Mars/Olympus"}},
+		{name: "集計単位が不正", q: ReportQuery{From: from, To: from.Add(time.Hour), Period: "year", TimeZone: "UTC"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.Report(context.Background(), tt.q); err == nil {
+				t.Error("Report() error = nil, want error")
+			}
+		})
+	}
+}
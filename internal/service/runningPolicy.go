@@ -0,0 +1,37 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+ * 計測中に別の計測を開始・再開した場合の扱い
+ */
+type RunningPolicy string
+
+const (
+	// 計測中のセッションがあれば開始を拒否する
+	RunningPolicyReject RunningPolicy = "reject"
+	// 計測中のセッションを自動で停止してから開始する
+	RunningPolicyAutoStop RunningPolicy = "auto_stop"
+	// タスクが異なれば並行して計測する（同一タスクは1つまで）
+	RunningPolicyParallel RunningPolicy = "parallel"
+)
+
+/*
+ * 設定値から計測ポリシーを取得する（未指定の場合は拒否）
+ *
+ * @param value 設定値
+ * @return 計測ポリシー, エラー
+ */
+func ParseRunningPolicy(value string) (RunningPolicy, error) {
+	switch p := RunningPolicy(strings.ToLower(strings.TrimSpace(value))); p {
+	case "":
+		return RunningPolicyReject, nil
+	case RunningPolicyReject, RunningPolicyAutoStop, RunningPolicyParallel:
+		return p, nil
+	default:
+		return "", fmt.Errorf("【ERROR】計測ポリシーが不正です: %s（reject / auto_stop / parallel）", value)
+	}
+}
@@ -0,0 +1,349 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"play-wails/internal/model"
+	"play-wails/internal/repository"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+ * TagService はタグの作成・名前の変更・統合と、タスク・計測結果へのタグ付けを行う
+ * タグの名前は大文字・小文字を区別せず重複させない
+ */
+type TagService struct {
+	tx       repository.Transactor
+	repo     repository.TagRepository
+	taskRepo repository.TaskRepository
+	trepo    repository.TimeRecordRepository
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param tx トランザクション
+ * @param repo タグリポジトリ
+ * @param taskRepo タスクリポジトリ
+ * @param trepo 時間計測レコードリポジトリ
+ * @return インスタンス
+ */
+func NewTagService(tx repository.Transactor, repo repository.TagRepository, taskRepo repository.TaskRepository, trepo repository.TimeRecordRepository) *TagService {
+	return &TagService{tx: tx, repo: repo, taskRepo: taskRepo, trepo: trepo}
+}
+
+/*
+ * タグを新規作成する
+ *
+ * @param ctx コンテキスト
+ * @param name 名前
+ * @return タグ, エラー
+ */
+func (s *TagService) Create(ctx context.Context, name string) (*model.Tag, error) {
+	var tag *model.Tag
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		name, err := s.validateName(ctx, name, uuid.Nil)
+		if err != nil {
+			return err
+		}
+		tag, err = s.create(ctx, name)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+/*
+ * タグ一覧を取得する（名前順）
+ *
+ * @param ctx コンテキスト
+ * @return タグ一覧, エラー
+ */
+func (s *TagService) List(ctx context.Context) ([]*model.Tag, error) {
+	return s.repo.List(ctx)
+}
+
+/*
+ * タグの名前を変更する
+ * 変更後の名前のタグが既にある場合は統合（Merge）を使う
+ *
+ * @param ctx コンテキスト
+ * @param id タグID
+ * @param name 変更後の名前
+ * @return 変更後のタグ, エラー
+ */
+func (s *TagService) Rename(ctx context.Context, id uuid.UUID, name string) (*model.Tag, error) {
+	var tag *model.Tag
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		tag, err = s.find(ctx, id)
+		if err != nil {
+			return err
+		}
+		name, err := s.validateName(ctx, name, id)
+		if err != nil {
+			return err
+		}
+
+		tag.Name = name
+		tag.UpdatedAt = time.Now()
+		return s.repo.Update(ctx, tag)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+/*
+ * タグを別のタグに統合する
+ * 統合元のタグが付いたタスク・計測結果はすべて統合先のタグに付け替え、統合元のタグは削除する
+ *
+ * @param ctx コンテキスト
+ * @param fromID 統合元のタグID
+ * @param toID 統合先のタグID
+ * @return 統合先のタグ, エラー
+ */
+func (s *TagService) Merge(ctx context.Context, fromID uuid.UUID, toID uuid.UUID) (*model.Tag, error) {
+	if fromID == toID {
+		return nil, errors.New("【ERROR】同じタグには統合できません。")
+	}
+
+	var tag *model.Tag
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.find(ctx, fromID); err != nil {
+			return err
+		}
+		var err error
+		tag, err = s.find(ctx, toID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.MoveLinks(ctx, fromID, toID); err != nil {
+			return err
+		}
+		return s.repo.Delete(ctx, fromID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+/*
+ * タグを削除する（タスク・計測結果からも外れる）
+ *
+ * @param ctx コンテキスト
+ * @param id タグID
+ * @return エラー
+ */
+func (s *TagService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.find(ctx, id); err != nil {
+			return err
+		}
+		return s.repo.Delete(ctx, id)
+	})
+}
+
+/*
+ * タスクにタグを付ける（同じ名前のタグがない場合は作成する）
+ *
+ * @param ctx コンテキスト
+ * @param taskID タスクID
+ * @param name タグの名前
+ * @return タグ, エラー
+ */
+func (s *TagService) TagTask(ctx context.Context, taskID uuid.UUID, name string) (*model.Tag, error) {
+	var tag *model.Tag
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.taskRepo.FindByID(ctx, taskID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.New("【ERROR】指定されたタスクが存在しません。")
+			}
+			return err
+		}
+		var err error
+		tag, err = s.findOrCreate(ctx, name)
+		if err != nil {
+			return err
+		}
+		return s.repo.AddToTask(ctx, taskID, tag.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+/*
+ * タスクからタグを外す
+ *
+ * @param ctx コンテキスト
+ * @param taskID タスクID
+ * @param tagID タグID
+ * @return エラー
+ */
+func (s *TagService) UntagTask(ctx context.Context, taskID uuid.UUID, tagID uuid.UUID) error {
+	return s.repo.RemoveFromTask(ctx, taskID, tagID)
+}
+
+/*
+ * タスクに付いているタグを取得する（名前順）
+ *
+ * @param ctx コンテキスト
+ * @param taskID タスクID
+ * @return タグ一覧, エラー
+ */
+func (s *TagService) TaskTags(ctx context.Context, taskID uuid.UUID) ([]*model.Tag, error) {
+	return s.repo.ListByTask(ctx, taskID)
+}
+
+/*
+ * 計測結果にタグを付ける（同じ名前のタグがない場合は作成する）
+ *
+ * @param ctx コンテキスト
+ * @param timeRecordID 計測結果ID
+ * @param name タグの名前
+ * @return タグ, エラー
+ */
+func (s *TagService) TagTimeRecord(ctx context.Context, timeRecordID uuid.UUID, name string) (*model.Tag, error) {
+	var tag *model.Tag
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.trepo.FindByID(ctx, timeRecordID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.New("【ERROR】計測結果が見つかりません。")
+			}
+			return err
+		}
+		var err error
+		tag, err = s.findOrCreate(ctx, name)
+		if err != nil {
+			return err
+		}
+		return s.repo.AddToTimeRecord(ctx, timeRecordID, tag.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+/*
+ * 計測結果からタグを外す
+ *
+ * @param ctx コンテキスト
+ * @param timeRecordID 計測結果ID
+ * @param tagID タグID
+ * @return エラー
+ */
+func (s *TagService) UntagTimeRecord(ctx context.Context, timeRecordID uuid.UUID, tagID uuid.UUID) error {
+	return s.repo.RemoveFromTimeRecord(ctx, timeRecordID, tagID)
+}
+
+/*
+ * 計測結果に付いているタグを取得する（名前順、タスクのタグは含まない）
+ *
+ * @param ctx コンテキスト
+ * @param timeRecordID 計測結果ID
+ * @return タグ一覧, エラー
+ */
+func (s *TagService) TimeRecordTags(ctx context.Context, timeRecordID uuid.UUID) ([]*model.Tag, error) {
+	return s.repo.ListByTimeRecord(ctx, timeRecordID)
+}
+
+/*
+ * 指定IDのタグを取得する
+ *
+ * @param ctx コンテキスト
+ * @param id タグID
+ * @return タグ, エラー
+ */
+func (s *TagService) find(ctx context.Context, id uuid.UUID) (*model.Tag, error) {
+	tag, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("【ERROR】指定されたタグが存在しません。")
+		}
+		return nil, err
+	}
+	return tag, nil
+}
+
+/*
+ * 同じ名前のタグを取得し、ない場合は作成する
+ *
+ * @param ctx コンテキスト
+ * @param name 名前
+ * @return タグ, エラー
+ */
+func (s *TagService) findOrCreate(ctx context.Context, name string) (*model.Tag, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("【ERROR】タグ名を入力してください。")
+	}
+
+	tag, err := s.repo.FindByName(ctx, name)
+	if err == nil {
+		return tag, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	return s.create(ctx, name)
+}
+
+/*
+ * タグを作成する
+ *
+ * @param ctx コンテキスト
+ * @param name 名前
+ * @return タグ, エラー
+ */
+func (s *TagService) create(ctx context.Context, name string) (*model.Tag, error) {
+	now := time.Now()
+	tag := &model.Tag{
+		ID:        uuid.New(),
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.repo.Create(ctx, tag); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+/*
+ * タグの名前を検証する（前後の空白は除く）
+ *
+ * @param ctx コンテキスト
+ * @param name 名前
+ * @param selfID 名前を変更するタグのID（作成時はuuid.Nil）
+ * @return 検証後の名前, エラー
+ */
+func (s *TagService) validateName(ctx context.Context, name string, selfID uuid.UUID) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New("【ERROR】タグ名を入力してください。")
+	}
+
+	other, err := s.repo.FindByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return name, nil
+		}
+		return "", err
+	}
+	if other.ID != selfID {
+		return "", errors.New("【ERROR】同じ名前のタグが既に存在します。まとめる場合は統合してください。")
+	}
+	return name, nil
+}
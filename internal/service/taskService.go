@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"play-wails/internal/model"
+	"play-wails/internal/repository"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TaskService struct {
+	repo        repository.TaskRepository
+	projectRepo repository.ProjectRepository
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param repo タスクリポジトリ
+ * @param projectRepo プロジェクトリポジトリ
+ * @return インスタンス
+ */
+func NewTaskService(repo repository.TaskRepository, projectRepo repository.ProjectRepository) *TaskService {
+	return &TaskService{repo: repo, projectRepo: projectRepo}
+}
+
+/*
+ * タスクを新規作成する
+ *
+ * @param ctx コンテキスト
+ * @param title タイトル
+ * @param description 説明
+ * @return タスク, エラー
+ */
+func (s *TaskService) Create(ctx context.Context, title string, description string) (*model.Task, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, errors.New("【ERROR】タスクのタイトルを入力してください。")
+	}
+
+	now := time.Now()
+	task := &model.Task{
+		ID:          uuid.New(),
+		Title:       title,
+		Description: description,
+		Status:      model.TaskStatusTodo,
+		Archived:    false,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := s.repo.Create(ctx, task); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+/*
+ * 指定IDのタスクを取得する
+ *
+ * @param ctx コンテキスト
+ * @param id タスクID
+ * @return タスク, エラー
+ */
+func (s *TaskService) Get(ctx context.Context, id uuid.UUID) (*model.Task, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+/*
+ * タスク一覧を取得する
+ *
+ * @param ctx コンテキスト
+ * @param includeArchived true のときアーカイブ済みも含める
+ * @return タスク一覧, エラー
+ */
+func (s *TaskService) List(ctx context.Context, includeArchived bool) ([]*model.Task, error) {
+	return s.repo.List(ctx, includeArchived)
+}
+
+/*
+ * タスクのタイトル・説明・ステータスを更新する
+ *
+ * @param ctx コンテキスト
+ * @param task タスク
+ * @return 更新後のタスク, エラー
+ */
+func (s *TaskService) Update(ctx context.Context, task *model.Task) (*model.Task, error) {
+	current, err := s.repo.FindByID(ctx, task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 入力チェック
+	title := strings.TrimSpace(task.Title)
+	if title == "" {
+		return nil, errors.New("【ERROR】タスクのタイトルを入力してください。")
+	}
+	if !task.Status.IsValid() {
+		return nil, errors.New("【ERROR】タスクのステータスが不正です。")
+	}
+
+	current.Title = title
+	current.Description = task.Description
+	current.Status = task.Status
+	current.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, current); err != nil {
+		return nil, err
+	}
+
+	return current, nil
+}
+
+/*
+ * タスクの所属プロジェクトを変更する
+ * アーカイブ済みのプロジェクトには追加できない
+ *
+ * @param ctx コンテキスト
+ * @param id タスクID
+ * @param projectID プロジェクトID（nilの場合はプロジェクトなし）
+ * @return 更新後のタスク, エラー
+ */
+func (s *TaskService) SetProject(ctx context.Context, id uuid.UUID, projectID *uuid.UUID) (*model.Task, error) {
+	task, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if projectID != nil {
+		project, err := s.projectRepo.FindByID(ctx, *projectID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, errors.New("【ERROR】指定されたプロジェクトが存在しません。")
+			}
+			return nil, err
+		}
+		if project.Archived {
+			return nil, errors.New("【ERROR】アーカイブ済みのプロジェクトにはタスクを追加できません。")
+		}
+	}
+
+	task.ProjectID = projectID
+	task.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, task); err != nil {
+		return nil, err
+	}
+
+	// プロジェクトのアーカイブ状態を反映して返す
+	return s.repo.FindByID(ctx, id)
+}
+
+/*
+ * タスクのアーカイブ状態を切り替える
+ * アーカイブ済みのタスクでは計測を開始できない
+ *
+ * @param ctx コンテキスト
+ * @param id タスクID
+ * @param archived アーカイブするか
+ * @return エラー
+ */
+func (s *TaskService) SetArchived(ctx context.Context, id uuid.UUID, archived bool) error {
+	task, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	task.Archived = archived
+	task.UpdatedAt = time.Now()
+
+	return s.repo.Update(ctx, task)
+}
+
+/*
+ * タスクの請求対象を切り替える
+ * 請求対象外のタスクの計測結果は請求額の計算に含めない
+ *
+ * @param ctx コンテキスト
+ * @param id タスクID
+ * @param billable 請求対象にするか
+ * @return エラー
+ */
+func (s *TaskService) SetBillable(ctx context.Context, id uuid.UUID, billable bool) error {
+	task, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	task.NonBillable = !billable
+	task.UpdatedAt = time.Now()
+
+	return s.repo.Update(ctx, task)
+}
+
+/*
+ * タスクを削除する
+ * 作業記録から参照されている場合は削除できない
+ *
+ * @param ctx コンテキスト
+ * @param id タスクID
+ * @return エラー
+ */
+func (s *TaskService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.repo.Delete(ctx, id)
+}
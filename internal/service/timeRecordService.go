@@ -1,62 +1,399 @@
 package service
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"play-wails/internal/model"
 	"play-wails/internal/repository"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+const (
+	// 計測結果一覧の1ページの件数（未指定時）
+	defaultTimeRecordPageSize = 50
+	// 計測結果一覧の1ページの最大件数
+	maxTimeRecordPageSize = 500
+)
+
+// 入力チェックのエラーの全体のメッセージ
+const invalidTimeRecordMessage = "【ERROR】計測結果の入力内容に誤りがあります。"
+
 type TimeRecordService struct {
-	trepo repository.TimeRecordRepository
+	tx       repository.Transactor
+	trepo    repository.TimeRecordRepository
+	wrepo    repository.WorkSessionRepository
+	taskRepo repository.TaskRepository
+	runRepo  repository.RunRepository
+	overlap  *OverlapService
 }
 
 /*
  * 実装クラスのインスタンス生成
  *
+ * @param tx トランザクション
  * @param trepo 時間計測レコードリポジトリ
+ * @param wrepo 作業セッションリポジトリ
+ * @param taskRepo タスクリポジトリ
+ * @param runRepo 計測実行リポジトリ
+ * @param overlap 重なり検出サービス
  * @return インスタンス
  */
-func NewTimeRecordService(trepo repository.TimeRecordRepository) *TimeRecordService {
-	return &TimeRecordService{trepo: trepo}
+func NewTimeRecordService(tx repository.Transactor, trepo repository.TimeRecordRepository, wrepo repository.WorkSessionRepository, taskRepo repository.TaskRepository, runRepo repository.RunRepository, overlap *OverlapService) *TimeRecordService {
+	return &TimeRecordService{tx: tx, trepo: trepo, wrepo: wrepo, taskRepo: taskRepo, runRepo: runRepo, overlap: overlap}
 }
 
 /*
  * 計測結果一覧を取得する
  * 論理削除済みは除外
  *
+ * @param ctx コンテキスト
  * @return 計測結果一覧, エラー
  */
-func (s *TimeRecordService) List() ([]*model.TimeRecord, error) {
-	return s.trepo.List(true)
+func (s *TimeRecordService) List(ctx context.Context) ([]*model.TimeRecord, error) {
+	return s.trepo.List(ctx, true)
+}
+
+/*
+ * 条件を指定して計測結果を1ページ分取得する
+ * 続きはNextCursorをCursorに指定して取得する
+ *
+ * @param ctx コンテキスト
+ * @param q 検索条件
+ * @return 検索結果, エラー
+ */
+func (s *TimeRecordService) Query(ctx context.Context, q model.TimeRecordQuery) (*model.TimeRecordPage, error) {
+	if q.From != nil && q.To != nil && !q.From.Before(*q.To) {
+		return nil, errors.New("【ERROR】期間の終了は開始より後を指定してください。")
+	}
+	if q.MinDuration != nil && q.MaxDuration != nil && *q.MinDuration > *q.MaxDuration {
+		return nil, errors.New("【ERROR】作業時間の下限が上限を超えています。")
+	}
+
+	// 件数の範囲を補正
+	switch {
+	case q.Limit <= 0:
+		q.Limit = defaultTimeRecordPageSize
+	case q.Limit > maxTimeRecordPageSize:
+		q.Limit = maxTimeRecordPageSize
+	}
+
+	return s.trepo.Query(ctx, q)
 }
 
 /*
  * 指定IDの計測結果を取得する
  *
+ * @param ctx コンテキスト
  * @param id 計測結果ID
  * @return 計測結果, エラー
  */
-func (s *TimeRecordService) Get(id uuid.UUID) (*model.TimeRecord, error) {
-	return s.trepo.FindByID(id)
+func (s *TimeRecordService) Get(ctx context.Context, id uuid.UUID) (*model.TimeRecord, error) {
+	return s.trepo.FindByID(ctx, id)
+}
+
+/*
+ * 計測結果を手入力で作成する
+ * 完了済みの計測実行と、開始〜終了の作業セッションを1件作成する
+ *
+ * @param ctx コンテキスト
+ * @param in 入力内容（TaskID・開始・終了）
+ * @return 計測結果, エラー（入力の誤りは*model.ValidationError）
+ */
+func (s *TimeRecordService) Create(ctx context.Context, in model.TimeRecordInput) (*model.TimeRecord, error) {
+	ctx = repository.WithAuditReason(ctx, in.Reason)
+	ctx = repository.WithAuditReason(ctx, "計測結果の手入力")
+	v := model.NewValidationError(invalidTimeRecordMessage)
+	validateTimeRange(v, in.StartTime, in.EndTime, time.Now())
+
+	// タスクの検証（計測の開始と同じく、アーカイブ済みのタスク・プロジェクトには記録できない）
+	if in.TaskID == uuid.Nil {
+		v.Add("task_id", "タスクを選択してください")
+	} else if err := validateTask(ctx, s.taskRepo, in.TaskID); err != nil {
+		switch {
+		case errors.Is(err, errTaskNotFound):
+			v.Add("task_id", "タスクが見つかりません")
+		case errors.Is(err, errTaskArchived):
+			v.Add("task_id", "アーカイブ済みのタスクには記録できません")
+		case errors.Is(err, errTaskProjectArchived):
+			v.Add("task_id", "アーカイブ済みのプロジェクトのタスクには記録できません")
+		default:
+			return nil, err
+		}
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	// 手入力は作業セッション1件のため、作業時間は開始〜終了
+	validateDuration(v, in.Duration, in.EndTime.Sub(in.StartTime))
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	run := &model.Run{
+		ID:        uuid.New(),
+		TaskID:    in.TaskID,
+		Status:    model.RunStatusCompleted,
+		CreatedAt: in.StartTime,
+		UpdatedAt: in.EndTime,
+	}
+	end := in.EndTime
+	session := &model.WorkSession{
+		ID:        uuid.New(),
+		RunID:     run.ID,
+		TaskID:    in.TaskID,
+		StartTime: in.StartTime,
+		EndTime:   &end,
+	}
+	record := &model.TimeRecord{
+		ID:        uuid.New(),
+		RunID:     run.ID,
+		TaskID:    in.TaskID,
+		StartTime: in.StartTime,
+		EndTime:   in.EndTime,
+		Duration:  in.EndTime.Sub(in.StartTime),
+	}
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// 他の作業セッションとの重なりを確認（設定で拒否する場合のみ）
+		if err := s.overlap.Enforce(ctx, session); err != nil {
+			return err
+		}
+
+		if err := s.runRepo.Create(ctx, run); err != nil {
+			return err
+		}
+		if err := s.wrepo.Create(ctx, session); err != nil {
+			return err
+		}
+		return s.trepo.Create(ctx, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
 }
 
 /*
  * 計測結果の時間（開始・終了・作業時間）を更新する
+ * 作業時間は計測実行の作業セッションの合計と一致させる
+ * - 作業セッションが1件で計測結果と同じ範囲の場合（手入力など）は、作業セッションも移動する
+ * - それ以外は、全ての作業セッションを開始〜終了の範囲に含める必要がある
+ * - 作業セッションがない場合は、作業時間を開始〜終了の範囲内で指定できる
+ * 論理削除済み・請求済みの計測結果は編集できない
  *
- * @param record 計測結果
- * @return エラー
+ * @param ctx コンテキスト
+ * @param in 入力内容（ID・開始・終了・作業時間）
+ * @return 計測結果, エラー（入力の誤りは*model.ValidationError）
  */
-func (s *TimeRecordService) Update(record *model.TimeRecord) error {
-	return s.trepo.Update(record)
+func (s *TimeRecordService) Update(ctx context.Context, in model.TimeRecordInput) (*model.TimeRecord, error) {
+	ctx = repository.WithAuditReason(ctx, in.Reason)
+	ctx = repository.WithAuditReason(ctx, "計測結果の修正")
+	var record *model.TimeRecord
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		record, err = s.trepo.FindByID(ctx, in.ID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.New("【ERROR】計測結果が見つかりません。")
+			}
+			return err
+		}
+		if record.DeleteFlag {
+			return errors.New("【ERROR】削除済みの計測結果は編集できません。")
+		}
+		if record.IsInvoiced() {
+			return errors.New("【ERROR】請求済みの計測結果は編集できません。請求書を取り消してから編集してください。")
+		}
+
+		v := model.NewValidationError(invalidTimeRecordMessage)
+		validateTimeRange(v, in.StartTime, in.EndTime, time.Now())
+		if err := v.Err(); err != nil {
+			return err
+		}
+
+		sessions, err := s.wrepo.ListByRunID(ctx, record.RunID)
+		if err != nil {
+			return err
+		}
+
+		span := in.EndTime.Sub(in.StartTime)
+		var moved *model.WorkSession
+		var total time.Duration
+		switch {
+		case len(sessions) == 0:
+			// 作業セッションがない場合は指定された作業時間
+			total = span
+			if in.Duration != nil {
+				if *in.Duration <= 0 || *in.Duration > span {
+					v.Add("duration", "作業時間は0より大きく、開始〜終了の範囲内にしてください")
+				}
+				total = *in.Duration
+			}
+		case len(sessions) == 1 && sessions[0].EndTime != nil &&
+			sessions[0].StartTime.Equal(record.StartTime) && sessions[0].EndTime.Equal(record.EndTime):
+			// 計測結果と同じ範囲の作業セッションは一緒に移動する
+			moved = sessions[0]
+			end := in.EndTime
+			moved.StartTime = in.StartTime
+			moved.EndTime = &end
+			total = span
+		default:
+			first, last := sessions[0].StartTime, time.Time{}
+			for _, sess := range sessions {
+				if sess.StartTime.Before(first) {
+					first = sess.StartTime
+				}
+				if sess.EndTime != nil && sess.EndTime.After(last) {
+					last = *sess.EndTime
+				}
+				total += sess.Duration()
+			}
+			if in.StartTime.After(first) {
+				v.Add("start_time", fmt.Sprintf("作業セッションの開始（%s）より後にはできません", formatLocalTime(first)))
+			}
+			if in.EndTime.Before(last) {
+				v.Add("end_time", fmt.Sprintf("作業セッションの終了（%s）より前にはできません", formatLocalTime(last)))
+			}
+		}
+		if len(sessions) > 0 {
+			validateDuration(v, in.Duration, total)
+		}
+		if err := v.Err(); err != nil {
+			return err
+		}
+
+		if moved != nil {
+			if err := s.overlap.Enforce(ctx, moved); err != nil {
+				return err
+			}
+			if err := s.wrepo.Update(ctx, moved); err != nil {
+				return err
+			}
+		}
+		record.StartTime = in.StartTime
+		record.EndTime = in.EndTime
+		record.Duration = total
+		return s.trepo.Update(ctx, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+/*
+ * 開始・終了時刻をチェックする
+ *
+ * @param v エラーの追加先
+ * @param start 開始時刻
+ * @param end 終了時刻
+ * @param now 現在時刻
+ */
+func validateTimeRange(v *model.ValidationError, start time.Time, end time.Time, now time.Time) {
+	if start.IsZero() {
+		v.Add("start_time", "開始時刻を入力してください")
+	}
+	if end.IsZero() {
+		v.Add("end_time", "終了時刻を入力してください")
+	}
+	if start.IsZero() || end.IsZero() {
+		return
+	}
+	if !end.After(start) {
+		v.Add("end_time", "終了時刻は開始時刻より後にしてください")
+	}
+	if end.After(now) {
+		v.Add("end_time", "未来の時刻は指定できません")
+	}
+}
+
+/*
+ * 指定された作業時間が作業セッションの合計と一致するかチェックする（未指定の場合は何もしない）
+ *
+ * @param v エラーの追加先
+ * @param duration 指定された作業時間
+ * @param total 作業セッションの合計
+ */
+func validateDuration(v *model.ValidationError, duration *time.Duration, total time.Duration) {
+	if duration != nil && *duration != total {
+		v.Add("duration", fmt.Sprintf("作業時間は作業セッションの合計（%s）と一致させてください", formatHMS(total)))
+	}
+}
+
+/*
+ * エラーメッセージ用にローカル時刻の文字列にする
+ */
+func formatLocalTime(t time.Time) string {
+	return t.In(time.Local).Format("2006-01-02 15:04:05")
+}
+
+/*
+ * 計測結果の請求対象を切り替える
+ * 論理削除済み・請求済みの計測結果は変更できない
+ *
+ * @param ctx コンテキスト
+ * @param id 計測結果ID
+ * @param billable 請求対象にするか
+ * @param reason 変更理由（省略可）
+ * @return 計測結果, エラー
+ */
+func (s *TimeRecordService) SetBillable(ctx context.Context, id uuid.UUID, billable bool, reason string) (*model.TimeRecord, error) {
+	ctx = repository.WithAuditReason(ctx, reason)
+	ctx = repository.WithAuditReason(ctx, "請求対象の変更")
+	var record *model.TimeRecord
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		record, err = s.trepo.FindByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.New("【ERROR】計測結果が見つかりません。")
+			}
+			return err
+		}
+		if record.DeleteFlag {
+			return errors.New("【ERROR】削除済みの計測結果は編集できません。")
+		}
+		if record.IsInvoiced() {
+			return errors.New("【ERROR】請求済みの計測結果は編集できません。請求書を取り消してから編集してください。")
+		}
+
+		record.NonBillable = !billable
+		return s.trepo.Update(ctx, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
 }
 
 /*
  * 計測結果を論理削除する
+ * 請求済みの計測結果は削除できない
  *
+ * @param ctx コンテキスト
  * @param id 計測結果ID
+ * @param reason 変更理由（省略可）
  * @return エラー
  */
-func (s *TimeRecordService) Delete(id uuid.UUID) error {
-	return s.trepo.Delete(id)
+func (s *TimeRecordService) Delete(ctx context.Context, id uuid.UUID, reason string) error {
+	ctx = repository.WithAuditReason(ctx, reason)
+	ctx = repository.WithAuditReason(ctx, "計測結果の削除")
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		record, err := s.trepo.FindByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.New("【ERROR】計測結果が見つかりません。")
+			}
+			return err
+		}
+		if record.IsInvoiced() {
+			return errors.New("【ERROR】請求済みの計測結果は削除できません。請求書を取り消してから削除してください。")
+		}
+		return s.trepo.Delete(ctx, id)
+	})
 }
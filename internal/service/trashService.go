@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"play-wails/internal/model"
+	"play-wails/internal/repository"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ゴミ箱の保持期間の既定値
+const defaultTrashRetentionDays = 30
+
+/*
+ * 設定値からゴミ箱の保持期間を取得する（日数、未指定の場合は30日、0の場合は自動で削除しない）
+ *
+ * @param value 設定値
+ * @return 保持期間, エラー
+ */
+func ParseTrashRetention(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return defaultTrashRetentionDays * 24 * time.Hour, nil
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		return 0, fmt.Errorf("【ERROR】ゴミ箱の保持期間が不正です: %s（0以上の日数）", value)
+	}
+	return time.Duration(days) * 24 * time.Hour, nil
+}
+
+/*
+ * TrashService は論理削除（ゴミ箱に移動）した計測結果の復元・完全な削除を行う
+ * 完全に削除すると計測実行・作業セッション・取り込み記録もまとめて削除する（変更履歴は残す）
+ */
+type TrashService struct {
+	tx        repository.Transactor
+	trepo     repository.TimeRecordRepository
+	wrepo     repository.WorkSessionRepository
+	runRepo   repository.RunRepository
+	taskRepo  repository.TaskRepository
+	fprepo    repository.ImportFingerprintRepository
+	overlap   *OverlapService
+	retention time.Duration
+}
+
+/*
+ * 実装クラスのインスタンス生成
+ *
+ * @param tx トランザクション
+ * @param trepo 時間計測レコードリポジトリ
+ * @param wrepo 作業セッションリポジトリ
+ * @param runRepo 計測実行リポジトリ
+ * @param taskRepo タスクリポジトリ
+ * @param fprepo 取り込み済みデータリポジトリ
+ * @param overlap 重なり検出サービス
+ * @param retention 保持期間（0の場合は自動で削除しない）
+ * @return インスタンス
+ */
+func NewTrashService(tx repository.Transactor, trepo repository.TimeRecordRepository, wrepo repository.WorkSessionRepository, runRepo repository.RunRepository, taskRepo repository.TaskRepository, fprepo repository.ImportFingerprintRepository, overlap *OverlapService, retention time.Duration) *TrashService {
+	return &TrashService{tx: tx, trepo: trepo, wrepo: wrepo, runRepo: runRepo, taskRepo: taskRepo, fprepo: fprepo, overlap: overlap, retention: retention}
+}
+
+/*
+ * ゴミ箱の計測結果一覧を取得する（削除日時の新しい順）
+ *
+ * @param ctx コンテキスト
+ * @return ゴミ箱の計測結果一覧, エラー
+ */
+func (s *TrashService) List(ctx context.Context) ([]*model.TrashEntry, error) {
+	records, err := s.trepo.ListDeleted(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.taskRepo.List(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	titles := make(map[uuid.UUID]string, len(tasks))
+	for _, t := range tasks {
+		titles[t.ID] = t.Title
+	}
+
+	entries := make([]*model.TrashEntry, 0, len(records))
+	for _, r := range records {
+		e := &model.TrashEntry{Record: r, TaskTitle: titles[r.TaskID]}
+		if s.retention > 0 && r.DeletedAt != nil {
+			purgeAt := r.DeletedAt.Add(s.retention)
+			e.PurgeAt = &purgeAt
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+/*
+ * ゴミ箱の計測結果を元に戻す
+ * 作業セッションが他の作業セッションと重なる場合は重なりの扱いに従う
+ *
+ * @param ctx コンテキスト
+ * @param id 計測結果ID
+ * @param reason 変更理由（省略可）
+ * @return 計測結果, エラー
+ */
+func (s *TrashService) Restore(ctx context.Context, id uuid.UUID, reason string) (*model.TimeRecord, error) {
+	ctx = repository.WithAuditReason(ctx, reason)
+	ctx = repository.WithAuditReason(ctx, "ゴミ箱から復元")
+
+	var restored *model.TimeRecord
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		record, err := s.trashedRecord(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		sessions, err := s.wrepo.ListByRunID(ctx, record.RunID)
+		if err != nil {
+			return err
+		}
+		for _, sess := range sessions {
+			if err := s.overlap.Enforce(ctx, sess); err != nil {
+				return err
+			}
+		}
+
+		if err := s.trepo.Restore(ctx, id); err != nil {
+			return err
+		}
+		restored, err = s.trepo.FindByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+/*
+ * ゴミ箱の計測結果を完全に削除する
+ *
+ * @param ctx コンテキスト
+ * @param id 計測結果ID
+ * @param reason 変更理由（省略可）
+ * @return エラー
+ */
+func (s *TrashService) Purge(ctx context.Context, id uuid.UUID, reason string) error {
+	ctx = repository.WithAuditReason(ctx, reason)
+	ctx = repository.WithAuditReason(ctx, "ゴミ箱から完全に削除")
+
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		record, err := s.trashedRecord(ctx, id)
+		if err != nil {
+			return err
+		}
+		return s.purge(ctx, record)
+	})
+}
+
+/*
+ * 保持期間を過ぎたゴミ箱の計測結果を完全に削除する（保持期間が0の場合は何もしない）
+ *
+ * @param ctx コンテキスト
+ * @return 削除した件数, エラー
+ */
+func (s *TrashService) PurgeExpired(ctx context.Context) (int, error) {
+	if s.retention <= 0 {
+		return 0, nil
+	}
+	ctx = repository.WithAuditReason(ctx, fmt.Sprintf("保持期間（%d日）の経過", int(s.retention/(24*time.Hour))))
+
+	expired, err := s.trepo.ListDeletedBefore(ctx, time.Now().Add(-s.retention))
+	if err != nil {
+		return 0, err
+	}
+
+	// 1件ごとに削除し、途中で失敗してもそれまでの削除は残す
+	purged := 0
+	for _, record := range expired {
+		err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+			return s.purge(ctx, record)
+		})
+		if err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+/*
+ * ゴミ箱にある計測結果を取得する
+ *
+ * @param ctx コンテキスト
+ * @param id 計測結果ID
+ * @return 計測結果, エラー
+ */
+func (s *TrashService) trashedRecord(ctx context.Context, id uuid.UUID) (*model.TimeRecord, error) {
+	record, err := s.trepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("【ERROR】計測結果が見つかりません。")
+		}
+		return nil, err
+	}
+	if !record.DeleteFlag {
+		return nil, errors.New("【ERROR】計測結果はゴミ箱にありません。")
+	}
+	return record, nil
+}
+
+/*
+ * 計測結果と、その計測実行・作業セッション・取り込み記録を削除する
+ *
+ * @param ctx コンテキスト
+ * @param record 計測結果
+ * @return エラー
+ */
+func (s *TrashService) purge(ctx context.Context, record *model.TimeRecord) error {
+	sessions, err := s.wrepo.ListByRunID(ctx, record.RunID)
+	if err != nil {
+		return err
+	}
+	for _, sess := range sessions {
+		if err := s.wrepo.Delete(ctx, sess.ID); err != nil {
+			return err
+		}
+	}
+	if err := s.fprepo.DeleteByTimeRecordID(ctx, record.ID); err != nil {
+		return err
+	}
+	if err := s.trepo.Purge(ctx, record.ID); err != nil {
+		return err
+	}
+	return s.runRepo.Delete(ctx, record.RunID)
+}
@@ -0,0 +1,369 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"play-wails/internal/model"
+	"play-wails/internal/repository"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 作業セッションの入力チェックのエラーの全体のメッセージ
+const invalidWorkSessionMessage = "【ERROR】作業セッションの入力内容に誤りがあります。"
+
+/*
+ * 計測実行の作業セッション一覧を取得する（開始時刻順）
+ *
+ * @param ctx コンテキスト
+ * @param runID 計測実行のグループID
+ * @return 作業セッション一覧, エラー
+ */
+func (s *WorkSessionService) Sessions(ctx context.Context, runID uuid.UUID) ([]*model.WorkSession, error) {
+	sessions, err := s.wrepo.ListByRunID(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	sortSessions(sessions)
+	return sessions, nil
+}
+
+/*
+ * 停止済みの作業セッションの開始・終了時刻を修正する
+ * 完了済みの計測実行の場合は計測結果も再集計する
+ *
+ * @param ctx コンテキスト
+ * @param sessionID 作業セッションID
+ * @param start 開始時刻
+ * @param end 終了時刻
+ * @param reason 変更理由（省略可）
+ * @return 作業セッション, エラー（入力の誤りは*model.ValidationError）
+ */
+func (s *WorkSessionService) EditSession(ctx context.Context, sessionID uuid.UUID, start time.Time, end time.Time, reason string) (*model.WorkSession, error) {
+	ctx = repository.WithAuditReason(ctx, reason)
+	ctx = repository.WithAuditReason(ctx, "作業セッションの修正")
+	var session *model.WorkSession
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		sess, run, err := s.editableSession(ctx, sessionID)
+		if err != nil {
+			return err
+		}
+
+		v := model.NewValidationError(invalidWorkSessionMessage)
+		validateTimeRange(v, start, end, time.Now())
+		if err := v.Err(); err != nil {
+			return err
+		}
+
+		sess.StartTime = start
+		sess.EndTime = &end
+		if err := s.validateNoOverlapInRun(ctx, v, sess); err != nil {
+			return err
+		}
+		if err := s.overlap.Enforce(ctx, sess); err != nil {
+			return err
+		}
+
+		if err := s.wrepo.Update(ctx, sess); err != nil {
+			return err
+		}
+		session = sess
+		return s.syncRunRecord(ctx, run)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+/*
+ * 停止済みの作業セッションを2つに分割する（例: 昼休みに一時停止し忘れた場合）
+ * 開始〜splitAt と resumeAt〜終了 の2つにし、間は作業時間から除く
+ * resumeAtを省略（ゼロ値）した場合は間を空けずに分割する
+ *
+ * @param ctx コンテキスト
+ * @param sessionID 作業セッションID
+ * @param splitAt 分割する時刻（前半の終了時刻）
+ * @param resumeAt 後半の開始時刻
+ * @param reason 変更理由（省略可）
+ * @return 分割後の作業セッション（前半・後半）, エラー（入力の誤りは*model.ValidationError）
+ */
+func (s *WorkSessionService) SplitSession(ctx context.Context, sessionID uuid.UUID, splitAt time.Time, resumeAt time.Time, reason string) ([]*model.WorkSession, error) {
+	ctx = repository.WithAuditReason(ctx, reason)
+	ctx = repository.WithAuditReason(ctx, "作業セッションの分割")
+	if resumeAt.IsZero() {
+		resumeAt = splitAt
+	}
+
+	var sessions []*model.WorkSession
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		sess, run, err := s.editableSession(ctx, sessionID)
+		if err != nil {
+			return err
+		}
+
+		v := model.NewValidationError(invalidWorkSessionMessage)
+		end := *sess.EndTime
+		if !splitAt.After(sess.StartTime) || !splitAt.Before(end) {
+			v.Add("split_at", "分割する時刻は作業セッションの開始〜終了の間にしてください")
+		} else if resumeAt.Before(splitAt) {
+			v.Add("resume_at", "再開時刻は分割する時刻以降にしてください")
+		} else if !resumeAt.Before(end) {
+			v.Add("resume_at", "再開時刻は作業セッションの終了より前にしてください")
+		}
+		if err := v.Err(); err != nil {
+			return err
+		}
+
+		// 前半は元の作業セッションを短くし、後半は新規に作成
+		first := sess
+		first.EndTime = &splitAt
+		second := &model.WorkSession{
+			ID:        uuid.New(),
+			RunID:     sess.RunID,
+			TaskID:    sess.TaskID,
+			StartTime: resumeAt,
+			EndTime:   &end,
+		}
+		if err := s.wrepo.Update(ctx, first); err != nil {
+			return err
+		}
+		if err := s.wrepo.Create(ctx, second); err != nil {
+			return err
+		}
+		sessions = []*model.WorkSession{first, second}
+		return s.syncRunRecord(ctx, run)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+/*
+ * 同じ計測実行の2つの作業セッションを1つに結合する
+ * 早い方の開始〜遅い方の終了を1つの作業セッションとし、間の時間も作業時間に含める
+ *
+ * @param ctx コンテキスト
+ * @param firstID 作業セッションID
+ * @param secondID 作業セッションID
+ * @param reason 変更理由（省略可）
+ * @return 結合後の作業セッション, エラー
+ */
+func (s *WorkSessionService) MergeSessions(ctx context.Context, firstID uuid.UUID, secondID uuid.UUID, reason string) (*model.WorkSession, error) {
+	ctx = repository.WithAuditReason(ctx, reason)
+	ctx = repository.WithAuditReason(ctx, "作業セッションの結合")
+	if firstID == secondID {
+		return nil, errors.New("【ERROR】異なる作業セッションを指定してください。")
+	}
+
+	var merged *model.WorkSession
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		a, run, err := s.editableSession(ctx, firstID)
+		if err != nil {
+			return err
+		}
+		b, _, err := s.editableSession(ctx, secondID)
+		if err != nil {
+			return err
+		}
+		if a.RunID != b.RunID {
+			return errors.New("【ERROR】同じ計測実行の作業セッションのみ結合できます。")
+		}
+
+		// 早い方を残し、遅い方を削除する
+		if b.StartTime.Before(a.StartTime) {
+			a, b = b, a
+		}
+		if b.EndTime.After(*a.EndTime) {
+			end := *b.EndTime
+			a.EndTime = &end
+		}
+
+		v := model.NewValidationError(invalidWorkSessionMessage)
+		if err := s.validateNoOverlapInRun(ctx, v, a, b.ID); err != nil {
+			return err
+		}
+		if err := s.overlap.Enforce(ctx, a, b.ID); err != nil {
+			return err
+		}
+
+		if err := s.wrepo.Delete(ctx, b.ID); err != nil {
+			return err
+		}
+		if err := s.wrepo.Update(ctx, a); err != nil {
+			return err
+		}
+		merged = a
+		return s.syncRunRecord(ctx, run)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+/*
+ * 停止済みの作業セッションを削除する
+ * 計測実行の最後の作業セッションは削除できない（計測結果の削除または計測の取消を行う）
+ *
+ * @param ctx コンテキスト
+ * @param sessionID 作業セッションID
+ * @param reason 変更理由（省略可）
+ * @return エラー
+ */
+func (s *WorkSessionService) DeleteSession(ctx context.Context, sessionID uuid.UUID, reason string) error {
+	ctx = repository.WithAuditReason(ctx, reason)
+	ctx = repository.WithAuditReason(ctx, "作業セッションの削除")
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		sess, run, err := s.editableSession(ctx, sessionID)
+		if err != nil {
+			return err
+		}
+
+		sessions, err := s.wrepo.ListByRunID(ctx, sess.RunID)
+		if err != nil {
+			return err
+		}
+		if len(sessions) <= 1 {
+			return errors.New("【ERROR】最後の作業セッションは削除できません。計測結果の削除または計測の取消を行ってください。")
+		}
+
+		if err := s.wrepo.Delete(ctx, sessionID); err != nil {
+			return err
+		}
+		return s.syncRunRecord(ctx, run)
+	})
+}
+
+/*
+ * 編集対象の作業セッションと計測実行を取得し、編集できるか確認する
+ * 計測中の作業セッション、取消済みの計測実行、削除済み・請求済みの計測結果のものは編集できない
+ *
+ * @param ctx コンテキスト
+ * @param sessionID 作業セッションID
+ * @return 作業セッション, 計測実行, エラー
+ */
+func (s *WorkSessionService) editableSession(ctx context.Context, sessionID uuid.UUID) (*model.WorkSession, *model.Run, error) {
+	sess, err := s.wrepo.FindByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, errors.New("【ERROR】作業セッションが見つかりません。")
+		}
+		return nil, nil, err
+	}
+	if sess.IsRunning() {
+		return nil, nil, errors.New("【ERROR】計測中の作業セッションは編集できません。停止してから編集してください。")
+	}
+
+	run, err := s.findRun(ctx, sess.RunID)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch run.Status {
+	case model.RunStatusCancelled:
+		return nil, nil, errors.New("【ERROR】取消済みの計測の作業セッションは編集できません。")
+	case model.RunStatusCompleted:
+		record, err := s.trepo.FindByRunID(ctx, run.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if record.DeleteFlag {
+			return nil, nil, errors.New("【ERROR】削除済みの計測結果の作業セッションは編集できません。")
+		}
+		if record.IsInvoiced() {
+			return nil, nil, errors.New("【ERROR】請求済みの計測結果の作業セッションは編集できません。請求書を取り消してから編集してください。")
+		}
+	}
+	return sess, run, nil
+}
+
+/*
+ * 同じ計測実行の他の作業セッションと重ならないか確認する
+ *
+ * @param ctx コンテキスト
+ * @param v エラーの追加先
+ * @param sess 変更後の作業セッション
+ * @param ignore 確認から除く作業セッションID（結合で削除するものなど）
+ * @return エラー（重なる場合は*model.ValidationError）
+ */
+func (s *WorkSessionService) validateNoOverlapInRun(ctx context.Context, v *model.ValidationError, sess *model.WorkSession, ignore ...uuid.UUID) error {
+	others, err := s.wrepo.ListByRunID(ctx, sess.RunID)
+	if err != nil {
+		return err
+	}
+	sortSessions(others)
+
+	now := time.Now()
+	for _, o := range others {
+		if o.ID == sess.ID || containsUUID(ignore, o.ID) {
+			continue
+		}
+		end := now
+		if o.EndTime != nil {
+			end = *o.EndTime
+		}
+		if sess.StartTime.Before(end) && o.StartTime.Before(*sess.EndTime) {
+			v.Add("start_time", fmt.Sprintf("同じ計測の作業セッション（%s〜%s）と重なっています", formatLocalTime(o.StartTime), formatLocalTime(end)))
+			break
+		}
+	}
+	return v.Err()
+}
+
+/*
+ * 完了済みの計測実行の計測結果を作業セッションから再集計する（完了前は何もしない）
+ *
+ * @param ctx コンテキスト
+ * @param run 計測実行
+ * @return エラー
+ */
+func (s *WorkSessionService) syncRunRecord(ctx context.Context, run *model.Run) error {
+	if run.Status != model.RunStatusCompleted {
+		return nil
+	}
+
+	record, err := s.trepo.FindByRunID(ctx, run.ID)
+	if err != nil {
+		return err
+	}
+	sessions, err := s.wrepo.ListByRunID(ctx, run.ID)
+	if err != nil {
+		return err
+	}
+	sortSessions(sessions)
+
+	aggregated, err := aggregateSessions(run.ID, sessions)
+	if err != nil {
+		return err
+	}
+	record.StartTime = aggregated.StartTime
+	record.EndTime = aggregated.EndTime
+	record.Duration = aggregated.Duration
+	return s.trepo.Update(ctx, record)
+}
+
+/*
+ * 作業セッションを開始時刻順に並べる
+ */
+func sortSessions(sessions []*model.WorkSession) {
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].StartTime.Before(sessions[j].StartTime)
+	})
+}
+
+/*
+ * UUIDの一覧に含まれるか判定
+ */
+func containsUUID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
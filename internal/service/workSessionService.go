@@ -1,7 +1,10 @@
 package service
 
 import (
+	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"play-wails/internal/model"
 	"play-wails/internal/repository"
 	"time"
@@ -10,45 +13,112 @@ import (
 )
 
 type WorkSessionService struct {
-	wrepo repository.WorkSessionRepository
-	trepo repository.TimeRecordRepository
+	tx       repository.Transactor
+	wrepo    repository.WorkSessionRepository
+	trepo    repository.TimeRecordRepository
+	taskRepo repository.TaskRepository
+	runRepo  repository.RunRepository
+	policy   RunningPolicy
+	overlap  *OverlapService
 }
 
-func NewWorkSessionService(wrepo repository.WorkSessionRepository, trepo repository.TimeRecordRepository) *WorkSessionService {
-	return &WorkSessionService{wrepo: wrepo, trepo: trepo}
+func NewWorkSessionService(tx repository.Transactor, wrepo repository.WorkSessionRepository, trepo repository.TimeRecordRepository, taskRepo repository.TaskRepository, runRepo repository.RunRepository, policy RunningPolicy, overlap *OverlapService) *WorkSessionService {
+	return &WorkSessionService{tx: tx, wrepo: wrepo, trepo: trepo, taskRepo: taskRepo, runRepo: runRepo, policy: policy, overlap: overlap}
 }
 
 /*
  * 新規作業セッションを生成し、作業を開始する（新規 RunID を発行）
  *
+ * @param ctx コンテキスト
  * @param taskID タスクID
  * @return 作業セッション（RunID を含む）, エラー
  */
-func (s *WorkSessionService) Start(taskID uuid.UUID) (*model.WorkSession, error) {
-	runID := uuid.New()
+func (s *WorkSessionService) Start(ctx context.Context, taskID uuid.UUID) (*model.WorkSession, error) {
+	ctx = repository.WithAuditReason(ctx, "計測の開始")
+	now := time.Now()
+	run := model.NewRun(taskID, now)
 	session := &model.WorkSession{
 		ID:        uuid.New(),
-		RunID:     runID,
+		RunID:     run.ID,
 		TaskID:    taskID,
-		StartTime: time.Now(),
+		StartTime: now,
 		EndTime:   nil,
 	}
 
-	if err := s.wrepo.Create(session); err != nil {
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// 計測対象のタスクを検証
+		if err := validateTask(ctx, s.taskRepo, taskID); err != nil {
+			return err
+		}
+
+		// 計測中のセッションをポリシーに従って処理
+		if err := s.applyRunningPolicy(ctx, taskID, session.StartTime); err != nil {
+			return err
+		}
+
+		// 他の作業セッションとの重なりを確認（設定で拒否する場合のみ）
+		if err := s.overlap.Enforce(ctx, session); err != nil {
+			return err
+		}
+
+		// 計測実行を作成してからセッションを作成
+		if err := s.runRepo.Create(ctx, run); err != nil {
+			return err
+		}
+		return s.wrepo.Create(ctx, session)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return session, nil
 }
 
+// 計測対象にできないタスクのエラー（手入力では入力項目のエラーに置き換える）
+var (
+	errTaskNotFound        = errors.New("【ERROR】指定されたタスクが存在しません。")
+	errTaskArchived        = errors.New("【ERROR】アーカイブ済みのタスクでは計測を開始できません。")
+	errTaskProjectArchived = errors.New("【ERROR】アーカイブ済みのプロジェクトのタスクでは計測を開始できません。")
+)
+
+/*
+ * 計測対象のタスクが存在し、タスク・所属プロジェクトがアーカイブされていないか検証する
+ * 計測の開始と計測結果の手入力で共通の検証
+ *
+ * @param ctx コンテキスト
+ * @param taskRepo タスクリポジトリ
+ * @param taskID タスクID
+ * @return エラー（errTaskNotFound / errTaskArchived / errTaskProjectArchived、またはDBのエラー）
+ */
+func validateTask(ctx context.Context, taskRepo repository.TaskRepository, taskID uuid.UUID) error {
+	task, err := taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errTaskNotFound
+		}
+		return err
+	}
+	if task.Archived {
+		return errTaskArchived
+	}
+	if !task.IsSelectable() {
+		return errTaskProjectArchived
+	}
+	return nil
+}
+
 /*
  * 同一計測実行として作業を再開する（既存 RunID で新規 WorkSession を作成）
+ * 計測実行が存在し、一時停止中で、指定タスクのものである場合のみ再開できる
+ * タスク・プロジェクトが一時停止中にアーカイブされた場合は再開できない
  *
+ * @param ctx コンテキスト
  * @param taskID タスクID
  * @param runID 計測実行のグループID（開始時に発行した RunID）
  * @return 作業セッション, エラー
  */
-func (s *WorkSessionService) Resume(taskID uuid.UUID, runID uuid.UUID) (*model.WorkSession, error) {
+func (s *WorkSessionService) Resume(ctx context.Context, taskID uuid.UUID, runID uuid.UUID) (*model.WorkSession, error) {
+	ctx = repository.WithAuditReason(ctx, "計測の再開")
 	session := &model.WorkSession{
 		ID:        uuid.New(),
 		RunID:     runID,
@@ -57,54 +127,325 @@ func (s *WorkSessionService) Resume(taskID uuid.UUID, runID uuid.UUID) (*model.W
 		EndTime:   nil,
 	}
 
-	if err := s.wrepo.Create(session); err != nil {
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// 計測実行を検証
+		run, err := s.findRun(ctx, runID)
+		if err != nil {
+			return err
+		}
+		if run.TaskID != taskID {
+			return errors.New("【ERROR】計測実行と指定されたタスクが一致しません。")
+		}
+		if run.Status != model.RunStatusPaused {
+			return fmt.Errorf("【ERROR】計測実行の状態が %s のため再開できません。", run.Status)
+		}
+
+		// 一時停止中にタスク・プロジェクトがアーカイブされていないか検証
+		if err := validateTask(ctx, s.taskRepo, run.TaskID); err != nil {
+			return err
+		}
+
+		// 計測中のセッションをポリシーに従って処理
+		if err := s.applyRunningPolicy(ctx, taskID, session.StartTime); err != nil {
+			return err
+		}
+
+		// 他の作業セッションとの重なりを確認（設定で拒否する場合のみ）
+		if err := s.overlap.Enforce(ctx, session); err != nil {
+			return err
+		}
+
+		// 計測実行を計測中に戻してからセッションを作成
+		if err := run.Resume(session.StartTime); err != nil {
+			return err
+		}
+		if err := s.runRepo.Update(ctx, run); err != nil {
+			return err
+		}
+		return s.wrepo.Create(ctx, session)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return session, nil
 }
 
+/*
+ * 計測中のセッションを計測ポリシーに従って処理する
+ * - reject: 計測中のセッションがあればエラー
+ * - auto_stop: 計測中のセッションを開始時刻で停止
+ * - parallel: 同一タスクの計測中セッションがあればエラー
+ *
+ * @param ctx コンテキスト
+ * @param taskID 開始するタスクID
+ * @param now 開始時刻
+ * @return エラー
+ */
+func (s *WorkSessionService) applyRunningPolicy(ctx context.Context, taskID uuid.UUID, now time.Time) error {
+	running, err := s.wrepo.FindRunning(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, sess := range running {
+		switch s.policy {
+		case RunningPolicyAutoStop:
+			if err := s.stopSession(ctx, sess, now); err != nil {
+				return err
+			}
+		case RunningPolicyParallel:
+			if sess.TaskID == taskID {
+				return errors.New("【ERROR】このタスクは既に計測中です。")
+			}
+		default:
+			return errors.New("【ERROR】計測中のセッションがあります。停止してから開始してください。")
+		}
+	}
+	return nil
+}
+
 /*
  * 作業セッションを停止する
  *
+ * @param ctx コンテキスト
  * @param sessionID 作業セッションID
  * @return エラー
  * @error エラー
  */
-func (s *WorkSessionService) Stop(sessionID uuid.UUID) error {
-	session, err := s.wrepo.FindByID(sessionID)
-	if err != nil {
+func (s *WorkSessionService) Stop(ctx context.Context, sessionID uuid.UUID) error {
+	return s.StopAt(ctx, sessionID, time.Now())
+}
+
+/*
+ * 作業セッションを指定時刻で停止する
+ * クラッシュ復旧で最終ハートビート時刻などに停止する場合に使用する
+ *
+ * @param ctx コンテキスト
+ * @param sessionID 作業セッションID
+ * @param at 停止時刻
+ * @return エラー
+ */
+func (s *WorkSessionService) StopAt(ctx context.Context, sessionID uuid.UUID, at time.Time) error {
+	ctx = repository.WithAuditReason(ctx, "計測の停止")
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		session, err := s.wrepo.FindByID(ctx, sessionID)
+		if err != nil {
+			return err
+		}
+
+		return s.stopSession(ctx, session, at)
+	})
+}
+
+/*
+ * 作業セッションを停止し、計測実行を一時停止にする
+ *
+ * @param ctx コンテキスト
+ * @param session 作業セッション
+ * @param now 停止時刻
+ * @return エラー
+ */
+func (s *WorkSessionService) stopSession(ctx context.Context, session *model.WorkSession, now time.Time) error {
+	if err := session.Stop(now); err != nil {
+		return err
+	}
+	if err := s.wrepo.Update(ctx, session); err != nil {
 		return err
 	}
 
-	if err := session.Stop(time.Now()); err != nil {
+	run, err := s.findRun(ctx, session.RunID)
+	if err != nil {
+		return err
+	}
+	if run.Status != model.RunStatusRunning {
+		return nil
+	}
+	if err := run.Pause(now); err != nil {
 		return err
 	}
+	return s.runRepo.Update(ctx, run)
+}
+
+/*
+ * 計測実行を取得する
+ *
+ * @param ctx コンテキスト
+ * @param runID 計測実行のグループID
+ * @return 計測実行, エラー
+ */
+func (s *WorkSessionService) findRun(ctx context.Context, runID uuid.UUID) (*model.Run, error) {
+	run, err := s.runRepo.FindByID(ctx, runID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("【ERROR】指定された計測実行が存在しません。")
+	}
+	return run, err
+}
 
-	return s.wrepo.Update(session)
+/*
+ * 計測実行を取得する
+ *
+ * @param ctx コンテキスト
+ * @param runID 計測実行のグループID
+ * @return 計測実行, エラー
+ */
+func (s *WorkSessionService) Run(ctx context.Context, runID uuid.UUID) (*model.Run, error) {
+	return s.findRun(ctx, runID)
+}
+
+/*
+ * 計測を取り消す
+ * 計測中のセッションは停止し、計測結果は作成しない
+ *
+ * @param ctx コンテキスト
+ * @param runID 計測実行のグループID
+ * @return エラー
+ */
+func (s *WorkSessionService) Cancel(ctx context.Context, runID uuid.UUID) error {
+	ctx = repository.WithAuditReason(ctx, "計測の取消")
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+
+		run, err := s.findRun(ctx, runID)
+		if err != nil {
+			return err
+		}
+		if err := run.Cancel(now); err != nil {
+			return err
+		}
+
+		// 計測中のセッションを停止
+		sessions, err := s.wrepo.ListByRunID(ctx, runID)
+		if err != nil {
+			return err
+		}
+		for _, sess := range sessions {
+			if !sess.IsRunning() {
+				continue
+			}
+			if err := sess.Stop(now); err != nil {
+				return err
+			}
+			if err := s.wrepo.Update(ctx, sess); err != nil {
+				return err
+			}
+		}
+
+		return s.runRepo.Update(ctx, run)
+	})
+}
+
+/*
+ * 計測中の作業セッション一覧を取得する（開始時刻の新しい順）
+ * 起動時に前回から計測中のタイマーを復元するために使用する
+ *
+ * @param ctx コンテキスト
+ * @return 作業セッション一覧, エラー
+ */
+func (s *WorkSessionService) Running(ctx context.Context) ([]*model.WorkSession, error) {
+	return s.wrepo.FindRunning(ctx)
+}
+
+/*
+ * 計測中の作業セッションを取得する（最後に開始したもの。なければnil）
+ *
+ * @param ctx コンテキスト
+ * @return 作業セッション, エラー
+ */
+func (s *WorkSessionService) Active(ctx context.Context) (*model.WorkSession, error) {
+	running, err := s.wrepo.FindRunning(ctx)
+	if err != nil || len(running) == 0 {
+		return nil, err
+	}
+	return running[0], nil
 }
 
 /*
  * 作業セッションを取得する
  *
+ * @param ctx コンテキスト
  * @param sessionID 作業セッションID
  * @return 作業セッション, エラー
  */
-func (s *WorkSessionService) Current(sessionID uuid.UUID) (*model.WorkSession, error) {
-	return s.wrepo.FindByID(sessionID)
+func (s *WorkSessionService) Current(ctx context.Context, sessionID uuid.UUID) (*model.WorkSession, error) {
+	return s.wrepo.FindByID(ctx, sessionID)
 }
 
 /*
  * 計測を完了し、同一 RunID の全 WorkSession の累計で TimeRecord を1件作成する
+ * 既に完了済みの RunID の場合は作成済みの TimeRecord を返す（二重完了の防止、ゴミ箱にある場合はエラー）
  *
+ * @param ctx コンテキスト
  * @param runID 計測実行のグループID
  * @return 作成した TimeRecord, エラー
  */
-func (s *WorkSessionService) Complete(runID uuid.UUID) (*model.TimeRecord, error) {
-	sessions, err := s.wrepo.ListByRunID(runID)
+func (s *WorkSessionService) Complete(ctx context.Context, runID uuid.UUID) (*model.TimeRecord, error) {
+	ctx = repository.WithAuditReason(ctx, "計測の完了")
+	var record *model.TimeRecord
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// 完了済みの場合は既存の計測結果を返す（ゴミ箱にある場合は新たに作成したものと区別できるようエラー）
+		existing, err := s.trepo.FindByRunID(ctx, runID)
+		if err == nil {
+			if existing.DeleteFlag {
+				return errors.New("【ERROR】この計測は完了済みで、計測結果はゴミ箱にあります。ゴミ箱から復元してください。")
+			}
+			record = existing
+			return nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		run, err := s.findRun(ctx, runID)
+		if err != nil {
+			return err
+		}
+
+		sessions, err := s.wrepo.ListByRunID(ctx, runID)
+		if err != nil {
+			return err
+		}
+
+		// 時間計測モデルを作成
+		created, err := aggregateSessions(runID, sessions)
+		if err != nil {
+			return err
+		}
+
+		// 計測実行を完了にする（一時停止中のみ）
+		if err := run.Complete(time.Now()); err != nil {
+			return err
+		}
+		if err := s.runRepo.Update(ctx, run); err != nil {
+			return err
+		}
+
+		// 時間計測レコードを作成
+		if err := s.trepo.Create(ctx, created); err != nil {
+			return err
+		}
+		record = created
+		return nil
+	})
 	if err != nil {
+		// 同時に完了された場合は一意制約で作成に失敗するため、作成済みのものを返す
+		if existing, findErr := s.trepo.FindByRunID(ctx, runID); findErr == nil && !existing.DeleteFlag {
+			return existing, nil
+		}
 		return nil, err
 	}
+
+	return record, nil
+}
+
+/*
+ * 同一 RunID の作業セッションを累計し、TimeRecord を組み立てる
+ *
+ * @param runID 計測実行のグループID
+ * @param sessions 作業セッション一覧（開始時刻順）
+ * @return 時間計測, エラー
+ */
+func aggregateSessions(runID uuid.UUID, sessions []*model.WorkSession) (*model.TimeRecord, error) {
 	if len(sessions) == 0 {
 		return nil, errors.New("該当する作業セッションがありません")
 	}
@@ -135,8 +476,7 @@ func (s *WorkSessionService) Complete(runID uuid.UUID) (*model.TimeRecord, error
 		}
 	}
 
-	// 時間計測モデルを作成
-	record := &model.TimeRecord{
+	return &model.TimeRecord{
 		ID:         uuid.New(),
 		RunID:      runID,
 		TaskID:     taskID,
@@ -144,12 +484,5 @@ func (s *WorkSessionService) Complete(runID uuid.UUID) (*model.TimeRecord, error
 		StartTime:  firstStart,
 		EndTime:    lastEnd,
 		Duration:   total,
-	}
-
-	// 時間計測レコードを作成
-	if err := s.trepo.Create(record); err != nil {
-		return nil, err
-	}
-
-	return record, nil
+	}, nil
 }
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"play-wails/internal/repository"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestWorkSessionService_Resume_ArchivedTask(t *testing.T) {
+	tests := []struct {
+		name    string
+		archive func(ctx context.Context, tasks *TaskService, projects *ProjectService, taskID, projectID uuid.UUID) error
+		wantErr error
+	}{
+		{
+			name:    "アーカイブされていない",
+			archive: func(context.Context, *TaskService, *ProjectService, uuid.UUID, uuid.UUID) error { return nil },
+		},
+		{
+			name: "タスクをアーカイブ",
+			archive: func(ctx context.Context, tasks *TaskService, _ *ProjectService, taskID, _ uuid.UUID) error {
+				return tasks.SetArchived(ctx, taskID, true)
+			},
+			wantErr: errTaskArchived,
+		},
+		{
+			name: "プロジェクトをアーカイブ",
+			archive: func(ctx context.Context, _ *TaskService, projects *ProjectService, _, projectID uuid.UUID) error {
+				return projects.SetArchived(ctx, projectID, true)
+			},
+			wantErr: errTaskProjectArchived,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			conn := openTestDB(t)
+			taskRepo := repository.NewTaskRepositoryImpl(conn)
+			projectRepo := repository.NewProjectRepositoryImpl(conn)
+			wrepo := repository.NewWorkSessionRepositoryImpl(conn)
+			tasks := NewTaskService(taskRepo, projectRepo)
+			projects := NewProjectService(projectRepo, repository.NewClientRepositoryImpl(conn))
+			s := NewWorkSessionService(repository.NewTransactor(conn), wrepo, repository.NewTimeRecordRepositoryImpl(conn), taskRepo,
+				repository.NewRunRepositoryImpl(conn), RunningPolicyReject, NewOverlapService(wrepo, taskRepo, OverlapPolicyReject))
+
+			project, err := projects.Create(ctx, "Website", nil)
+			if err != nil {
+				t.Fatalf("プロジェクトを作成できません: %v", err)
+			}
+			task := createTestTask(t, taskRepo, "設計")
+			if _, err := tasks.SetProject(ctx, task.ID, &project.ID); err != nil {
+				t.Fatalf("プロジェクトを設定できません: %v", err)
+			}
+
+			// 計測を開始して一時停止し、その間にアーカイブする
+			session, err := s.Start(ctx, task.ID)
+			if err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+			if err := s.Stop(ctx, session.ID); err != nil {
+				t.Fatalf("Stop() error = %v", err)
+			}
+			if err := tt.archive(ctx, tasks, projects, task.ID, project.ID); err != nil {
+				t.Fatalf("アーカイブできません: %v", err)
+			}
+
+			_, err = s.Resume(ctx, task.ID, session.RunID)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("Resume() error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Resume() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
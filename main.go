@@ -4,25 +4,146 @@ import (
 	"context"
 	"embed"
 	"log"
+	"os"
+	"os/user"
 	"play-wails/infarstructure/db"
+	"play-wails/internal/controller"
+	"play-wails/internal/model"
+	"play-wails/internal/repository"
+	"play-wails/internal/service"
+	"time"
 
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
 	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 var assets embed.FS
 
+// フロントからの1回の呼び出しのタイムアウト
+const callTimeout = 10 * time.Second
+
 func main() {
 
 	// TursoDBを起動
-	db, err := db.NewTursoDB()
+	tursoDB, err := db.NewTursoDB()
 	if err != nil {
 		log.Fatal("【ERROR】TursoDBの起動に失敗しました")
 		return
 	}
 
-	app := NewApp(db)
+	// スキーマのマイグレーションを適用
+	if err := migrate(tursoDB); err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	// オフラインモードではローカルの変更をバックグラウンドでTursoへ同期
+	var syncer *db.Syncer
+	if tursoDB.Mode() == db.ModeOffline {
+		syncer, err = db.NewSyncer(tursoDB)
+		if err != nil {
+			log.Fatal(err)
+			return
+		}
+		if err := syncer.Start(context.Background()); err != nil {
+			log.Fatal(err)
+			return
+		}
+	}
+
+	// コントローラが共有するWailsのコンテキスト（呼び出しごとにタイムアウトを設定）
+	appCtx := controller.NewAppContext(callTimeout)
+
+	// 計測中に別の計測を開始した場合の扱い（SESSION_POLICY: reject / auto_stop / parallel）
+	runningPolicy, err := service.ParseRunningPolicy(os.Getenv("SESSION_POLICY"))
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	// 作業セッションの時間の重なりの扱い（OVERLAP_POLICY: allow / reject）
+	overlapPolicy, err := service.ParseOverlapPolicy(os.Getenv("OVERLAP_POLICY"))
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+	if overlapPolicy == service.OverlapPolicyReject && runningPolicy == service.RunningPolicyParallel {
+		log.Fatal("【ERROR】SESSION_POLICY=parallel と OVERLAP_POLICY=reject は同時に指定できません")
+		return
+	}
+
+	// 異常終了で停止されなかったセッションの扱い（RECOVERY_MODE: prompt / heartbeat / shutdown）
+	recoveryMode, err := service.ParseRecoveryMode(os.Getenv("RECOVERY_MODE"))
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	// ゴミ箱の計測結果を完全に削除するまでの日数（TRASH_RETENTION_DAYS: 既定30、0の場合は削除しない）
+	trashRetention, err := service.ParseTrashRetention(os.Getenv("TRASH_RETENTION_DAYS"))
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	// リポジトリ → サービス → コントローラの順に組み立てる
+	transactor := repository.NewTransactor(tursoDB.DB())
+	clientRepo := repository.NewClientRepositoryImpl(tursoDB.DB())
+	projectRepo := repository.NewProjectRepositoryImpl(tursoDB.DB())
+	taskRepo := repository.NewTaskRepositoryImpl(tursoDB.DB())
+	tagRepo := repository.NewTagRepositoryImpl(tursoDB.DB())
+	rateRepo := repository.NewRateRepositoryImpl(tursoDB.DB())
+	invoiceRepo := repository.NewInvoiceRepositoryImpl(tursoDB.DB())
+	runRepo := repository.NewRunRepositoryImpl(tursoDB.DB())
+	auditLogRepo := repository.NewAuditLogRepositoryImpl(tursoDB.DB())
+	// 計測結果・作業セッションの変更は変更履歴に記録する
+	actor := auditActor()
+	workSessionRepo := repository.NewAuditedWorkSessionRepository(repository.NewWorkSessionRepositoryImpl(tursoDB.DB()), transactor, auditLogRepo, actor)
+	timeRecordRepo := repository.NewAuditedTimeRecordRepository(repository.NewTimeRecordRepositoryImpl(tursoDB.DB()), transactor, auditLogRepo, actor)
+	appStateRepo := repository.NewAppStateRepositoryImpl(tursoDB.DB())
+	importFingerprintRepo := repository.NewImportFingerprintRepositoryImpl(tursoDB.DB())
+
+	clientService := service.NewClientService(clientRepo)
+	projectService := service.NewProjectService(projectRepo, clientRepo)
+	taskService := service.NewTaskService(taskRepo, projectRepo)
+	overlapService := service.NewOverlapService(workSessionRepo, taskRepo, overlapPolicy)
+	workSessionService := service.NewWorkSessionService(transactor, workSessionRepo, timeRecordRepo, taskRepo, runRepo, runningPolicy, overlapService)
+	timeRecordService := service.NewTimeRecordService(transactor, timeRecordRepo, workSessionRepo, taskRepo, runRepo, overlapService)
+	reportService := service.NewReportService(timeRecordRepo, workSessionRepo, taskRepo, projectRepo, clientRepo, tagRepo)
+	exportService := service.NewExportService(timeRecordRepo, workSessionRepo, taskRepo)
+	importService := service.NewImportService(transactor, taskRepo, runRepo, workSessionRepo, timeRecordRepo, importFingerprintRepo)
+	recoveryService := service.NewRecoveryService(workSessionRepo, appStateRepo, workSessionService, recoveryMode)
+	auditService := service.NewAuditService(auditLogRepo, timeRecordRepo)
+	tagService := service.NewTagService(transactor, tagRepo, taskRepo, timeRecordRepo)
+	billingService := service.NewBillingService(rateRepo, timeRecordRepo, workSessionRepo, taskRepo, projectRepo, clientRepo)
+	invoiceService := service.NewInvoiceService(transactor, invoiceRepo, timeRecordRepo, clientRepo, projectRepo, billingService)
+	// ポモドーロのフェーズが変わるたびにフロントへ通知する
+	pomodoroService := service.NewPomodoroService(workSessionService, func(state model.PomodoroState) {
+		runtime.EventsEmit(appCtx.Context(), "pomodoro:phase", state)
+	})
+	trashService := service.NewTrashService(transactor, timeRecordRepo, workSessionRepo, runRepo, taskRepo, importFingerprintRepo, overlapService, trashRetention)
+
+	app := NewApp(tursoDB, appCtx, recoveryService, trashService, pomodoroService)
+
+	clientController := controller.NewClientController(appCtx, clientService)
+	projectController := controller.NewProjectController(appCtx, projectService)
+	taskController := controller.NewTaskController(appCtx, taskService)
+	workSessionController := controller.NewWorkSessionController(appCtx, workSessionService)
+	timeRecordController := controller.NewTimeRecordController(appCtx, timeRecordService)
+	syncController := controller.NewSyncController(appCtx, syncer)
+	recoveryController := controller.NewRecoveryController(appCtx, recoveryService)
+	reportController := controller.NewReportController(appCtx, reportService)
+	exportController := controller.NewExportController(appCtx, exportService)
+	importController := controller.NewImportController(appCtx, importService)
+	overlapController := controller.NewOverlapController(appCtx, overlapService)
+	auditController := controller.NewAuditController(appCtx, auditService)
+	trashController := controller.NewTrashController(appCtx, trashService)
+	tagController := controller.NewTagController(appCtx, tagService)
+	billingController := controller.NewBillingController(appCtx, billingService)
+	invoiceController := controller.NewInvoiceController(appCtx, invoiceService)
+	pomodoroController := controller.NewPomodoroController(appCtx, pomodoroService)
 
 	err = wails.Run(&options.App{
 		Title:  "ToDo App",
@@ -32,11 +153,40 @@ func main() {
 			Assets: assets,
 		},
 		BackgroundColour: &options.RGBA{R: 27, G: 38, B: 54, A: 1},
+		// 入力チェックのエラーは項目ごとのエラーをフロントに返す
+		ErrorFormatter: controller.FormatError,
 		Bind: []interface{}{
 			app,
+			clientController,
+			projectController,
+			taskController,
+			workSessionController,
+			timeRecordController,
+			syncController,
+			recoveryController,
+			reportController,
+			exportController,
+			importController,
+			overlapController,
+			auditController,
+			trashController,
+			tagController,
+			billingController,
+			invoiceController,
+			pomodoroController,
 		},
 		OnShutdown: func(ctx context.Context) {
-			err := db.Close()
+			// 終了時刻を保存
+			app.shutdown(ctx)
+
+			// 同期を停止してからDBをクローズ
+			if syncer != nil {
+				if err := syncer.Stop(); err != nil {
+					log.Println("【ERROR】同期処理の停止に失敗しました")
+				}
+			}
+
+			err := tursoDB.Close()
 			if err != nil {
 				log.Fatal("【ERROR】TursoDBのクローズに失敗しました")
 				return
@@ -50,3 +200,50 @@ func main() {
 		println("Error:", err.Error())
 	}
 }
+
+/*
+ * 起動時にスキーマのマイグレーションを適用する
+ * DB_MIGRATE_DRY_RUN=true の場合は未適用の一覧を出力して終了する
+ *
+ * @param tursoDB DB
+ * @return エラー
+ */
+func migrate(tursoDB *db.TursoDB) error {
+	ctx := context.Background()
+
+	// ドライラン：未適用のマイグレーションを一覧表示するのみ
+	if os.Getenv("DB_MIGRATE_DRY_RUN") == "true" {
+		pending, err := tursoDB.PendingMigrations(ctx)
+		if err != nil {
+			return err
+		}
+		if len(pending) == 0 {
+			log.Println("【INFO】未適用のマイグレーションはありません")
+		}
+		for _, m := range pending {
+			log.Printf("【INFO】未適用: %04d_%s", m.Version, m.Name)
+		}
+		os.Exit(0)
+	}
+
+	applied, err := tursoDB.Migrate(ctx)
+	for _, m := range applied {
+		log.Printf("【INFO】マイグレーションを適用しました: %04d_%s", m.Version, m.Name)
+	}
+	return err
+}
+
+/*
+ * 変更履歴に記録する変更した端末（ユーザー名@ホスト名）
+ * 取得できない値は空にする
+ *
+ * @return ユーザー名@ホスト名
+ */
+func auditActor() string {
+	name := ""
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	host, _ := os.Hostname()
+	return name + "@" + host
+}